@@ -1,8 +1,10 @@
 package auth
 
 import (
+	"bytes"
 	"net/http"
 
+	"github.com/friendsofgo/errors"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
@@ -12,11 +14,18 @@ import (
 
 func router(s *Service) *gin.Engine {
 	var router = gin.Default()
+	// gin trusts forwarded headers of all proxies by default, which lets clients choose their IP
+	err := router.SetTrustedProxies(s.TrustedProxies)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid trusted proxies, trusting none")
+		router.SetTrustedProxies(nil)
+	}
 
 	config := cors.DefaultConfig()
 	config.AddAllowMethods("PUT", "PATCH", "GET", "POST", "DELETE", "OPTIONS")
 	config.AddAllowHeaders("Authorization")
 	config.AddAllowHeaders(roles.RoleHeader)
+	config.AddAllowHeaders(tokens.APIKeyHeader)
 	if s.release {
 		config.AllowOriginFunc = func(origin string) bool {
 			_, ok := s.AllowOrigins[origin]
@@ -33,18 +42,143 @@ func router(s *Service) *gin.Engine {
 	api := router.Group("/")
 
 	// without authorization middleware
+	api.GET("/.well-known/jwks.json", func(ctx *gin.Context) {
+		JWKSHandler(ctx, s)
+	})
+	api.GET("/.well-known/openid-configuration", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, s.OpenIDConfiguration())
+	})
+	api.GET("/authorize", func(ctx *gin.Context) {
+		AuthorizeHandler(ctx, s)
+	})
+	api.POST("/token", func(ctx *gin.Context) {
+		TokenHandler(ctx, s)
+	})
 	api.PUT("/signup", func(ctx *gin.Context) {
 		SignupHandler(ctx, s)
 	})
 	api.POST("/login", func(ctx *gin.Context) {
 		LoginHandler(ctx, s)
 	})
+	api.POST("/login/2fa", func(ctx *gin.Context) {
+		LoginTwoFactorHandler(ctx, s)
+	})
+	api.POST("/login/2fa/enroll", func(ctx *gin.Context) {
+		EnrollTOTPForLoginHandler(ctx, s)
+	})
+	api.POST("/login/passkey/options", func(ctx *gin.Context) {
+		PasskeyLoginOptionsHandler(ctx, s)
+	})
+	api.POST("/login/passkey", func(ctx *gin.Context) {
+		LoginPasskeyHandler(ctx, s)
+	})
+	api.GET("/login/oidc/providers", func(ctx *gin.Context) {
+		IdentityProvidersHandler(ctx, s)
+	})
+	api.POST("/login/oidc", func(ctx *gin.Context) {
+		OIDCLoginHandler(ctx, s)
+	})
+	api.GET("/login/oidc/callback", func(ctx *gin.Context) {
+		OIDCCallbackHandler(ctx, s)
+	})
 	api.POST("/refresh", func(ctx *gin.Context) {
 		RefreshHandler(ctx, s)
 	})
+	api.POST("/refresh/instance", func(ctx *gin.Context) {
+		SwitchInstanceHandler(ctx, s)
+	})
+	api.GET("/verify", func(ctx *gin.Context) {
+		VerifyHandler(ctx, s)
+	})
+	api.POST("/verify", func(ctx *gin.Context) {
+		VerifyHandler(ctx, s)
+	})
+	api.POST("/verify/resend", func(ctx *gin.Context) {
+		ResendVerificationHandler(ctx, s)
+	})
+	api.POST("/password/forgot", func(ctx *gin.Context) {
+		ForgotPasswordHandler(ctx, s)
+	})
+	api.POST("/password/reset", func(ctx *gin.Context) {
+		ResetPasswordHandler(ctx, s)
+	})
+	api.GET("/password/policy", func(ctx *gin.Context) {
+		PasswordPolicyHandler(ctx, s)
+	})
+	api.POST("/invitations/accept", func(ctx *gin.Context) {
+		AcceptInvitationHandler(ctx, s)
+	})
+	api.GET(tokens.APIKeyVerifyPath, func(ctx *gin.Context) {
+		VerifyAPIKeyHandler(ctx, s)
+	})
+	api.GET(tokens.DenylistPath, func(ctx *gin.Context) {
+		DenylistHandler(ctx, s)
+	})
+
+	// with authorization middleware, rejecting revoked access tokens and deleted API keys immediately
+	authorize := tokens.AuthorizeJWTWithDenylist(s.TokenAPI.ValidationKeys, s.Issuer, s.Audience, s.Denylist, s)
+	api.POST("/authorize", authorize, func(ctx *gin.Context) {
+		ApproveAuthorizationHandler(ctx, s)
+	})
+	api.POST("/unlock", authorize, func(ctx *gin.Context) {
+		UnlockLoginHandler(ctx, s)
+	})
+	api.GET("/userinfo", authorize, func(ctx *gin.Context) {
+		UserinfoHandler(ctx, s)
+	})
+	api.POST("/userinfo", authorize, func(ctx *gin.Context) {
+		UserinfoHandler(ctx, s)
+	})
+	meAPI := api.Group("/me", authorize)
+	{
+		meAPI.GET("", func(ctx *gin.Context) {
+			MeHandler(ctx, s)
+		})
+		meAPI.PATCH("", func(ctx *gin.Context) {
+			UpdateMeHandler(ctx, s)
+		})
+		meAPI.DELETE("", func(ctx *gin.Context) {
+			EraseMeHandler(ctx, s)
+		})
+		meAPI.GET("/export", func(ctx *gin.Context) {
+			ExportMeHandler(ctx, s)
+		})
+		meAPI.POST("/password", func(ctx *gin.Context) {
+			ChangePasswordHandler(ctx, s)
+		})
+		meAPI.POST("/2fa", func(ctx *gin.Context) {
+			EnrollTOTPHandler(ctx, s)
+		})
+		meAPI.POST("/2fa/confirm", func(ctx *gin.Context) {
+			ConfirmTOTPHandler(ctx, s)
+		})
+		meAPI.DELETE("/2fa", func(ctx *gin.Context) {
+			DisableTOTPHandler(ctx, s)
+		})
+		meAPI.GET("/passkeys", func(ctx *gin.Context) {
+			PasskeysHandler(ctx, s)
+		})
+		meAPI.POST("/passkeys/options", func(ctx *gin.Context) {
+			PasskeyRegistrationOptionsHandler(ctx, s)
+		})
+		meAPI.POST("/passkeys", func(ctx *gin.Context) {
+			RegisterPasskeyHandler(ctx, s)
+		})
+		meAPI.DELETE("/passkeys/:id", func(ctx *gin.Context) {
+			DeletePasskeyHandler(ctx, s)
+		})
+		meAPI.GET("/apikeys", func(ctx *gin.Context) {
+			APIKeysHandler(ctx, s)
+		})
+		meAPI.POST("/apikeys", func(ctx *gin.Context) {
+			CreateAPIKeyHandler(ctx, s)
+		})
+		meAPI.DELETE("/apikeys/:id", func(ctx *gin.Context) {
+			DeleteAPIKeyHandler(ctx, s)
+		})
+	}
 
-	// with authorization middleware
-	tokenAPI := api.Group("/revoke", tokens.AuthorizeJWT(s.TokenAPI.ValidationKey, s.Issuer, s.Audience))
+	tokenAPI := api.Group("/revoke", authorize)
 	{
 		tokenAPI.DELETE("/", func(ctx *gin.Context) {
 			RevokeHandler(ctx, s)
@@ -54,14 +188,105 @@ func router(s *Service) *gin.Engine {
 		})
 	}
 
+	sessionAPI := api.Group("/sessions", authorize)
+	{
+		sessionAPI.GET("", func(ctx *gin.Context) {
+			SessionsHandler(ctx, s)
+		})
+		sessionAPI.DELETE("/:id", func(ctx *gin.Context) {
+			RevokeSessionHandler(ctx, s)
+		})
+	}
+
+	serviceAccountAPI := api.Group("/service-accounts", authorize)
+	{
+		serviceAccountAPI.GET("", func(ctx *gin.Context) {
+			ServiceAccountsHandler(ctx, s)
+		})
+		serviceAccountAPI.POST("", func(ctx *gin.Context) {
+			CreateServiceAccountHandler(ctx, s)
+		})
+		serviceAccountAPI.POST("/:id/secret", func(ctx *gin.Context) {
+			RotateServiceAccountSecretHandler(ctx, s)
+		})
+		serviceAccountAPI.DELETE("/:id", func(ctx *gin.Context) {
+			DeleteServiceAccountHandler(ctx, s)
+		})
+	}
+
+	instanceAPI := api.Group("/instances", authorize)
+	{
+		instanceAPI.GET("", func(ctx *gin.Context) {
+			InstancesHandler(ctx, s)
+		})
+		instanceAPI.POST("", func(ctx *gin.Context) {
+			CreateInstanceHandler(ctx, s)
+		})
+		instanceAPI.PATCH("/:id", func(ctx *gin.Context) {
+			UpdateInstanceHandler(ctx, s)
+		})
+		instanceAPI.DELETE("/:id", func(ctx *gin.Context) {
+			DeleteInstanceHandler(ctx, s)
+		})
+	}
+
+	memberAPI := api.Group("/members", authorize)
+	{
+		memberAPI.GET("", func(ctx *gin.Context) {
+			MembersHandler(ctx, s)
+		})
+		memberAPI.PATCH("/:id", func(ctx *gin.Context) {
+			ChangeMemberRoleHandler(ctx, s)
+		})
+		memberAPI.DELETE("/:id", func(ctx *gin.Context) {
+			RemoveMemberHandler(ctx, s)
+		})
+	}
+
+	invitationAPI := api.Group("/invitations", authorize)
+	{
+		invitationAPI.GET("", func(ctx *gin.Context) {
+			InvitationsHandler(ctx, s)
+		})
+		invitationAPI.POST("", func(ctx *gin.Context) {
+			InviteHandler(ctx, s)
+		})
+		invitationAPI.DELETE("/:id", func(ctx *gin.Context) {
+			DeleteInvitationHandler(ctx, s)
+		})
+	}
+
 	return router
 }
 
+// JWKSHandler serves the public keys to validate tokens with.
+func JWKSHandler(ctx *gin.Context, s *Service) {
+	ctx.JSON(http.StatusOK, s.TokenAPI.JWKS())
+}
+
+// DenylistHandler serves the IDs of revoked access tokens that did not expire yet, for other services to reject them.
+func DenylistHandler(ctx *gin.Context, s *Service) {
+	denied, err := s.DBAPI.LoadDenylist(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.JSON(http.StatusOK, tokens.DenylistResponse{Denied: denied})
+}
+
 // SignupHandler creates a new user.
 func SignupHandler(ctx *gin.Context, s *Service) {
 	userID, err := s.Signup(ctx)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("")
+		if abortWithPolicyViolation(ctx, err) {
+			return
+		}
+		if errors.Is(err, ErrInvalidEmail) {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
 		ctx.AbortWithStatus(http.StatusUnauthorized)
 	} else {
 		ctx.JSON(http.StatusCreated, gin.H{"userID": userID})
@@ -70,23 +295,69 @@ func SignupHandler(ctx *gin.Context, s *Service) {
 
 // LoginHandler logs a user in and returs a fresh set of tokens.
 func LoginHandler(ctx *gin.Context, s *Service) {
-	accessToken, refreshToken, role, err := s.Login(ctx)
+	accessToken, refreshToken, role, instances, challenge, err := s.Login(ctx)
 	if err != nil {
+		if errors.Is(err, ErrUserNotActivated) {
+			// credentials were correct, so it is safe to tell the user to verify the email first
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		// locked out logins are rejected like wrong credentials, so they do not reveal which emails have an account
 		ctx.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
+	if challenge != nil {
+		ctx.JSON(http.StatusOK, challenge)
+		return
+	}
 
-	ctx.JSON(http.StatusOK, gin.H{
+	res := gin.H{
 		"accessToken":  accessToken,
 		"refreshToken": refreshToken,
 		"role":         role,
 		"rolesSpec":    roles.RolesSpec(role),
-	})
+	}
+	if instances != nil {
+		res["instances"] = instances
+	}
+	ctx.JSON(http.StatusOK, res)
 }
 
-// RefreshHandler refreshes a user's access token.
+// LoginTwoFactorHandler completes a login requiring a second factor and returns a fresh set of tokens.
+func LoginTwoFactorHandler(ctx *gin.Context, s *Service) {
+	accessToken, refreshToken, role, recoveryCodes, err := s.LoginTwoFactor(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	res := gin.H{
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
+		"role":         role,
+		"rolesSpec":    roles.RolesSpec(role),
+	}
+	if recoveryCodes != nil {
+		res["recoveryCodes"] = recoveryCodes
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// EnrollTOTPForLoginHandler starts the TOTP enrollment required to complete a pending login.
+func EnrollTOTPForLoginHandler(ctx *gin.Context, s *Service) {
+	enrollment, err := s.EnrollTOTPForLogin(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, enrollment)
+	}
+}
+
+// RefreshHandler refreshes a user's access token and rotates the refresh token.
 func RefreshHandler(ctx *gin.Context, s *Service) {
-	accessToken, err := s.Refresh(ctx)
+	accessToken, refreshToken, err := s.Refresh(ctx)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("")
 		// Different errors might allow to differentiate between the user does not exist or the provided credentials are wrong.
@@ -94,11 +365,167 @@ func RefreshHandler(ctx *gin.Context, s *Service) {
 		ctx.AbortWithStatus(http.StatusUnauthorized)
 	} else {
 		ctx.JSON(http.StatusOK, gin.H{
-			"accessToken": accessToken,
+			"accessToken":  accessToken,
+			"refreshToken": refreshToken,
 		})
 	}
 }
 
+// SwitchInstanceHandler exchanges a refresh token for a fresh set of tokens scoped to another instance of the user.
+func SwitchInstanceHandler(ctx *gin.Context, s *Service) {
+	accessToken, refreshToken, role, challenge, err := s.SwitchInstance(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrUserNotActivated) {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	if challenge != nil {
+		ctx.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
+		"role":         role,
+		"rolesSpec":    roles.RolesSpec(role),
+	})
+}
+
+// VerifyHandler activates a user by the token received by mail.
+func VerifyHandler(ctx *gin.Context, s *Service) {
+	userID, err := s.Verify(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"userID": userID})
+	}
+}
+
+// ResendVerificationHandler sends a new verification mail.
+func ResendVerificationHandler(ctx *gin.Context, s *Service) {
+	err := s.ResendVerification(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+	}
+	// Always succeed to not leak who created an account on the platform!
+	ctx.Status(http.StatusAccepted)
+}
+
+// ForgotPasswordHandler sends a password reset mail.
+func ForgotPasswordHandler(ctx *gin.Context, s *Service) {
+	err := s.ForgotPassword(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+	}
+	// Always succeed to not leak who created an account on the platform!
+	ctx.Status(http.StatusAccepted)
+}
+
+// ResetPasswordHandler sets a new password by the token received by mail.
+func ResetPasswordHandler(ctx *gin.Context, s *Service) {
+	err := s.ResetPassword(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if abortWithPolicyViolation(ctx, err) {
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// MeHandler returns the authorized user.
+func MeHandler(ctx *gin.Context, s *Service) {
+	me, err := s.Me(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, me)
+	}
+}
+
+// UpdateMeHandler changes the authorized user's name or email.
+func UpdateMeHandler(ctx *gin.Context, s *Service) {
+	me, err := s.UpdateMe(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrEmailTaken) {
+			ctx.AbortWithStatus(http.StatusConflict)
+			return
+		}
+		if errors.Is(err, ErrInvalidEmail) {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, me)
+	}
+}
+
+// ExportMeHandler exports the data all services hold about the authorized user as ZIP archive, or as JSON with format=json.
+func ExportMeHandler(ctx *gin.Context, s *Service) {
+	export, err := s.ExportMe(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	if ctx.Query("format") == "json" {
+		ctx.JSON(http.StatusOK, export)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = export.WriteZip(&buf)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.Header("Content-Disposition", `attachment; filename="export.zip"`)
+	ctx.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// EraseMeHandler erases the authorized user in all services.
+func EraseMeHandler(ctx *gin.Context, s *Service) {
+	err := s.EraseMe(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// ChangePasswordHandler changes the authorized user's password and returns a fresh set of tokens.
+func ChangePasswordHandler(ctx *gin.Context, s *Service) {
+	accessToken, refreshToken, role, err := s.ChangePassword(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if abortWithPolicyViolation(ctx, err) {
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
+		"role":         role,
+		"rolesSpec":    roles.RolesSpec(role),
+	})
+}
+
 // RevokeHandler revokes a user's tokens for a specific instance or falls back to the authorization tokens instance.
 func RevokeHandler(ctx *gin.Context, s *Service) {
 	err := s.Revoke(ctx)
@@ -128,3 +555,574 @@ func RevokeAllHandler(ctx *gin.Context, s *Service) {
 		ctx.Status(http.StatusOK)
 	}
 }
+
+// SessionsHandler lists a user's active sessions for a specific instance or falls back to the authorization tokens instance.
+func SessionsHandler(ctx *gin.Context, s *Service) {
+	sessions, err := s.Sessions(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, sessions)
+	}
+}
+
+// RevokeSessionHandler revokes a single session.
+func RevokeSessionHandler(ctx *gin.Context, s *Service) {
+	err := s.RevokeSession(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrSessionNotFound) {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// EnrollTOTPHandler starts the TOTP enrollment of the authorized user.
+func EnrollTOTPHandler(ctx *gin.Context, s *Service) {
+	enrollment, err := s.EnrollTOTP(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrTwoFactorEnabled) {
+			ctx.AbortWithStatus(http.StatusConflict)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, enrollment)
+	}
+}
+
+// ConfirmTOTPHandler completes the TOTP enrollment of the authorized user and returns recovery codes.
+func ConfirmTOTPHandler(ctx *gin.Context, s *Service) {
+	recoveryCodes, err := s.ConfirmTOTP(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrTwoFactorEnabled) {
+			ctx.AbortWithStatus(http.StatusConflict)
+			return
+		}
+		if errors.Is(err, ErrTwoFactorCodeInvalid) || errors.Is(err, ErrTwoFactorNotEnrolled) {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"recoveryCodes": recoveryCodes})
+	}
+}
+
+// DisableTOTPHandler disables two-factor authentication of the authorized user.
+func DisableTOTPHandler(ctx *gin.Context, s *Service) {
+	err := s.DisableTOTP(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// PasskeyLoginOptionsHandler starts a login with a passkey.
+func PasskeyLoginOptionsHandler(ctx *gin.Context, s *Service) {
+	options, err := s.PasskeyLoginOptions(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"publicKey": options})
+	}
+}
+
+// LoginPasskeyHandler logs a user in with a passkey and returns a fresh set of tokens.
+func LoginPasskeyHandler(ctx *gin.Context, s *Service) {
+	accessToken, refreshToken, role, err := s.LoginPasskey(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrUserNotActivated) {
+			// the passkey was valid, so it is safe to tell the user to verify the email first
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
+		"role":         role,
+		"rolesSpec":    roles.RolesSpec(role),
+	})
+}
+
+// PasskeysHandler lists the authorized user's passkeys.
+func PasskeysHandler(ctx *gin.Context, s *Service) {
+	passkeys, err := s.Passkeys(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, passkeys)
+	}
+}
+
+// PasskeyRegistrationOptionsHandler starts the registration of a passkey for the authorized user.
+func PasskeyRegistrationOptionsHandler(ctx *gin.Context, s *Service) {
+	options, err := s.PasskeyRegistrationOptions(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"publicKey": options})
+	}
+}
+
+// RegisterPasskeyHandler completes the registration of a passkey for the authorized user.
+func RegisterPasskeyHandler(ctx *gin.Context, s *Service) {
+	passkey, err := s.RegisterPasskey(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusBadRequest)
+	} else {
+		ctx.JSON(http.StatusCreated, passkey)
+	}
+}
+
+// DeletePasskeyHandler removes a passkey of the authorized user.
+func DeletePasskeyHandler(ctx *gin.Context, s *Service) {
+	err := s.DeletePasskey(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrPasskeyNotFound) {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// IdentityProvidersHandler lists the identity providers users of an instance can log in with.
+func IdentityProvidersHandler(ctx *gin.Context, s *Service) {
+	providers, err := s.IdentityProviders(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusBadRequest)
+	} else {
+		ctx.JSON(http.StatusOK, providers)
+	}
+}
+
+// OIDCLoginHandler starts a login at an identity provider and returns the URL to redirect the user to.
+func OIDCLoginHandler(ctx *gin.Context, s *Service) {
+	authorizationURL, err := s.OIDCLogin(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrIdentityProviderNotFound) {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusBadRequest)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"authorizationURL": authorizationURL})
+	}
+}
+
+// OIDCCallbackHandler completes a login at an identity provider and returns a fresh set of tokens.
+func OIDCCallbackHandler(ctx *gin.Context, s *Service) {
+	accessToken, refreshToken, role, challenge, err := s.OIDCCallback(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrUserNotActivated) {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	if challenge != nil {
+		ctx.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
+		"role":         role,
+		"rolesSpec":    roles.RolesSpec(role),
+	})
+}
+
+// AuthorizeHandler redirects the authorization request of a client to the login page.
+func AuthorizeHandler(ctx *gin.Context, s *Service) {
+	redirectURL, err := s.Authorize(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+	}
+	if len(redirectURL) > 0 {
+		ctx.Redirect(http.StatusFound, redirectURL)
+		return
+	}
+	// without valid client and redirect URI, the error must not be redirected
+	ctx.AbortWithStatus(http.StatusBadRequest)
+}
+
+// ApproveAuthorizationHandler issues an authorization code to a client for the authorized user.
+// The login page navigates to the returned redirectURL to hand the code to the client.
+func ApproveAuthorizationHandler(ctx *gin.Context, s *Service) {
+	redirectURL, err := s.ApproveAuthorization(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		var oauthErr *OAuthError
+		if errors.As(err, &oauthErr) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, oauthErr)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusBadRequest)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"redirectURL": redirectURL})
+	}
+}
+
+// TokenHandler redeems an authorization code or refresh token of a client, or the client credentials of a service account.
+func TokenHandler(ctx *gin.Context, s *Service) {
+	// token responses must not be cached, see RFC 6749 section 5.1
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+
+	res, err := s.Token(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		var oauthErr *OAuthError
+		if !errors.As(err, &oauthErr) {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, &OAuthError{Code: "server_error"})
+			return
+		}
+		status := http.StatusBadRequest
+		if oauthErr.Code == "invalid_client" {
+			status = http.StatusUnauthorized
+		}
+		// the description might leak details about users and tokens
+		ctx.AbortWithStatusJSON(status, &OAuthError{Code: oauthErr.Code})
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UserinfoHandler returns the standard claims of the authorized user.
+func UserinfoHandler(ctx *gin.Context, s *Service) {
+	res, err := s.Userinfo(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, res)
+	}
+}
+
+// ServiceAccountsHandler lists the service accounts of the authorized instance.
+func ServiceAccountsHandler(ctx *gin.Context, s *Service) {
+	accounts, err := s.ServiceAccounts(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, accounts)
+	}
+}
+
+// CreateServiceAccountHandler creates a service account and returns its client secret once.
+func CreateServiceAccountHandler(ctx *gin.Context, s *Service) {
+	account, err := s.CreateServiceAccount(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, roles.ErrInvalidRole) {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusCreated, account)
+	}
+}
+
+// RotateServiceAccountSecretHandler replaces the client secret of a service account and returns the new one once.
+func RotateServiceAccountSecretHandler(ctx *gin.Context, s *Service) {
+	secret, err := s.RotateServiceAccountSecret(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrServiceAccountNotFound) {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"clientSecret": secret})
+	}
+}
+
+// DeleteServiceAccountHandler deletes a service account.
+func DeleteServiceAccountHandler(ctx *gin.Context, s *Service) {
+	err := s.DeleteServiceAccount(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrServiceAccountNotFound) {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// InstancesHandler lists a page of instances.
+func InstancesHandler(ctx *gin.Context, s *Service) {
+	instances, err := s.Instances(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, instances)
+	}
+}
+
+// CreateInstanceHandler creates an instance.
+func CreateInstanceHandler(ctx *gin.Context, s *Service) {
+	instance, err := s.CreateInstance(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		abortWithInstanceError(ctx, err)
+	} else {
+		ctx.JSON(http.StatusCreated, instance)
+	}
+}
+
+// UpdateInstanceHandler renames an instance or changes its URL.
+func UpdateInstanceHandler(ctx *gin.Context, s *Service) {
+	instance, err := s.UpdateInstance(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		abortWithInstanceError(ctx, err)
+	} else {
+		ctx.JSON(http.StatusOK, instance)
+	}
+}
+
+// DeleteInstanceHandler soft-deletes an instance.
+func DeleteInstanceHandler(ctx *gin.Context, s *Service) {
+	err := s.DeleteInstance(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		abortWithInstanceError(ctx, err)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// abortWithInstanceError responds with the status matching an error of managing instances.
+// Only super admins get past the authorization, so the errors can be revealed.
+func abortWithInstanceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidInstanceName), errors.Is(err, ErrInvalidInstanceURL):
+		ctx.AbortWithStatus(http.StatusBadRequest)
+	case errors.Is(err, ErrInstanceURLTaken):
+		ctx.AbortWithStatus(http.StatusConflict)
+	case errors.Is(err, ErrInstanceDoesNotExist):
+		ctx.AbortWithStatus(http.StatusNotFound)
+	default:
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+// MembersHandler lists a page of the members of the authorized instance.
+func MembersHandler(ctx *gin.Context, s *Service) {
+	members, err := s.Members(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, members)
+	}
+}
+
+// ChangeMemberRoleHandler gives a member another role.
+func ChangeMemberRoleHandler(ctx *gin.Context, s *Service) {
+	member, err := s.ChangeMemberRole(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		abortWithMemberError(ctx, err)
+	} else {
+		ctx.JSON(http.StatusOK, member)
+	}
+}
+
+// RemoveMemberHandler removes a member from the authorized instance.
+func RemoveMemberHandler(ctx *gin.Context, s *Service) {
+	err := s.RemoveMember(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		abortWithMemberError(ctx, err)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// abortWithMemberError responds with the status matching an error of administrating members.
+func abortWithMemberError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, roles.ErrInvalidRole):
+		ctx.AbortWithStatus(http.StatusBadRequest)
+	case errors.Is(err, ErrProfileDoesNotExist):
+		ctx.AbortWithStatus(http.StatusNotFound)
+	default:
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+// InvitationsHandler lists the pending invitations of the authorized instance.
+func InvitationsHandler(ctx *gin.Context, s *Service) {
+	invitations, err := s.Invitations(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, invitations)
+	}
+}
+
+// InviteHandler sends an invitation to join the authorized instance.
+func InviteHandler(ctx *gin.Context, s *Service) {
+	invitation, err := s.Invite(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, roles.ErrInvalidRole) || errors.Is(err, ErrInvalidEmail) {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusCreated, invitation)
+	}
+}
+
+// DeleteInvitationHandler revokes a pending invitation.
+func DeleteInvitationHandler(ctx *gin.Context, s *Service) {
+	err := s.DeleteInvitation(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrInvitationNotFound) {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// AcceptInvitationHandler adds the invited user to the instance by the token received by mail.
+func AcceptInvitationHandler(ctx *gin.Context, s *Service) {
+	userID, role, err := s.AcceptInvitation(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if abortWithPolicyViolation(ctx, err) {
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, gin.H{"userID": userID, "role": role})
+	}
+}
+
+// APIKeysHandler lists the API keys of the authorized user.
+func APIKeysHandler(ctx *gin.Context, s *Service) {
+	keys, err := s.APIKeys(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, keys)
+	}
+}
+
+// CreateAPIKeyHandler creates an API key and returns the key once.
+func CreateAPIKeyHandler(ctx *gin.Context, s *Service) {
+	key, err := s.CreateAPIKey(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, roles.ErrInvalidRole) || errors.Is(err, ErrAPIKeyExpired) {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusCreated, key)
+	}
+}
+
+// DeleteAPIKeyHandler deletes an API key of the authorized user.
+func DeleteAPIKeyHandler(ctx *gin.Context, s *Service) {
+	err := s.DeleteAPIKey(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		if errors.Is(err, ErrAPIKeyNotFound) {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// VerifyAPIKeyHandler returns the claims of the API key presented in the API key header, for other services to authorize API keys.
+func VerifyAPIKeyHandler(ctx *gin.Context, s *Service) {
+	claims, err := s.VerifyAPIKey(ctx, ctx.GetHeader(tokens.APIKeyHeader))
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.JSON(http.StatusOK, claims)
+	}
+}
+
+// UnlockLoginHandler lifts the lockout of an account or client IP after too many failed logins.
+func UnlockLoginHandler(ctx *gin.Context, s *Service) {
+	err := s.UnlockLogin(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	} else {
+		ctx.Status(http.StatusOK)
+	}
+}
+
+// PasswordPolicyHandler returns the password policy of an instance.
+func PasswordPolicyHandler(ctx *gin.Context, s *Service) {
+	policy, err := s.PasswordPolicy(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		ctx.AbortWithStatus(http.StatusBadRequest)
+	} else {
+		ctx.JSON(http.StatusOK, policy)
+	}
+}
+
+// abortWithPolicyViolation responds with the violated rules if err is a PasswordPolicyError.
+// The violations only concern the chosen password, so they can be revealed.
+func abortWithPolicyViolation(ctx *gin.Context, err error) bool {
+	var policyErr *PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+	ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "password policy violated", "rules": policyErr.Rules})
+	return true
+}
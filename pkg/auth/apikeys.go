@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	authtokens "github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/volatiletech/null/v8"
+)
+
+// APIKeyBody describes an API key to create
+type APIKeyBody struct {
+	Name string `json:"name"`
+	// Role defaults to the current role of the authorized user
+	Role *roles.Role `json:"role"`
+	// Scopes limit the API key in addition to its role
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// APIKeyResponse describes an API key, the key itself is only returned when it is created
+type APIKeyResponse struct {
+	ID        string     `json:"id"`
+	Key       string     `json:"key,omitempty"`
+	Name      string     `json:"name"`
+	Role      roles.Role `json:"role"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// APIKeys lists the API keys of the authorized user in the authorized instance.
+func (s *Service) APIKeys(ctx *gin.Context) ([]APIKeyResponse, error) {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instanceID, err := roles.Instance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.DBAPI.ListAPIKeys(ctx, userID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]APIKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, apiKeyResponse(k))
+	}
+	return res, nil
+}
+
+// CreateAPIKey creates an API key of the authorized user in the authorized instance.
+// The API key can act in a subset of the authorized role and scopes only.
+func (s *Service) CreateAPIKey(ctx *gin.Context) (res APIKeyResponse, err error) {
+	var body APIKeyBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	instanceID, err := roles.Instance(ctx)
+	if err != nil {
+		return
+	}
+	role, err := roles.FromContext(ctx)
+	if err != nil {
+		return
+	}
+	if body.Role != nil {
+		if !validRole(*body.Role) {
+			err = errors.WithStack(roles.ErrInvalidRole)
+			return
+		}
+		if !roles.CanActIn(ctx, *body.Role) {
+			err = errors.WithStack(roles.ErrUnauthorized)
+			return
+		}
+		role = *body.Role
+	}
+	// a key created with a scoped key must not escape its scopes
+	if len(ctx.GetString(roles.ScopeKey)) > 0 {
+		if len(body.Scopes) == 0 {
+			err = errors.WithStack(roles.ErrUnauthorized)
+			return
+		}
+		for _, scope := range body.Scopes {
+			if !roles.HasScope(ctx, scope) {
+				err = errors.Wrapf(roles.ErrUnauthorized, "scope %s", scope)
+				return
+			}
+		}
+	}
+	if body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()) {
+		err = errors.WithStack(ErrAPIKeyExpired)
+		return
+	}
+
+	id, key, digest, err := authtokens.GenerateAPIKey()
+	if err != nil {
+		return
+	}
+	apiKey := &m.APIKey{
+		ID:         id,
+		UserID:     userID,
+		InstanceID: instanceID,
+		Name:       body.Name,
+		Digest:     digest,
+		Role:       string(role),
+		Scopes:     strings.Join(body.Scopes, " "),
+		ExpiresAt:  null.TimeFromPtr(body.ExpiresAt),
+	}
+	err = s.DBAPI.CreateAPIKey(ctx, apiKey)
+	if err != nil {
+		return
+	}
+
+	res = apiKeyResponse(apiKey)
+	res.Key = key
+	return
+}
+
+// DeleteAPIKey deletes an API key of the authorized user.
+func (s *Service) DeleteAPIKey(ctx *gin.Context) error {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := s.DBAPI.DeleteAPIKey(ctx, userID, ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrAPIKeyNotFound)
+	}
+	return nil
+}
+
+// VerifyAPIKey returns the claims of a valid API key, implementing tokens.APIKeyVerifier.
+// The key is rejected if the user's profile no longer inherits the key's role.
+func (s *Service) VerifyAPIKey(ctx context.Context, key string) (*tokens.APIKeyClaims, error) {
+	id, err := tokens.ParseAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+	apiKey, err := s.DBAPI.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(authtokens.Digest(key), apiKey.Digest) != 1 {
+		return nil, errors.WithStack(tokens.ErrInvalidAPIKey)
+	}
+	if apiKey.ExpiresAt.Valid && !time.Now().Before(apiKey.ExpiresAt.Time) {
+		return nil, errors.WithStack(ErrAPIKeyExpired)
+	}
+
+	profile, err := s.DBAPI.GetProfile(ctx, apiKey.UserID, apiKey.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	inherited := false
+	for _, r := range roles.InheritedRoles(roles.Role(profile.Role.String)) {
+		inherited = inherited || r == roles.Role(apiKey.Role)
+	}
+	if !inherited {
+		return nil, errors.Wrapf(roles.ErrUnauthorized, "profile role %s", profile.Role.String)
+	}
+
+	return &tokens.APIKeyClaims{
+		Subject:   apiKey.UserID,
+		Role:      apiKey.Role,
+		Instance:  apiKey.InstanceID,
+		Scope:     apiKey.Scopes,
+		ExpiresAt: apiKey.ExpiresAt.Ptr(),
+	}, nil
+}
+
+func apiKeyResponse(k *m.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:        k.ID,
+		Name:      k.Name,
+		Role:      roles.Role(k.Role),
+		Scopes:    strings.Fields(k.Scopes),
+		ExpiresAt: k.ExpiresAt.Ptr(),
+		CreatedAt: k.CreatedAt,
+	}
+}
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyExpired  = errors.New("api key expired")
+)
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	libtokens "github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_verifyAPIKey(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+	ctx := context.Background()
+
+	id, key, digest, err := tokens.GenerateAPIKey()
+	require.CmpNoError(err)
+	apiKey := &m.APIKey{
+		ID:         id,
+		UserID:     xid.New().String(),
+		InstanceID: xid.New().String(),
+		Digest:     digest,
+		Role:       string(roles.RoleEventOrganizer),
+		Scopes:     "workshops:read",
+		ExpiresAt:  null.TimeFrom(time.Now().Add(time.Hour)),
+	}
+	profile := &m.Profile{UserID: apiKey.UserID, InstanceID: apiKey.InstanceID, Role: null.StringFrom(string(roles.RoleInstanceAdmin))}
+	mock.EXPECT().
+		GetAPIKey(gomock.Any(), gomock.Eq(id)).
+		Return(apiKey, nil).
+		AnyTimes()
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(apiKey.UserID), gomock.Eq(apiKey.InstanceID)).
+		Return(profile, nil).
+		AnyTimes()
+
+	// when
+	claims, err := service.VerifyAPIKey(ctx, key)
+
+	// then
+	require.CmpNoError(err)
+	assert.Cmp(claims, &libtokens.APIKeyClaims{
+		Subject:   apiKey.UserID,
+		Role:      string(roles.RoleEventOrganizer),
+		Instance:  apiKey.InstanceID,
+		Scope:     "workshops:read",
+		ExpiresAt: apiKey.ExpiresAt.Ptr(),
+	})
+
+	// a key with a wrong secret is rejected
+	_, err = service.VerifyAPIKey(ctx, libtokens.FormatAPIKey(id, "guessed"))
+	assert.True(errors.Is(err, libtokens.ErrInvalidAPIKey))
+
+	// a key is rejected once the user's role no longer covers it
+	profile.Role = null.StringFrom(string(roles.RoleTeacher))
+	_, err = service.VerifyAPIKey(ctx, key)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+}
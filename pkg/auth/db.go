@@ -6,14 +6,19 @@ package auth
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/lib/pq"
 	"github.com/rs/xid"
 	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/paging"
 	"github.com/smartnuance/saas-kit/pkg/lib/roles"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	// . "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
@@ -22,14 +27,91 @@ type DBAPI interface {
 	Commit(tx *sql.Tx) error
 	Rollback(tx *sql.Tx) error
 	FindUserByEmail(ctx context.Context, email string) (*m.User, error)
+	GetUser(ctx context.Context, userID string) (*m.User, error)
 	GetInstance(ctx context.Context, instanceURL string) (instance *m.Instance, err error)
+	GetInstanceByID(ctx context.Context, instanceID string) (*m.Instance, error)
+	ListInstances(ctx context.Context, page paging.Page) (m.InstanceSlice, error)
+	CreateInstance(ctx context.Context, name, url string) (*m.Instance, error)
+	UpdateInstance(ctx context.Context, instance *m.Instance) error
+	DeleteInstance(ctx context.Context, instanceID string) (revoked int64, err error)
 	GetProfile(ctx context.Context, userID, instanceID string) (profile *m.Profile, err error)
+	ListProfiles(ctx context.Context, userID string) (m.ProfileSlice, error)
 	GetUserAndProfile(ctx context.Context, userID string, instanceURL string) (user *m.User, profile *m.Profile, err error)
+	ListMembers(ctx context.Context, instanceID string, page paging.Page) (m.ProfileSlice, error)
+	GetMember(ctx context.Context, instanceID, profileID string) (*m.Profile, error)
+	UpdateProfileRole(ctx context.Context, profileID string, role roles.Role) error
+	DeleteProfile(ctx context.Context, profile *m.Profile) (revoked int64, err error)
 	CreateProfile(ctx context.Context, tx *sql.Tx, instanceID string, user *m.User, role roles.Role) (profile *m.Profile, err error)
 	CreateUser(ctx context.Context, tx *sql.Tx, name, email string, passwordHash []byte) (user *m.User, err error)
-	DeleteUser(ctx context.Context, userID string) error
-	SaveToken(ctx context.Context, profile *m.Profile, token string, expiresAt time.Time) error
-	HasToken(ctx context.Context, userID, profileID, token string) (bool, error)
+	DeleteUser(ctx context.Context, userID string) (revoked int64, err error)
+	ActivateUser(ctx context.Context, tx *sql.Tx, userID string) error
+	UpdateUserName(ctx context.Context, userID, name string) error
+	ChangePassword(ctx context.Context, userID string, passwordHash []byte) (revoked int64, err error)
+	RehashPassword(ctx context.Context, userID string, oldHash, newHash []byte) error
+	CreateVerification(ctx context.Context, tx *sql.Tx, userID, email string, token []byte, expiresAt time.Time) error
+	VerifyEmail(ctx context.Context, token []byte) (user *m.User, err error)
+	CreatePasswordReset(ctx context.Context, userID string, token []byte, expiresAt time.Time) error
+	GetPasswordResetUser(ctx context.Context, token []byte) (*m.User, error)
+	ResetPassword(ctx context.Context, token []byte, passwordHash []byte) (user *m.User, revoked int64, err error)
+	GetTOTPSecret(ctx context.Context, userID string) (*m.TotpSecret, error)
+	SaveTOTPSecret(ctx context.Context, userID, secret string) error
+	ConfirmTOTPSecret(ctx context.Context, userID string, step int64, recoveryCodes [][]byte) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+	UseRecoveryCode(ctx context.Context, userID string, code []byte) error
+	DeleteTOTPSecret(ctx context.Context, userID string) error
+	CreatePasskeyChallenge(ctx context.Context, userID string, challenge []byte, expiresAt time.Time) error
+	ConsumePasskeyChallenge(ctx context.Context, challenge []byte, userID string) error
+	DeleteExpiredPasskeyChallenges(ctx context.Context, before time.Time) (int64, error)
+	CreatePasskey(ctx context.Context, passkey *m.Passkey) error
+	GetPasskey(ctx context.Context, id []byte) (*m.Passkey, error)
+	ListPasskeys(ctx context.Context, userID string) (m.PasskeySlice, error)
+	UpdatePasskeySignCount(ctx context.Context, id []byte, signCount uint32) error
+	DeletePasskey(ctx context.Context, userID string, id []byte) (int64, error)
+	CreateIdentityProvider(ctx context.Context, provider *m.IdentityProvider) error
+	GetIdentityProvider(ctx context.Context, instanceID, slug string) (*m.IdentityProvider, error)
+	ListIdentityProviders(ctx context.Context, instanceID string) (m.IdentityProviderSlice, error)
+	CreateOIDCState(ctx context.Context, state *m.OidcState) error
+	ConsumeOIDCState(ctx context.Context, state string) (*m.OidcState, error)
+	DeleteExpiredOIDCStates(ctx context.Context, before time.Time) (int64, error)
+	GetIdentity(ctx context.Context, providerID, subject string) (*m.Identity, error)
+	LinkIdentity(ctx context.Context, identity *m.Identity, instanceID, name string) (user *m.User, err error)
+	CreateOAuthClient(ctx context.Context, client *m.OauthClient) error
+	GetOAuthClient(ctx context.Context, clientID string) (*m.OauthClient, error)
+	ListServiceAccounts(ctx context.Context, instanceID string) (m.OauthClientSlice, error)
+	UpdateServiceAccountSecret(ctx context.Context, instanceID, clientID string, secret []byte) (int64, error)
+	DeleteServiceAccount(ctx context.Context, instanceID, clientID string) (int64, error)
+	CreateAuthorizationCode(ctx context.Context, code *m.AuthorizationCode) error
+	ConsumeAuthorizationCode(ctx context.Context, digest []byte) (*m.AuthorizationCode, error)
+	DeleteExpiredAuthorizationCodes(ctx context.Context, before time.Time) (int64, error)
+	RecordLoginFailure(ctx context.Context, key string, now, expiresAt time.Time) (int, error)
+	LockLogin(ctx context.Context, key string, lockedUntil, expiresAt time.Time) error
+	GetLoginLocks(ctx context.Context, keys []string, now time.Time) (map[string]time.Time, error)
+	ResetLoginAttempts(ctx context.Context, key string) (int64, error)
+	DeleteExpiredLoginAttempts(ctx context.Context, before time.Time) (int64, error)
+	CreateAPIKey(ctx context.Context, key *m.APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*m.APIKey, error)
+	ListAPIKeys(ctx context.Context, userID, instanceID string) (m.APIKeySlice, error)
+	DeleteAPIKey(ctx context.Context, userID, id string) (int64, error)
+	DeleteExpiredAPIKeys(ctx context.Context, before time.Time) (int64, error)
+	CreateInvitation(ctx context.Context, invitation *m.Invitation) error
+	GetInvitation(ctx context.Context, digest []byte) (*m.Invitation, error)
+	ListInvitations(ctx context.Context, instanceID string) (m.InvitationSlice, error)
+	DeleteInvitation(ctx context.Context, instanceID, id string) (int64, error)
+	AcceptInvitation(ctx context.Context, digest []byte, name string, passwordHash []byte) (user *m.User, profile *m.Profile, revoked int64, err error)
+	DeleteExpiredInvitations(ctx context.Context, before time.Time) (int64, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	ExportUser(ctx context.Context, userID string) (*m.User, error)
+	EraseUser(ctx context.Context, userID string) (revoked int64, err error)
+	SaveToken(ctx context.Context, profile *m.Profile, digest []byte, expiresAt, loggedInAt time.Time, accessJTI, clientID, userAgent, ip string) error
+	GetToken(ctx context.Context, userID, profileID string, digest []byte) (*m.Token, error)
+	RotateToken(ctx context.Context, parent *m.Token, digest []byte, expiresAt time.Time, accessJTI string) error
+	HashTokens(ctx context.Context, purge bool) (int64, error)
+	DeleteExpiredTokens(ctx context.Context, before time.Time, limit int) (int64, error)
+	LoadDenylist(ctx context.Context) (map[string]time.Time, error)
+	DeleteExpiredDenials(ctx context.Context, before time.Time) (int64, error)
+	DeleteTokenFamily(ctx context.Context, family string) (int64, error)
+	ListSessions(ctx context.Context, profileID string) (m.TokenSlice, error)
+	GetSession(ctx context.Context, family string) (*m.Token, error)
 	DeleteToken(ctx context.Context, profileID string) (int64, error)
 	DeleteAllTokens(ctx context.Context, userID string) (int64, error)
 }
@@ -59,6 +141,15 @@ func (db *dbAPI) FindUserByEmail(ctx context.Context, email string) (*m.User, er
 	return user, err
 }
 
+func (db *dbAPI) GetUser(ctx context.Context, userID string) (*m.User, error) {
+	user, err := m.Users(m.UserWhere.ID.EQ(userID)).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of login context
+		return nil, errors.WithStack(ErrUserDoesNotExist)
+	}
+	return user, err
+}
+
 func (db *dbAPI) GetInstance(ctx context.Context, instanceURL string) (instance *m.Instance, err error) {
 	instance, err = m.Instances(m.InstanceWhere.URL.EQ(instanceURL)).One(ctx, db.DB)
 	if err == sql.ErrNoRows {
@@ -69,6 +160,101 @@ func (db *dbAPI) GetInstance(ctx context.Context, instanceURL string) (instance
 	return instance, err
 }
 
+func (db *dbAPI) GetInstanceByID(ctx context.Context, instanceID string) (*m.Instance, error) {
+	instance, err := m.FindInstance(ctx, db.DB, instanceID)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of login context
+		return nil, errors.WithStack(ErrInstanceDoesNotExist)
+	}
+	return instance, err
+}
+
+// ListInstances lists a page of instances ordered by ID.
+func (db *dbAPI) ListInstances(ctx context.Context, page paging.Page) (m.InstanceSlice, error) {
+	instances, err := m.Instances(m.InstanceWhere.ID.Page(page)).All(ctx, db.DB)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := page.(*paging.Paging_Previous); ok {
+		for i, j := 0, len(instances)-1; i < j; i, j = i+1, j-1 {
+			instances[i], instances[j] = instances[j], instances[i]
+		}
+	}
+	return instances, nil
+}
+
+func (db *dbAPI) CreateInstance(ctx context.Context, name, url string) (*m.Instance, error) {
+	instance := &m.Instance{
+		ID:   xid.New().String(),
+		Name: name,
+		URL:  url,
+	}
+	err := instance.Insert(ctx, db.DB, boil.Infer())
+	if err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+func (db *dbAPI) UpdateInstance(ctx context.Context, instance *m.Instance) error {
+	instance.UpdatedAt = time.Now()
+	n, err := instance.Update(ctx, db.DB, boil.Whitelist(m.InstanceColumns.Name, m.InstanceColumns.URL, m.InstanceColumns.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrInstanceDoesNotExist)
+	}
+	return nil
+}
+
+// DeleteInstance soft-deletes an instance with all its profiles, so logins to it fail,
+// revokes the refresh tokens and API keys of all its profiles and deletes its OAuth clients and service accounts.
+// The number of revoked refresh tokens is returned.
+func (db *dbAPI) DeleteInstance(ctx context.Context, instanceID string) (revoked int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	n, err := m.Instances(m.InstanceWhere.ID.EQ(instanceID)).DeleteAll(ctx, tx, false)
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		err = errors.WithStack(ErrInstanceDoesNotExist)
+		return
+	}
+	_, err = m.Profiles(m.ProfileWhere.InstanceID.EQ(instanceID)).DeleteAll(ctx, tx, false)
+	if err != nil {
+		return
+	}
+	_, err = m.APIKeys(m.APIKeyWhere.InstanceID.EQ(instanceID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+	revoked, err = revokeTokens(ctx, tx,
+		qm.Where(fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s = ?)",
+			m.TokenColumns.ProfileID, m.ProfileColumns.ID, m.TableNames.Profiles, m.ProfileColumns.InstanceID), instanceID),
+	)
+	if err != nil {
+		return
+	}
+	// clients of the instance must not obtain tokens anymore, their authorization codes are deleted with them
+	_, err = m.OauthClients(m.OauthClientWhere.InstanceID.EQ(instanceID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
 func (db *dbAPI) GetProfile(ctx context.Context, userID, instanceID string) (profile *m.Profile, err error) {
 	where := &m.ProfileWhere
 	profile, err = m.Profiles(where.UserID.EQ(userID), where.InstanceID.EQ(instanceID)).One(ctx, db.DB)
@@ -80,6 +266,17 @@ func (db *dbAPI) GetProfile(ctx context.Context, userID, instanceID string) (pro
 	return profile, err
 }
 
+// ListProfiles lists the profiles of a user in instances that are not deleted, with their instance, in the order they were created.
+func (db *dbAPI) ListProfiles(ctx context.Context, userID string) (m.ProfileSlice, error) {
+	return m.Profiles(
+		m.ProfileWhere.UserID.EQ(userID),
+		qm.Where(fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s IS NULL)",
+			m.ProfileColumns.InstanceID, m.InstanceColumns.ID, m.TableNames.Instances, m.InstanceColumns.DeletedAt)),
+		qm.Load(m.ProfileRels.Instance),
+		qm.OrderBy(m.ProfileColumns.CreatedAt),
+	).All(ctx, db.DB)
+}
+
 func (db *dbAPI) GetUserAndProfile(ctx context.Context, userID string, instanceURL string) (user *m.User, profile *m.Profile, err error) {
 	profile, err = m.Profiles(m.ProfileWhere.UserID.EQ(userID)).One(ctx, db.DB)
 	if err != nil {
@@ -97,6 +294,54 @@ func (db *dbAPI) GetUserAndProfile(ctx context.Context, userID string, instanceU
 	return
 }
 
+// ListMembers lists a page of the profiles of an instance ordered by ID, loading their users.
+func (db *dbAPI) ListMembers(ctx context.Context, instanceID string, page paging.Page) (m.ProfileSlice, error) {
+	profiles, err := m.Profiles(m.ProfileWhere.ID.Page(page), m.ProfileWhere.InstanceID.EQ(instanceID), qm.Load(m.ProfileRels.User)).All(ctx, db.DB)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := page.(*paging.Paging_Previous); ok {
+		for i, j := 0, len(profiles)-1; i < j; i, j = i+1, j-1 {
+			profiles[i], profiles[j] = profiles[j], profiles[i]
+		}
+	}
+	return profiles, nil
+}
+
+// GetMember loads a profile of an instance with its user.
+func (db *dbAPI) GetMember(ctx context.Context, instanceID, profileID string) (*m.Profile, error) {
+	where := &m.ProfileWhere
+	profile, err := m.Profiles(where.ID.EQ(profileID), where.InstanceID.EQ(instanceID), qm.Load(m.ProfileRels.User)).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of member context
+		return nil, errors.WithStack(ErrProfileDoesNotExist)
+	}
+	return profile, err
+}
+
+func (db *dbAPI) UpdateProfileRole(ctx context.Context, profileID string, role roles.Role) error {
+	_, err := m.Profiles(m.ProfileWhere.ID.EQ(profileID)).UpdateAll(ctx, db.DB, m.M{
+		m.ProfileColumns.Role:      null.StringFrom(string(role)),
+		m.ProfileColumns.UpdatedAt: time.Now(),
+	})
+	return err
+}
+
+// DeleteProfile soft-deletes a profile and revokes its refresh tokens and API keys.
+// The number of revoked refresh tokens is returned.
+func (db *dbAPI) DeleteProfile(ctx context.Context, profile *m.Profile) (revoked int64, err error) {
+	_, err = m.Profiles(m.ProfileWhere.ID.EQ(profile.ID)).DeleteAll(ctx, db.DB, false)
+	if err != nil {
+		return
+	}
+	where := &m.APIKeyWhere
+	_, err = m.APIKeys(where.UserID.EQ(profile.UserID), where.InstanceID.EQ(profile.InstanceID)).DeleteAll(ctx, db.DB)
+	if err != nil {
+		return
+	}
+	return db.DeleteToken(ctx, profile.ID)
+}
+
 func (db *dbAPI) CreateProfile(ctx context.Context, tx *sql.Tx, instanceID string, user *m.User, role roles.Role) (profile *m.Profile, err error) {
 	profile = &m.Profile{
 		ID:         xid.New().String(),
@@ -125,42 +370,1221 @@ func (db *dbAPI) CreateUser(ctx context.Context, tx *sql.Tx, name, email string,
 	return
 }
 
-func (db *dbAPI) DeleteUser(ctx context.Context, userID string) error {
-	_, err := m.Users(m.UserWhere.ID.EQ(userID)).DeleteAll(ctx, db.DB, false)
+// DeleteUser soft-deletes a user with all profiles and revokes the user's refresh tokens and API keys.
+// The number of revoked refresh tokens is returned.
+func (db *dbAPI) DeleteUser(ctx context.Context, userID string) (revoked int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = m.Profiles(m.ProfileWhere.UserID.EQ(userID)).DeleteAll(ctx, tx, false)
+	if err != nil {
+		return
+	}
+	n, err := m.Users(m.UserWhere.ID.EQ(userID)).DeleteAll(ctx, tx, false)
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		err = errors.WithStack(ErrUserDoesNotExist)
+		return
+	}
+	_, err = m.APIKeys(m.APIKeyWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+	err = tx.Commit()
+	if err != nil {
+		return
+	}
+
+	return db.DeleteAllTokens(ctx, userID)
+}
+
+func (db *dbAPI) ActivateUser(ctx context.Context, tx *sql.Tx, userID string) error {
+	_, err := m.Users(m.UserWhere.ID.EQ(userID)).UpdateAll(ctx, tx, m.M{m.UserColumns.ActivatedAt: time.Now()})
+	return err
+}
+
+func (db *dbAPI) UpdateUserName(ctx context.Context, userID, name string) error {
+	_, err := m.Users(m.UserWhere.ID.EQ(userID)).UpdateAll(ctx, db.DB, m.M{
+		m.UserColumns.Name:      null.StringFrom(name),
+		m.UserColumns.UpdatedAt: time.Now(),
+	})
+	return err
+}
+
+// ChangePassword replaces the password of a user and revokes all of the user's refresh tokens.
+func (db *dbAPI) ChangePassword(ctx context.Context, userID string, passwordHash []byte) (revoked int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = m.Users(m.UserWhere.ID.EQ(userID)).UpdateAll(ctx, tx, m.M{
+		m.UserColumns.Password:  passwordHash,
+		m.UserColumns.UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	// sessions possibly started by someone knowing the old password have to end together with it
+	revoked, err = revokeTokens(ctx, tx, m.TokenWhere.UserID.EQ(userID))
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// RehashPassword replaces the password hash of a user only if it is still oldHash,
+// so a concurrent password change is not overwritten.
+func (db *dbAPI) RehashPassword(ctx context.Context, userID string, oldHash, newHash []byte) error {
+	_, err := m.Users(m.UserWhere.ID.EQ(userID), m.UserWhere.Password.EQ(oldHash)).UpdateAll(ctx, db.DB, m.M{
+		m.UserColumns.Password:  newHash,
+		m.UserColumns.UpdatedAt: time.Now(),
+	})
 	return err
 }
 
-func (db *dbAPI) SaveToken(ctx context.Context, profile *m.Profile, token string, expiresAt time.Time) error {
-	t := m.Token{
-		UserID:    profile.UserID,
-		ProfileID: profile.ID,
+// CreateVerification stores the digest of a verification token for the email of a user.
+// Any previous verification of the user is invalidated.
+func (db *dbAPI) CreateVerification(ctx context.Context, tx *sql.Tx, userID, email string, token []byte, expiresAt time.Time) error {
+	_, err := m.Verifications(m.VerificationWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return err
+	}
+	v := m.Verification{
+		UserID:    userID,
+		Email:     email,
 		Token:     token,
 		ExpiresAt: expiresAt,
 	}
-	return t.Insert(ctx, db.DB, boil.Infer())
+	return v.Insert(ctx, tx, boil.Infer())
 }
 
-func (db *dbAPI) HasToken(ctx context.Context, userID, profileID, token string) (bool, error) {
-	where := &m.TokenWhere
-	n, err := m.Tokens(where.UserID.EQ(userID), where.ProfileID.EQ(profileID), where.Token.EQ(token)).Count(ctx, db.DB)
+// VerifyEmail consumes a valid verification token and activates the user with the verified email.
+func (db *dbAPI) VerifyEmail(ctx context.Context, token []byte) (user *m.User, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	where := &m.VerificationWhere
+	verification, err := m.Verifications(where.Token.EQ(token), where.ExpiresAt.GT(time.Now()), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of verification context
+		err = errors.WithStack(ErrVerificationInvalid)
+		return
+	}
+	if err != nil {
+		return
+	}
+	// verification tokens are single-use
+	_, err = verification.Delete(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	user, err = verification.User().One(ctx, tx)
+	if err == sql.ErrNoRows {
+		err = errors.WithStack(ErrUserDoesNotExist)
+		return
+	}
+	if err != nil {
+		return
+	}
+	user.Email = verification.Email
+	if !user.ActivatedAt.Valid {
+		user.ActivatedAt = null.TimeFrom(time.Now())
+	}
+	_, err = user.Update(ctx, tx, boil.Infer())
 	if err != nil {
-		return false, err
+		return
 	}
-	return n != 0, nil
+
+	err = tx.Commit()
+	return
 }
 
-func (db *dbAPI) DeleteToken(ctx context.Context, profileID string) (int64, error) {
-	where := &m.TokenWhere
-	numDeleted, err := m.Tokens(
-		where.ProfileID.EQ(profileID),
-	).DeleteAll(ctx, db.DB)
-	return numDeleted, err
+// CreatePasswordReset stores the digest of a password reset token for a user.
+// Any previous password reset of the user is invalidated.
+func (db *dbAPI) CreatePasswordReset(ctx context.Context, userID string, token []byte, expiresAt time.Time) (err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = m.PasswordResets(m.PasswordResetWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+	reset := m.PasswordReset{
+		UserID:    userID,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+	err = reset.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
 }
 
-func (db *dbAPI) DeleteAllTokens(ctx context.Context, userID string) (int64, error) {
-	where := &m.TokenWhere
-	numDeleted, err := m.Tokens(
-		where.UserID.EQ(userID),
-	).DeleteAll(ctx, db.DB)
-	return numDeleted, err
+// GetPasswordResetUser finds the user of a valid password reset token without consuming it.
+func (db *dbAPI) GetPasswordResetUser(ctx context.Context, token []byte) (*m.User, error) {
+	where := &m.PasswordResetWhere
+	reset, err := m.PasswordResets(where.Token.EQ(token), where.ExpiresAt.GT(time.Now()), qm.Load(m.PasswordResetRels.User)).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		return nil, errors.WithStack(ErrResetInvalid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if reset.R == nil || reset.R.User == nil {
+		return nil, errors.WithStack(ErrUserDoesNotExist)
+	}
+	return reset.R.User, nil
+}
+
+// ResetPassword consumes a valid password reset token, replaces the user's password and revokes all of the user's refresh tokens.
+// The number of revoked refresh tokens is returned.
+func (db *dbAPI) ResetPassword(ctx context.Context, token []byte, passwordHash []byte) (user *m.User, revoked int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	where := &m.PasswordResetWhere
+	reset, err := m.PasswordResets(where.Token.EQ(token), where.ExpiresAt.GT(time.Now()), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of password reset context
+		err = errors.WithStack(ErrResetInvalid)
+		return
+	}
+	if err != nil {
+		return
+	}
+	// password reset tokens are single-use
+	_, err = reset.Delete(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	user, err = reset.User().One(ctx, tx)
+	if err == sql.ErrNoRows {
+		err = errors.WithStack(ErrUserDoesNotExist)
+		return
+	}
+	if err != nil {
+		return
+	}
+	user.Password = passwordHash
+	_, err = user.Update(ctx, tx, boil.Infer())
+	if err != nil {
+		return
+	}
+
+	// sessions possibly started by someone knowing the old password have to end together with it
+	revoked, err = revokeTokens(ctx, tx, m.TokenWhere.UserID.EQ(user.ID))
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (db *dbAPI) GetTOTPSecret(ctx context.Context, userID string) (*m.TotpSecret, error) {
+	secret, err := m.FindTotpSecret(ctx, db.DB, userID)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of two-factor context
+		return nil, errors.WithStack(ErrTwoFactorNotEnrolled)
+	}
+	return secret, err
+}
+
+// SaveTOTPSecret starts a (new) pending enrollment of a user.
+func (db *dbAPI) SaveTOTPSecret(ctx context.Context, userID, secret string) error {
+	t := m.TotpSecret{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+	return t.Upsert(ctx, db.DB, true, []string{m.TotpSecretColumns.UserID},
+		boil.Whitelist(m.TotpSecretColumns.Secret, m.TotpSecretColumns.CreatedAt, m.TotpSecretColumns.ConfirmedAt, m.TotpSecretColumns.LastStep),
+		boil.Infer())
+}
+
+// ConfirmTOTPSecret completes a pending enrollment and replaces the recovery codes of a user by the given digests.
+func (db *dbAPI) ConfirmTOTPSecret(ctx context.Context, userID string, step int64, recoveryCodes [][]byte) (err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	where := &m.TotpSecretWhere
+	n, err := m.TotpSecrets(where.UserID.EQ(userID), where.ConfirmedAt.IsNull()).
+		UpdateAll(ctx, tx, m.M{m.TotpSecretColumns.ConfirmedAt: time.Now(), m.TotpSecretColumns.LastStep: step})
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		err = errors.WithStack(ErrTwoFactorEnabled)
+		return
+	}
+
+	_, err = m.RecoveryCodes(m.RecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+	for _, code := range recoveryCodes {
+		c := m.RecoveryCode{UserID: userID, Code: code}
+		err = c.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return
+		}
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// UseTOTPStep marks the time step of a valid code as used, so the code cannot be replayed.
+func (db *dbAPI) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	where := &m.TotpSecretWhere
+	n, err := m.TotpSecrets(where.UserID.EQ(userID), where.ConfirmedAt.IsNotNull(), where.LastStep.LT(step)).
+		UpdateAll(ctx, db.DB, m.M{m.TotpSecretColumns.LastStep: step})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrTwoFactorCodeInvalid)
+	}
+	return nil
+}
+
+// UseRecoveryCode consumes a single-use recovery code.
+func (db *dbAPI) UseRecoveryCode(ctx context.Context, userID string, code []byte) error {
+	where := &m.RecoveryCodeWhere
+	n, err := m.RecoveryCodes(where.UserID.EQ(userID), where.Code.EQ(code)).DeleteAll(ctx, db.DB)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrTwoFactorCodeInvalid)
+	}
+	return nil
+}
+
+// DeleteTOTPSecret disables two-factor authentication of a user.
+func (db *dbAPI) DeleteTOTPSecret(ctx context.Context, userID string) (err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = m.RecoveryCodes(m.RecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+	_, err = m.TotpSecrets(m.TotpSecretWhere.UserID.EQ(userID)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// CreatePasskeyChallenge stores the challenge of a pending registration of a user or a pending login, if userID is empty.
+func (db *dbAPI) CreatePasskeyChallenge(ctx context.Context, userID string, challenge []byte, expiresAt time.Time) error {
+	c := m.PasskeyChallenge{
+		UserID:    null.NewString(userID, len(userID) > 0),
+		Challenge: challenge,
+		ExpiresAt: expiresAt,
+	}
+	return c.Insert(ctx, db.DB, boil.Infer())
+}
+
+// ConsumePasskeyChallenge consumes a valid challenge of a pending registration of a user or a pending login, if userID is empty.
+func (db *dbAPI) ConsumePasskeyChallenge(ctx context.Context, challenge []byte, userID string) error {
+	where := &m.PasskeyChallengeWhere
+	mods := []qm.QueryMod{where.Challenge.EQ(challenge), where.ExpiresAt.GT(time.Now())}
+	if len(userID) > 0 {
+		mods = append(mods, where.UserID.EQ(null.StringFrom(userID)))
+	} else {
+		mods = append(mods, where.UserID.IsNull())
+	}
+	n, err := m.PasskeyChallenges(mods...).DeleteAll(ctx, db.DB)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrPasskeyChallengeInvalid)
+	}
+	return nil
+}
+
+// DeleteExpiredPasskeyChallenges deletes challenges of ceremonies that were never completed.
+func (db *dbAPI) DeleteExpiredPasskeyChallenges(ctx context.Context, before time.Time) (int64, error) {
+	where := &m.PasskeyChallengeWhere
+	return m.PasskeyChallenges(where.ExpiresAt.LT(before)).DeleteAll(ctx, db.DB)
+}
+
+func (db *dbAPI) CreatePasskey(ctx context.Context, passkey *m.Passkey) error {
+	return passkey.Insert(ctx, db.DB, boil.Infer())
+}
+
+func (db *dbAPI) GetPasskey(ctx context.Context, id []byte) (*m.Passkey, error) {
+	passkey, err := m.FindPasskey(ctx, db.DB, id)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of passkey context
+		return nil, errors.WithStack(ErrPasskeyNotFound)
+	}
+	return passkey, err
+}
+
+func (db *dbAPI) ListPasskeys(ctx context.Context, userID string) (m.PasskeySlice, error) {
+	where := &m.PasskeyWhere
+	return m.Passkeys(where.UserID.EQ(userID), qm.OrderBy(m.PasskeyColumns.CreatedAt)).All(ctx, db.DB)
+}
+
+// UpdatePasskeySignCount stores the signature counter of the last login with a passkey.
+func (db *dbAPI) UpdatePasskeySignCount(ctx context.Context, id []byte, signCount uint32) error {
+	where := &m.PasskeyWhere
+	_, err := m.Passkeys(where.ID.EQ(id)).
+		UpdateAll(ctx, db.DB, m.M{m.PasskeyColumns.SignCount: int64(signCount), m.PasskeyColumns.LastUsedAt: time.Now()})
+	return err
+}
+
+func (db *dbAPI) DeletePasskey(ctx context.Context, userID string, id []byte) (int64, error) {
+	where := &m.PasskeyWhere
+	return m.Passkeys(where.UserID.EQ(userID), where.ID.EQ(id)).DeleteAll(ctx, db.DB)
+}
+
+func (db *dbAPI) CreateIdentityProvider(ctx context.Context, provider *m.IdentityProvider) error {
+	provider.ID = xid.New().String()
+	return provider.Insert(ctx, db.DB, boil.Infer())
+}
+
+func (db *dbAPI) GetIdentityProvider(ctx context.Context, instanceID, slug string) (*m.IdentityProvider, error) {
+	where := &m.IdentityProviderWhere
+	provider, err := m.IdentityProviders(where.InstanceID.EQ(instanceID), where.Slug.EQ(slug)).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of oidc context
+		return nil, errors.WithStack(ErrIdentityProviderNotFound)
+	}
+	return provider, err
+}
+
+func (db *dbAPI) ListIdentityProviders(ctx context.Context, instanceID string) (m.IdentityProviderSlice, error) {
+	where := &m.IdentityProviderWhere
+	return m.IdentityProviders(where.InstanceID.EQ(instanceID), qm.OrderBy(m.IdentityProviderColumns.Name)).All(ctx, db.DB)
+}
+
+// CreateOIDCState stores a pending login redirected to a provider.
+func (db *dbAPI) CreateOIDCState(ctx context.Context, state *m.OidcState) error {
+	return state.Insert(ctx, db.DB, boil.Infer())
+}
+
+// ConsumeOIDCState consumes a valid pending login, loading its provider and the provider's instance.
+func (db *dbAPI) ConsumeOIDCState(ctx context.Context, state string) (s *m.OidcState, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	where := &m.OidcStateWhere
+	s, err = m.OidcStates(where.State.EQ(state), where.ExpiresAt.GT(time.Now()),
+		qm.Load(qm.Rels(m.OidcStateRels.Provider, m.IdentityProviderRels.Instance)), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of oidc context
+		err = errors.WithStack(ErrOIDCStateInvalid)
+		return
+	}
+	if err != nil {
+		return
+	}
+	_, err = s.Delete(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// DeleteExpiredOIDCStates deletes pending logins that were never completed.
+func (db *dbAPI) DeleteExpiredOIDCStates(ctx context.Context, before time.Time) (int64, error) {
+	where := &m.OidcStateWhere
+	return m.OidcStates(where.ExpiresAt.LT(before)).DeleteAll(ctx, db.DB)
+}
+
+func (db *dbAPI) GetIdentity(ctx context.Context, providerID, subject string) (*m.Identity, error) {
+	where := &m.IdentityWhere
+	identity, err := m.Identities(where.ProviderID.EQ(providerID), where.Subject.EQ(subject)).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of oidc context
+		return nil, errors.WithStack(ErrIdentityNotFound)
+	}
+	return identity, err
+}
+
+// LinkIdentity links an external identity to the user with the identity's verified email and activates the user.
+// If no such user exists, the user is created without password and with a profile in the given instance.
+func (db *dbAPI) LinkIdentity(ctx context.Context, identity *m.Identity, instanceID, name string) (user *m.User, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	user, err = m.Users(m.UserWhere.Email.EQ(identity.Email), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		user, err = db.CreateUser(ctx, tx, name, identity.Email, []byte{})
+		if err != nil {
+			return
+		}
+		_, err = db.CreateProfile(ctx, tx, instanceID, user, roles.NoRole)
+	}
+	if err != nil {
+		return
+	}
+
+	if !user.ActivatedAt.Valid {
+		user.ActivatedAt = null.TimeFrom(time.Now())
+		_, err = user.Update(ctx, tx, boil.Whitelist(m.UserColumns.ActivatedAt))
+		if err != nil {
+			return
+		}
+	}
+
+	identity.UserID = user.ID
+	err = identity.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (db *dbAPI) CreateOAuthClient(ctx context.Context, client *m.OauthClient) error {
+	client.ID = xid.New().String()
+	return client.Insert(ctx, db.DB, boil.Infer())
+}
+
+// GetOAuthClient loads a client with its instance. Clients of deleted instances are not found.
+func (db *dbAPI) GetOAuthClient(ctx context.Context, clientID string) (*m.OauthClient, error) {
+	client, err := m.OauthClients(m.OauthClientWhere.ID.EQ(clientID), qm.Load(m.OauthClientRels.Instance)).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of authorization context
+		return nil, errors.WithStack(ErrOAuthClientNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the instance is soft deleted, so it is not loaded if it was deleted
+	if client.R == nil || client.R.Instance == nil {
+		return nil, errors.WithStack(ErrOAuthClientNotFound)
+	}
+	return client, nil
+}
+
+// ListServiceAccounts lists the clients of an instance with a role.
+func (db *dbAPI) ListServiceAccounts(ctx context.Context, instanceID string) (m.OauthClientSlice, error) {
+	where := &m.OauthClientWhere
+	return m.OauthClients(where.InstanceID.EQ(instanceID), where.Role.IsNotNull(), qm.OrderBy(m.OauthClientColumns.CreatedAt)).All(ctx, db.DB)
+}
+
+// UpdateServiceAccountSecret replaces the digest of a service account's secret.
+func (db *dbAPI) UpdateServiceAccountSecret(ctx context.Context, instanceID, clientID string, secret []byte) (int64, error) {
+	where := &m.OauthClientWhere
+	return m.OauthClients(where.InstanceID.EQ(instanceID), where.ID.EQ(clientID), where.Role.IsNotNull()).
+		UpdateAll(ctx, db.DB, m.M{m.OauthClientColumns.Secret: secret})
+}
+
+func (db *dbAPI) DeleteServiceAccount(ctx context.Context, instanceID, clientID string) (int64, error) {
+	where := &m.OauthClientWhere
+	return m.OauthClients(where.InstanceID.EQ(instanceID), where.ID.EQ(clientID), where.Role.IsNotNull()).DeleteAll(ctx, db.DB)
+}
+
+// CreateAuthorizationCode stores the digest of an authorization code issued to a client.
+func (db *dbAPI) CreateAuthorizationCode(ctx context.Context, code *m.AuthorizationCode) error {
+	return code.Insert(ctx, db.DB, boil.Infer())
+}
+
+// ConsumeAuthorizationCode consumes a valid authorization code, loading its client.
+func (db *dbAPI) ConsumeAuthorizationCode(ctx context.Context, digest []byte) (code *m.AuthorizationCode, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	where := &m.AuthorizationCodeWhere
+	code, err = m.AuthorizationCodes(where.Digest.EQ(digest), where.ExpiresAt.GT(time.Now()),
+		qm.Load(m.AuthorizationCodeRels.Client), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of authorization context
+		err = errors.WithStack(ErrAuthorizationCodeInvalid)
+		return
+	}
+	if err != nil {
+		return
+	}
+	_, err = code.Delete(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// DeleteExpiredAuthorizationCodes deletes authorization codes that were never redeemed.
+func (db *dbAPI) DeleteExpiredAuthorizationCodes(ctx context.Context, before time.Time) (int64, error) {
+	where := &m.AuthorizationCodeWhere
+	return m.AuthorizationCodes(where.ExpiresAt.LT(before)).DeleteAll(ctx, db.DB)
+}
+
+// RecordLoginFailure counts a failed login under key and returns the failures not yet forgotten, including this one.
+// Failures expired by now are forgotten before counting.
+func (db *dbAPI) RecordLoginFailure(ctx context.Context, key string, now, expiresAt time.Time) (failures int, err error) {
+	// a single statement, so concurrent failures are all counted
+	err = db.DB.QueryRowContext(ctx, `
+		INSERT INTO login_attempts (key, failures, expires_at) VALUES ($1, 1, $3)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.expires_at < $2 THEN 1 ELSE login_attempts.failures + 1 END,
+			expires_at = GREATEST(login_attempts.expires_at, EXCLUDED.expires_at)
+		RETURNING failures`, key, now, expiresAt).Scan(&failures)
+	return
+}
+
+// LockLogin locks the logins counted under key until lockedUntil.
+func (db *dbAPI) LockLogin(ctx context.Context, key string, lockedUntil, expiresAt time.Time) error {
+	where := &m.LoginAttemptWhere
+	_, err := m.LoginAttempts(where.Key.EQ(key)).
+		UpdateAll(ctx, db.DB, m.M{m.LoginAttemptColumns.LockedUntil: lockedUntil, m.LoginAttemptColumns.ExpiresAt: expiresAt})
+	return err
+}
+
+// GetLoginLocks returns the keys locked at now with the end of their lock.
+func (db *dbAPI) GetLoginLocks(ctx context.Context, keys []string, now time.Time) (map[string]time.Time, error) {
+	where := &m.LoginAttemptWhere
+	attempts, err := m.LoginAttempts(where.Key.IN(keys), where.LockedUntil.GT(null.TimeFrom(now))).All(ctx, db.DB)
+	if err != nil {
+		return nil, err
+	}
+	locks := make(map[string]time.Time, len(attempts))
+	for _, a := range attempts {
+		locks[a.Key] = a.LockedUntil.Time
+	}
+	return locks, nil
+}
+
+// ResetLoginAttempts forgets the failures counted under key and lifts its lock.
+func (db *dbAPI) ResetLoginAttempts(ctx context.Context, key string) (int64, error) {
+	where := &m.LoginAttemptWhere
+	return m.LoginAttempts(where.Key.EQ(key)).DeleteAll(ctx, db.DB)
+}
+
+func (db *dbAPI) DeleteExpiredLoginAttempts(ctx context.Context, before time.Time) (int64, error) {
+	where := &m.LoginAttemptWhere
+	return m.LoginAttempts(where.ExpiresAt.LT(before)).DeleteAll(ctx, db.DB)
+}
+
+// CreateAPIKey stores an API key, its ID has to be set as it is part of the key.
+func (db *dbAPI) CreateAPIKey(ctx context.Context, key *m.APIKey) error {
+	return key.Insert(ctx, db.DB, boil.Infer())
+}
+
+func (db *dbAPI) GetAPIKey(ctx context.Context, id string) (*m.APIKey, error) {
+	key, err := m.FindAPIKey(ctx, db.DB, id)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of API key context
+		return nil, errors.WithStack(ErrAPIKeyNotFound)
+	}
+	return key, err
+}
+
+func (db *dbAPI) ListAPIKeys(ctx context.Context, userID, instanceID string) (m.APIKeySlice, error) {
+	where := &m.APIKeyWhere
+	return m.APIKeys(where.UserID.EQ(userID), where.InstanceID.EQ(instanceID), qm.OrderBy(m.APIKeyColumns.CreatedAt)).All(ctx, db.DB)
+}
+
+func (db *dbAPI) DeleteAPIKey(ctx context.Context, userID, id string) (int64, error) {
+	where := &m.APIKeyWhere
+	return m.APIKeys(where.UserID.EQ(userID), where.ID.EQ(id)).DeleteAll(ctx, db.DB)
+}
+
+// DeleteExpiredAPIKeys deletes API keys expired before the given time.
+func (db *dbAPI) DeleteExpiredAPIKeys(ctx context.Context, before time.Time) (int64, error) {
+	where := &m.APIKeyWhere
+	return m.APIKeys(where.ExpiresAt.LT(null.TimeFrom(before))).DeleteAll(ctx, db.DB)
+}
+
+// CreateInvitation stores an invitation, replacing a pending invitation of the same email to the instance.
+func (db *dbAPI) CreateInvitation(ctx context.Context, invitation *m.Invitation) (err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	where := &m.InvitationWhere
+	_, err = m.Invitations(where.InstanceID.EQ(invitation.InstanceID), where.Email.EQ(invitation.Email)).DeleteAll(ctx, tx)
+	if err != nil {
+		return
+	}
+	invitation.ID = xid.New().String()
+	err = invitation.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// GetInvitation loads a valid invitation by the digest of its token.
+func (db *dbAPI) GetInvitation(ctx context.Context, digest []byte) (*m.Invitation, error) {
+	where := &m.InvitationWhere
+	invitation, err := m.Invitations(where.Digest.EQ(digest), where.ExpiresAt.GT(time.Now())).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of invitation context
+		return nil, errors.WithStack(ErrInvitationInvalid)
+	}
+	return invitation, err
+}
+
+func (db *dbAPI) ListInvitations(ctx context.Context, instanceID string) (m.InvitationSlice, error) {
+	where := &m.InvitationWhere
+	return m.Invitations(where.InstanceID.EQ(instanceID), where.ExpiresAt.GT(time.Now()), qm.OrderBy(m.InvitationColumns.CreatedAt)).All(ctx, db.DB)
+}
+
+func (db *dbAPI) DeleteInvitation(ctx context.Context, instanceID, id string) (int64, error) {
+	where := &m.InvitationWhere
+	return m.Invitations(where.InstanceID.EQ(instanceID), where.ID.EQ(id)).DeleteAll(ctx, db.DB)
+}
+
+// AcceptInvitation consumes a valid invitation and gives the user with the invited email a profile with the invited role.
+// The user is created with the given name and password hash if there is none with the email yet.
+// An existing profile of the user in the instance gets the invited role, unless its role already includes the invited one.
+// If the role changes, the refresh tokens of the profile are revoked like on ChangeMemberRole and their number is returned.
+// The user is activated, as the token proves control over the email.
+func (db *dbAPI) AcceptInvitation(ctx context.Context, digest []byte, name string, passwordHash []byte) (user *m.User, profile *m.Profile, revoked int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	where := &m.InvitationWhere
+	invitation, err := m.Invitations(where.Digest.EQ(digest), where.ExpiresAt.GT(time.Now()), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of invitation context
+		err = errors.WithStack(ErrInvitationInvalid)
+		return
+	}
+	if err != nil {
+		return
+	}
+	// invitation tokens are single-use
+	_, err = invitation.Delete(ctx, tx)
+	if err != nil {
+		return
+	}
+
+	user, err = m.Users(m.UserWhere.Email.EQ(invitation.Email), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		if len(passwordHash) == 0 {
+			err = errors.WithStack(ErrUserDoesNotExist)
+			return
+		}
+		user, err = db.CreateUser(ctx, tx, name, invitation.Email, passwordHash)
+	}
+	if err != nil {
+		return
+	}
+	if !user.ActivatedAt.Valid {
+		user.ActivatedAt = null.TimeFrom(time.Now())
+		_, err = user.Update(ctx, tx, boil.Whitelist(m.UserColumns.ActivatedAt))
+		if err != nil {
+			return
+		}
+	}
+
+	profileWhere := &m.ProfileWhere
+	profile, err = m.Profiles(profileWhere.UserID.EQ(user.ID), profileWhere.InstanceID.EQ(invitation.InstanceID), qm.For("UPDATE")).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		profile, err = db.CreateProfile(ctx, tx, invitation.InstanceID, user, roles.Role(invitation.Role))
+	} else if err == nil && !includesRole(roles.Role(profile.Role.String), roles.Role(invitation.Role)) {
+		profile.Role = null.StringFrom(invitation.Role)
+		profile.UpdatedAt = time.Now()
+		_, err = profile.Update(ctx, tx, boil.Whitelist(m.ProfileColumns.Role, m.ProfileColumns.UpdatedAt))
+		if err != nil {
+			return
+		}
+		// sessions of the previous role end, so the new role takes effect with the next login
+		revoked, err = revokeTokens(ctx, tx, m.TokenWhere.ProfileID.EQ(profile.ID))
+	}
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// includesRole checks if a member's role already grants the permissions of another role, so it must not be replaced by it.
+func includesRole(role, other roles.Role) bool {
+	return role == other || roles.CanSwitchTo(role, other)
+}
+
+// DeleteExpiredInvitations deletes invitations that were never accepted.
+func (db *dbAPI) DeleteExpiredInvitations(ctx context.Context, before time.Time) (int64, error) {
+	where := &m.InvitationWhere
+	return m.Invitations(where.ExpiresAt.LT(before)).DeleteAll(ctx, db.DB)
+}
+
+// PurgeDeleted hard-deletes profiles, users and instances soft-deleted before the given time and returns their number.
+// Rows depending on them are deleted by cascade.
+func (db *dbAPI) PurgeDeleted(ctx context.Context, before time.Time) (purged int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	n, err := m.Profiles(qm.WithDeleted(), m.ProfileWhere.DeletedAt.LT(null.TimeFrom(before))).DeleteAll(ctx, tx, true)
+	purged += n
+	if err != nil {
+		return
+	}
+	n, err = m.Users(qm.WithDeleted(), m.UserWhere.DeletedAt.LT(null.TimeFrom(before))).DeleteAll(ctx, tx, true)
+	purged += n
+	if err != nil {
+		return
+	}
+	n, err = m.Instances(qm.WithDeleted(), m.InstanceWhere.DeletedAt.LT(null.TimeFrom(before))).DeleteAll(ctx, tx, true)
+	purged += n
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// ExportUser loads a user with profiles and their instances, refresh tokens, linked identities, passkeys, API keys and TOTP secret.
+func (db *dbAPI) ExportUser(ctx context.Context, userID string) (*m.User, error) {
+	user, err := m.Users(
+		m.UserWhere.ID.EQ(userID),
+		qm.Load(qm.Rels(m.UserRels.Profiles, m.ProfileRels.Instance)),
+		qm.Load(m.UserRels.Tokens, qm.OrderBy(m.TokenColumns.CreatedAt)),
+		qm.Load(m.UserRels.Identities),
+		qm.Load(m.UserRels.Passkeys),
+		qm.Load(m.UserRels.APIKeys),
+		qm.Load(m.UserRels.TotpSecrets),
+	).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of login context
+		return nil, errors.WithStack(ErrUserDoesNotExist)
+	}
+	return user, err
+}
+
+// EraseUser anonymizes a user and deletes everything else held about the user:
+// profiles are soft-deleted, credentials, linked identities, API keys, pending verifications and invitations are deleted.
+// The user row is kept with erased_at set, so data of other services can keep referring to it.
+// The number of revoked refresh tokens is returned.
+func (db *dbAPI) EraseUser(ctx context.Context, userID string) (revoked int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	user, err := m.Users(m.UserWhere.ID.EQ(userID)).One(ctx, tx)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of login context
+		err = errors.WithStack(ErrUserDoesNotExist)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	_, err = m.Profiles(m.ProfileWhere.UserID.EQ(userID)).DeleteAll(ctx, tx, false)
+	if err != nil {
+		return
+	}
+	for _, q := range []interface {
+		DeleteAll(context.Context, boil.ContextExecutor) (int64, error)
+	}{
+		m.APIKeys(m.APIKeyWhere.UserID.EQ(userID)),
+		m.Identities(m.IdentityWhere.UserID.EQ(userID)),
+		m.Passkeys(m.PasskeyWhere.UserID.EQ(userID)),
+		m.PasskeyChallenges(m.PasskeyChallengeWhere.UserID.EQ(null.StringFrom(userID))),
+		m.TotpSecrets(m.TotpSecretWhere.UserID.EQ(userID)),
+		m.RecoveryCodes(m.RecoveryCodeWhere.UserID.EQ(userID)),
+		m.Verifications(m.VerificationWhere.UserID.EQ(userID)),
+		m.PasswordResets(m.PasswordResetWhere.UserID.EQ(userID)),
+		m.AuthorizationCodes(m.AuthorizationCodeWhere.UserID.EQ(userID)),
+		m.Invitations(m.InvitationWhere.Email.EQ(user.Email)),
+		m.LoginAttempts(m.LoginAttemptWhere.Key.EQ(accountKey(user.Email))),
+	} {
+		_, err = q.DeleteAll(ctx, tx)
+		if err != nil {
+			return
+		}
+	}
+
+	if !user.ErasedAt.Valid {
+		user.Name = null.String{}
+		user.Email = userID + ErasedEmailSuffix
+		user.Password = []byte{}
+		user.ActivatedAt = null.Time{}
+		user.ErasedAt = null.TimeFrom(time.Now())
+		_, err = user.Update(ctx, tx, boil.Infer())
+		if err != nil {
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return
+	}
+
+	return db.DeleteAllTokens(ctx, userID)
+}
+
+// SaveToken stores the digest of a refresh token starting a new token family, i.e. a new session.
+// Tokens issued to an OAuth client are bound to the client, tokens of first-party logins have no client ID.
+// The session keeps the time the user logged in with credentials, which may precede the session when switching instances.
+// A digest that is stored already fails with ErrTokenDuplicate.
+func (db *dbAPI) SaveToken(ctx context.Context, profile *m.Profile, digest []byte, expiresAt, loggedInAt time.Time, accessJTI, clientID, userAgent, ip string) error {
+	t := m.Token{
+		UserID:     profile.UserID,
+		ProfileID:  profile.ID,
+		Digest:     null.BytesFrom(digest),
+		ExpiresAt:  expiresAt,
+		Family:     xid.New().String(),
+		AccessJti:  null.StringFrom(accessJTI),
+		ClientID:   null.NewString(clientID, len(clientID) > 0),
+		UserAgent:  null.NewString(userAgent, len(userAgent) > 0),
+		IP:         null.NewString(ip, len(ip) > 0),
+		LoggedInAt: loggedInAt,
+	}
+	err := t.Insert(ctx, db.DB, boil.Infer())
+	if isUniqueViolation(err) {
+		return errors.WithStack(ErrTokenDuplicate)
+	}
+	return err
+}
+
+func (db *dbAPI) GetToken(ctx context.Context, userID, profileID string, digest []byte) (*m.Token, error) {
+	where := &m.TokenWhere
+	t, err := m.Tokens(where.UserID.EQ(userID), where.ProfileID.EQ(profileID), where.Digest.EQ(null.BytesFrom(digest))).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of refresh context
+		return nil, errors.WithStack(ErrTokenRevoked)
+	}
+	return t, err
+}
+
+// RotateToken consumes the parent refresh token and stores its successor in the same family.
+// If the parent was consumed concurrently, ErrTokenReused is returned, if the successor is stored already, ErrTokenDuplicate.
+func (db *dbAPI) RotateToken(ctx context.Context, parent *m.Token, digest []byte, expiresAt time.Time, accessJTI string) (err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	where := &m.TokenWhere
+	n, err := m.Tokens(where.ID.EQ(parent.ID), where.ConsumedAt.IsNull()).UpdateAll(ctx, tx, m.M{m.TokenColumns.ConsumedAt: time.Now()})
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		err = errors.WithStack(ErrTokenReused)
+		return
+	}
+
+	t := m.Token{
+		UserID:     parent.UserID,
+		ProfileID:  parent.ProfileID,
+		Digest:     null.BytesFrom(digest),
+		ExpiresAt:  expiresAt,
+		Family:     parent.Family,
+		ParentID:   null.Int64From(parent.ID),
+		AccessJti:  null.StringFrom(accessJTI),
+		ClientID:   parent.ClientID,
+		UserAgent:  parent.UserAgent,
+		IP:         parent.IP,
+		LoggedInAt: parent.LoggedInAt,
+	}
+	err = t.Insert(ctx, tx, boil.Infer())
+	if isUniqueViolation(err) {
+		err = errors.WithStack(ErrTokenDuplicate)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (db *dbAPI) DeleteTokenFamily(ctx context.Context, family string) (int64, error) {
+	where := &m.TokenWhere
+	return db.deleteTokens(ctx,
+		where.Family.EQ(family),
+	)
+}
+
+// ListSessions lists the active refresh token of each session of a profile, latest login first.
+func (db *dbAPI) ListSessions(ctx context.Context, profileID string) (m.TokenSlice, error) {
+	where := &m.TokenWhere
+	return m.Tokens(
+		where.ProfileID.EQ(profileID),
+		where.ConsumedAt.IsNull(),
+		where.ExpiresAt.GT(time.Now()),
+		qm.OrderBy(m.TokenColumns.LoggedInAt+" DESC"),
+	).All(ctx, db.DB)
+}
+
+// GetSession returns the active refresh token of a session together with its profile.
+func (db *dbAPI) GetSession(ctx context.Context, family string) (*m.Token, error) {
+	where := &m.TokenWhere
+	t, err := m.Tokens(
+		where.Family.EQ(family),
+		where.ConsumedAt.IsNull(),
+		where.ExpiresAt.GT(time.Now()),
+		qm.Load(m.TokenRels.Profile),
+	).One(ctx, db.DB)
+	if err == sql.ErrNoRows {
+		// transform sql error in specific error of session context
+		return nil, errors.WithStack(ErrSessionNotFound)
+	}
+	return t, err
+}
+
+// DeleteExpiredTokens deletes up to limit tokens that expired before the given time.
+func (db *dbAPI) DeleteExpiredTokens(ctx context.Context, before time.Time, limit int) (int64, error) {
+	where := &m.TokenWhere
+	expired, err := m.Tokens(
+		qm.Select(m.TokenColumns.ID),
+		where.ExpiresAt.LT(before),
+		qm.Limit(limit),
+	).All(ctx, db.DB)
+	if err != nil || len(expired) == 0 {
+		return 0, err
+	}
+
+	ids := make([]int64, len(expired))
+	for i, t := range expired {
+		ids[i] = t.ID
+	}
+	return m.Tokens(where.ID.IN(ids)).DeleteAll(ctx, db.DB)
+}
+
+// HashTokens replaces plaintext refresh tokens stored before tokens were stored as digest.
+// With purge, these tokens are deleted instead, which ends the affected sessions.
+func (db *dbAPI) HashTokens(ctx context.Context, purge bool) (n int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	where := &m.TokenWhere
+	legacy, err := m.Tokens(where.Token.IsNotNull(), qm.For("UPDATE")).All(ctx, tx)
+	if err != nil {
+		return
+	}
+	for _, t := range legacy {
+		if purge {
+			_, err = t.Delete(ctx, tx)
+		} else {
+			t.Digest = null.BytesFrom(tokens.Digest(t.Token.String))
+			t.Token = null.String{}
+			_, err = t.Update(ctx, tx, boil.Whitelist(m.TokenColumns.Digest, m.TokenColumns.Token))
+		}
+		if err != nil {
+			return
+		}
+		n++
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (db *dbAPI) DeleteToken(ctx context.Context, profileID string) (int64, error) {
+	where := &m.TokenWhere
+	return db.deleteTokens(ctx,
+		where.ProfileID.EQ(profileID),
+	)
+}
+
+func (db *dbAPI) DeleteAllTokens(ctx context.Context, userID string) (int64, error) {
+	where := &m.TokenWhere
+	return db.deleteTokens(ctx,
+		where.UserID.EQ(userID),
+	)
+}
+
+// deleteTokens deletes the refresh tokens matching mods
+// and denies the access tokens issued together with them that did not expire yet.
+func (db *dbAPI) deleteTokens(ctx context.Context, mods ...qm.QueryMod) (numDeleted int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	numDeleted, err = revokeTokens(ctx, tx, mods...)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// isUniqueViolation reports whether err is caused by a unique constraint of the database.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// revokeTokens is deleteTokens as part of a surrounding transaction.
+func revokeTokens(ctx context.Context, tx *sql.Tx, mods ...qm.QueryMod) (numDeleted int64, err error) {
+	where := &m.TokenWhere
+	recent, err := m.Tokens(append([]qm.QueryMod{
+		where.AccessJti.IsNotNull(),
+		where.CreatedAt.GT(time.Now().Add(-tokens.AccessTokenExpiry)),
+	}, mods...)...).All(ctx, tx)
+	if err != nil {
+		return
+	}
+	for _, t := range recent {
+		d := m.DeniedToken{
+			Jti:       t.AccessJti.String,
+			ExpiresAt: t.CreatedAt.Add(tokens.AccessTokenExpiry),
+		}
+		err = d.Upsert(ctx, tx, false, []string{m.DeniedTokenColumns.Jti}, boil.None(), boil.Infer())
+		if err != nil {
+			return
+		}
+	}
+
+	return m.Tokens(mods...).DeleteAll(ctx, tx)
+}
+
+// LoadDenylist loads the IDs of denied access tokens that did not expire yet.
+func (db *dbAPI) LoadDenylist(ctx context.Context) (map[string]time.Time, error) {
+	where := &m.DeniedTokenWhere
+	denied, err := m.DeniedTokens(where.ExpiresAt.GT(time.Now())).All(ctx, db.DB)
+	if err != nil {
+		return nil, err
+	}
+	denylist := make(map[string]time.Time, len(denied))
+	for _, d := range denied {
+		denylist[d.Jti] = d.ExpiresAt
+	}
+	return denylist, nil
+}
+
+// DeleteExpiredDenials deletes denylist entries of access tokens that expired before the given time.
+func (db *dbAPI) DeleteExpiredDenials(ctx context.Context, before time.Time) (int64, error) {
+	where := &m.DeniedTokenWhere
+	return m.DeniedTokens(where.ExpiresAt.LT(before)).DeleteAll(ctx, db.DB)
 }
@@ -12,6 +12,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	dbmodels "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	paging "github.com/smartnuance/saas-kit/pkg/lib/paging"
 	roles "github.com/smartnuance/saas-kit/pkg/lib/roles"
 )
 
@@ -38,6 +39,37 @@ func (m *MockDBAPI) EXPECT() *MockDBAPIMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockDBAPI) AcceptInvitation(arg0 context.Context, arg1 []byte, arg2 string, arg3 []byte) (*dbmodels.User, *dbmodels.Profile, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(*dbmodels.Profile)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockDBAPIMockRecorder) AcceptInvitation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockDBAPI)(nil).AcceptInvitation), arg0, arg1, arg2, arg3)
+}
+
+// ActivateUser mocks base method.
+func (m *MockDBAPI) ActivateUser(arg0 context.Context, arg1 *sql.Tx, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateUser indicates an expected call of ActivateUser.
+func (mr *MockDBAPIMockRecorder) ActivateUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUser", reflect.TypeOf((*MockDBAPI)(nil).ActivateUser), arg0, arg1, arg2)
+}
+
 // BeginTx mocks base method.
 func (m *MockDBAPI) BeginTx(arg0 context.Context) (*sql.Tx, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +85,21 @@ func (mr *MockDBAPIMockRecorder) BeginTx(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockDBAPI)(nil).BeginTx), arg0)
 }
 
+// ChangePassword mocks base method.
+func (m *MockDBAPI) ChangePassword(arg0 context.Context, arg1 string, arg2 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockDBAPIMockRecorder) ChangePassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockDBAPI)(nil).ChangePassword), arg0, arg1, arg2)
+}
+
 // Commit mocks base method.
 func (m *MockDBAPI) Commit(arg0 *sql.Tx) error {
 	m.ctrl.T.Helper()
@@ -67,6 +114,205 @@ func (mr *MockDBAPIMockRecorder) Commit(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockDBAPI)(nil).Commit), arg0)
 }
 
+// ConfirmTOTPSecret mocks base method.
+func (m *MockDBAPI) ConfirmTOTPSecret(arg0 context.Context, arg1 string, arg2 int64, arg3 [][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPSecret", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTPSecret indicates an expected call of ConfirmTOTPSecret.
+func (mr *MockDBAPIMockRecorder) ConfirmTOTPSecret(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPSecret", reflect.TypeOf((*MockDBAPI)(nil).ConfirmTOTPSecret), arg0, arg1, arg2, arg3)
+}
+
+// ConsumeAuthorizationCode mocks base method.
+func (m *MockDBAPI) ConsumeAuthorizationCode(arg0 context.Context, arg1 []byte) (*dbmodels.AuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeAuthorizationCode", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.AuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeAuthorizationCode indicates an expected call of ConsumeAuthorizationCode.
+func (mr *MockDBAPIMockRecorder) ConsumeAuthorizationCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeAuthorizationCode", reflect.TypeOf((*MockDBAPI)(nil).ConsumeAuthorizationCode), arg0, arg1)
+}
+
+// ConsumeOIDCState mocks base method.
+func (m *MockDBAPI) ConsumeOIDCState(arg0 context.Context, arg1 string) (*dbmodels.OidcState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOIDCState", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.OidcState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOIDCState indicates an expected call of ConsumeOIDCState.
+func (mr *MockDBAPIMockRecorder) ConsumeOIDCState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOIDCState", reflect.TypeOf((*MockDBAPI)(nil).ConsumeOIDCState), arg0, arg1)
+}
+
+// ConsumePasskeyChallenge mocks base method.
+func (m *MockDBAPI) ConsumePasskeyChallenge(arg0 context.Context, arg1 []byte, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePasskeyChallenge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumePasskeyChallenge indicates an expected call of ConsumePasskeyChallenge.
+func (mr *MockDBAPIMockRecorder) ConsumePasskeyChallenge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePasskeyChallenge", reflect.TypeOf((*MockDBAPI)(nil).ConsumePasskeyChallenge), arg0, arg1, arg2)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockDBAPI) CreateAPIKey(arg0 context.Context, arg1 *dbmodels.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockDBAPIMockRecorder) CreateAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockDBAPI)(nil).CreateAPIKey), arg0, arg1)
+}
+
+// CreateAuthorizationCode mocks base method.
+func (m *MockDBAPI) CreateAuthorizationCode(arg0 context.Context, arg1 *dbmodels.AuthorizationCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthorizationCode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuthorizationCode indicates an expected call of CreateAuthorizationCode.
+func (mr *MockDBAPIMockRecorder) CreateAuthorizationCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthorizationCode", reflect.TypeOf((*MockDBAPI)(nil).CreateAuthorizationCode), arg0, arg1)
+}
+
+// CreateIdentityProvider mocks base method.
+func (m *MockDBAPI) CreateIdentityProvider(arg0 context.Context, arg1 *dbmodels.IdentityProvider) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentityProvider", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentityProvider indicates an expected call of CreateIdentityProvider.
+func (mr *MockDBAPIMockRecorder) CreateIdentityProvider(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentityProvider", reflect.TypeOf((*MockDBAPI)(nil).CreateIdentityProvider), arg0, arg1)
+}
+
+// CreateInstance mocks base method.
+func (m *MockDBAPI) CreateInstance(arg0 context.Context, arg1, arg2 string) (*dbmodels.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dbmodels.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInstance indicates an expected call of CreateInstance.
+func (mr *MockDBAPIMockRecorder) CreateInstance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstance", reflect.TypeOf((*MockDBAPI)(nil).CreateInstance), arg0, arg1, arg2)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockDBAPI) CreateInvitation(arg0 context.Context, arg1 *dbmodels.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockDBAPIMockRecorder) CreateInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockDBAPI)(nil).CreateInvitation), arg0, arg1)
+}
+
+// CreateOAuthClient mocks base method.
+func (m *MockDBAPI) CreateOAuthClient(arg0 context.Context, arg1 *dbmodels.OauthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockDBAPIMockRecorder) CreateOAuthClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockDBAPI)(nil).CreateOAuthClient), arg0, arg1)
+}
+
+// CreateOIDCState mocks base method.
+func (m *MockDBAPI) CreateOIDCState(arg0 context.Context, arg1 *dbmodels.OidcState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOIDCState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOIDCState indicates an expected call of CreateOIDCState.
+func (mr *MockDBAPIMockRecorder) CreateOIDCState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOIDCState", reflect.TypeOf((*MockDBAPI)(nil).CreateOIDCState), arg0, arg1)
+}
+
+// CreatePasskey mocks base method.
+func (m *MockDBAPI) CreatePasskey(arg0 context.Context, arg1 *dbmodels.Passkey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasskey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasskey indicates an expected call of CreatePasskey.
+func (mr *MockDBAPIMockRecorder) CreatePasskey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasskey", reflect.TypeOf((*MockDBAPI)(nil).CreatePasskey), arg0, arg1)
+}
+
+// CreatePasskeyChallenge mocks base method.
+func (m *MockDBAPI) CreatePasskeyChallenge(arg0 context.Context, arg1 string, arg2 []byte, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasskeyChallenge", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasskeyChallenge indicates an expected call of CreatePasskeyChallenge.
+func (mr *MockDBAPIMockRecorder) CreatePasskeyChallenge(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasskeyChallenge", reflect.TypeOf((*MockDBAPI)(nil).CreatePasskeyChallenge), arg0, arg1, arg2, arg3)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockDBAPI) CreatePasswordReset(arg0 context.Context, arg1 string, arg2 []byte, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockDBAPIMockRecorder) CreatePasswordReset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockDBAPI)(nil).CreatePasswordReset), arg0, arg1, arg2, arg3)
+}
+
 // CreateProfile mocks base method.
 func (m *MockDBAPI) CreateProfile(arg0 context.Context, arg1 *sql.Tx, arg2 string, arg3 *dbmodels.User, arg4 roles.Role) (*dbmodels.Profile, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +343,35 @@ func (mr *MockDBAPIMockRecorder) CreateUser(arg0, arg1, arg2, arg3, arg4 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockDBAPI)(nil).CreateUser), arg0, arg1, arg2, arg3, arg4)
 }
 
+// CreateVerification mocks base method.
+func (m *MockDBAPI) CreateVerification(arg0 context.Context, arg1 *sql.Tx, arg2, arg3 string, arg4 []byte, arg5 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVerification", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVerification indicates an expected call of CreateVerification.
+func (mr *MockDBAPIMockRecorder) CreateVerification(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVerification", reflect.TypeOf((*MockDBAPI)(nil).CreateVerification), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// DeleteAPIKey mocks base method.
+func (m *MockDBAPI) DeleteAPIKey(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockDBAPIMockRecorder) DeleteAPIKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockDBAPI)(nil).DeleteAPIKey), arg0, arg1, arg2)
+}
+
 // DeleteAllTokens mocks base method.
 func (m *MockDBAPI) DeleteAllTokens(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -112,135 +387,996 @@ func (mr *MockDBAPIMockRecorder) DeleteAllTokens(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllTokens", reflect.TypeOf((*MockDBAPI)(nil).DeleteAllTokens), arg0, arg1)
 }
 
-// DeleteToken mocks base method.
-func (m *MockDBAPI) DeleteToken(arg0 context.Context, arg1 string) (int64, error) {
+// DeleteExpiredAPIKeys mocks base method.
+func (m *MockDBAPI) DeleteExpiredAPIKeys(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteToken", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteExpiredAPIKeys", arg0, arg1)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteToken indicates an expected call of DeleteToken.
-func (mr *MockDBAPIMockRecorder) DeleteToken(arg0, arg1 interface{}) *gomock.Call {
+// DeleteExpiredAPIKeys indicates an expected call of DeleteExpiredAPIKeys.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredAPIKeys(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockDBAPI)(nil).DeleteToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredAPIKeys", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredAPIKeys), arg0, arg1)
 }
 
-// DeleteUser mocks base method.
-func (m *MockDBAPI) DeleteUser(arg0 context.Context, arg1 string) error {
+// DeleteExpiredAuthorizationCodes mocks base method.
+func (m *MockDBAPI) DeleteExpiredAuthorizationCodes(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteExpiredAuthorizationCodes", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockDBAPIMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+// DeleteExpiredAuthorizationCodes indicates an expected call of DeleteExpiredAuthorizationCodes.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredAuthorizationCodes(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDBAPI)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredAuthorizationCodes", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredAuthorizationCodes), arg0, arg1)
 }
 
-// FindUserByEmail mocks base method.
-func (m *MockDBAPI) FindUserByEmail(arg0 context.Context, arg1 string) (*dbmodels.User, error) {
+// DeleteExpiredDenials mocks base method.
+func (m *MockDBAPI) DeleteExpiredDenials(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindUserByEmail", arg0, arg1)
-	ret0, _ := ret[0].(*dbmodels.User)
+	ret := m.ctrl.Call(m, "DeleteExpiredDenials", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindUserByEmail indicates an expected call of FindUserByEmail.
-func (mr *MockDBAPIMockRecorder) FindUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+// DeleteExpiredDenials indicates an expected call of DeleteExpiredDenials.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredDenials(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByEmail", reflect.TypeOf((*MockDBAPI)(nil).FindUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredDenials", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredDenials), arg0, arg1)
 }
 
-// GetInstance mocks base method.
-func (m *MockDBAPI) GetInstance(arg0 context.Context, arg1 string) (*dbmodels.Instance, error) {
+// DeleteExpiredInvitations mocks base method.
+func (m *MockDBAPI) DeleteExpiredInvitations(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInstance", arg0, arg1)
-	ret0, _ := ret[0].(*dbmodels.Instance)
+	ret := m.ctrl.Call(m, "DeleteExpiredInvitations", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetInstance indicates an expected call of GetInstance.
-func (mr *MockDBAPIMockRecorder) GetInstance(arg0, arg1 interface{}) *gomock.Call {
+// DeleteExpiredInvitations indicates an expected call of DeleteExpiredInvitations.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredInvitations(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstance", reflect.TypeOf((*MockDBAPI)(nil).GetInstance), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredInvitations", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredInvitations), arg0, arg1)
 }
 
-// GetProfile mocks base method.
-func (m *MockDBAPI) GetProfile(arg0 context.Context, arg1, arg2 string) (*dbmodels.Profile, error) {
+// DeleteExpiredLoginAttempts mocks base method.
+func (m *MockDBAPI) DeleteExpiredLoginAttempts(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProfile", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*dbmodels.Profile)
+	ret := m.ctrl.Call(m, "DeleteExpiredLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProfile indicates an expected call of GetProfile.
-func (mr *MockDBAPIMockRecorder) GetProfile(arg0, arg1, arg2 interface{}) *gomock.Call {
+// DeleteExpiredLoginAttempts indicates an expected call of DeleteExpiredLoginAttempts.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredLoginAttempts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockDBAPI)(nil).GetProfile), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLoginAttempts", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredLoginAttempts), arg0, arg1)
 }
 
-// GetUserAndProfile mocks base method.
-func (m *MockDBAPI) GetUserAndProfile(arg0 context.Context, arg1, arg2 string) (*dbmodels.User, *dbmodels.Profile, error) {
+// DeleteExpiredOIDCStates mocks base method.
+func (m *MockDBAPI) DeleteExpiredOIDCStates(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserAndProfile", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*dbmodels.User)
-	ret1, _ := ret[1].(*dbmodels.Profile)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "DeleteExpiredOIDCStates", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetUserAndProfile indicates an expected call of GetUserAndProfile.
-func (mr *MockDBAPIMockRecorder) GetUserAndProfile(arg0, arg1, arg2 interface{}) *gomock.Call {
+// DeleteExpiredOIDCStates indicates an expected call of DeleteExpiredOIDCStates.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredOIDCStates(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAndProfile", reflect.TypeOf((*MockDBAPI)(nil).GetUserAndProfile), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredOIDCStates", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredOIDCStates), arg0, arg1)
 }
 
-// HasToken mocks base method.
-func (m *MockDBAPI) HasToken(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+// DeleteExpiredPasskeyChallenges mocks base method.
+func (m *MockDBAPI) DeleteExpiredPasskeyChallenges(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasToken", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "DeleteExpiredPasskeyChallenges", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HasToken indicates an expected call of HasToken.
-func (mr *MockDBAPIMockRecorder) HasToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// DeleteExpiredPasskeyChallenges indicates an expected call of DeleteExpiredPasskeyChallenges.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredPasskeyChallenges(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasToken", reflect.TypeOf((*MockDBAPI)(nil).HasToken), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredPasskeyChallenges", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredPasskeyChallenges), arg0, arg1)
 }
 
-// Rollback mocks base method.
-func (m *MockDBAPI) Rollback(arg0 *sql.Tx) error {
+// DeleteExpiredTokens mocks base method.
+func (m *MockDBAPI) DeleteExpiredTokens(arg0 context.Context, arg1 time.Time, arg2 int) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Rollback", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteExpiredTokens", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Rollback indicates an expected call of Rollback.
-func (mr *MockDBAPIMockRecorder) Rollback(arg0 interface{}) *gomock.Call {
+// DeleteExpiredTokens indicates an expected call of DeleteExpiredTokens.
+func (mr *MockDBAPIMockRecorder) DeleteExpiredTokens(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockDBAPI)(nil).Rollback), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredTokens", reflect.TypeOf((*MockDBAPI)(nil).DeleteExpiredTokens), arg0, arg1, arg2)
 }
 
-// SaveToken mocks base method.
-func (m *MockDBAPI) SaveToken(arg0 context.Context, arg1 *dbmodels.Profile, arg2 string, arg3 time.Time) error {
+// DeleteInstance mocks base method.
+func (m *MockDBAPI) DeleteInstance(arg0 context.Context, arg1 string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveToken", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteInstance", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SaveToken indicates an expected call of SaveToken.
-func (mr *MockDBAPIMockRecorder) SaveToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// DeleteInstance indicates an expected call of DeleteInstance.
+func (mr *MockDBAPIMockRecorder) DeleteInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInstance", reflect.TypeOf((*MockDBAPI)(nil).DeleteInstance), arg0, arg1)
+}
+
+// DeleteInvitation mocks base method.
+func (m *MockDBAPI) DeleteInvitation(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvitation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInvitation indicates an expected call of DeleteInvitation.
+func (mr *MockDBAPIMockRecorder) DeleteInvitation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvitation", reflect.TypeOf((*MockDBAPI)(nil).DeleteInvitation), arg0, arg1, arg2)
+}
+
+// DeletePasskey mocks base method.
+func (m *MockDBAPI) DeletePasskey(arg0 context.Context, arg1 string, arg2 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasskey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePasskey indicates an expected call of DeletePasskey.
+func (mr *MockDBAPIMockRecorder) DeletePasskey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasskey", reflect.TypeOf((*MockDBAPI)(nil).DeletePasskey), arg0, arg1, arg2)
+}
+
+// DeleteProfile mocks base method.
+func (m *MockDBAPI) DeleteProfile(arg0 context.Context, arg1 *dbmodels.Profile) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProfile", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProfile indicates an expected call of DeleteProfile.
+func (mr *MockDBAPIMockRecorder) DeleteProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfile", reflect.TypeOf((*MockDBAPI)(nil).DeleteProfile), arg0, arg1)
+}
+
+// DeleteServiceAccount mocks base method.
+func (m *MockDBAPI) DeleteServiceAccount(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceAccount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteServiceAccount indicates an expected call of DeleteServiceAccount.
+func (mr *MockDBAPIMockRecorder) DeleteServiceAccount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockDBAPI)(nil).DeleteServiceAccount), arg0, arg1, arg2)
+}
+
+// DeleteTOTPSecret mocks base method.
+func (m *MockDBAPI) DeleteTOTPSecret(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTPSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTPSecret indicates an expected call of DeleteTOTPSecret.
+func (mr *MockDBAPIMockRecorder) DeleteTOTPSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTPSecret", reflect.TypeOf((*MockDBAPI)(nil).DeleteTOTPSecret), arg0, arg1)
+}
+
+// DeleteToken mocks base method.
+func (m *MockDBAPI) DeleteToken(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockDBAPIMockRecorder) DeleteToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockDBAPI)(nil).DeleteToken), arg0, arg1)
+}
+
+// DeleteTokenFamily mocks base method.
+func (m *MockDBAPI) DeleteTokenFamily(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTokenFamily", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTokenFamily indicates an expected call of DeleteTokenFamily.
+func (mr *MockDBAPIMockRecorder) DeleteTokenFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTokenFamily", reflect.TypeOf((*MockDBAPI)(nil).DeleteTokenFamily), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockDBAPI) DeleteUser(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockDBAPIMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDBAPI)(nil).DeleteUser), arg0, arg1)
+}
+
+// EraseUser mocks base method.
+func (m *MockDBAPI) EraseUser(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockDBAPIMockRecorder) EraseUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockDBAPI)(nil).EraseUser), arg0, arg1)
+}
+
+// ExportUser mocks base method.
+func (m *MockDBAPI) ExportUser(arg0 context.Context, arg1 string) (*dbmodels.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUser", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUser indicates an expected call of ExportUser.
+func (mr *MockDBAPIMockRecorder) ExportUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUser", reflect.TypeOf((*MockDBAPI)(nil).ExportUser), arg0, arg1)
+}
+
+// FindUserByEmail mocks base method.
+func (m *MockDBAPI) FindUserByEmail(arg0 context.Context, arg1 string) (*dbmodels.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByEmail indicates an expected call of FindUserByEmail.
+func (mr *MockDBAPIMockRecorder) FindUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByEmail", reflect.TypeOf((*MockDBAPI)(nil).FindUserByEmail), arg0, arg1)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockDBAPI) GetAPIKey(arg0 context.Context, arg1 string) (*dbmodels.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockDBAPIMockRecorder) GetAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockDBAPI)(nil).GetAPIKey), arg0, arg1)
+}
+
+// GetIdentity mocks base method.
+func (m *MockDBAPI) GetIdentity(arg0 context.Context, arg1, arg2 string) (*dbmodels.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dbmodels.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockDBAPIMockRecorder) GetIdentity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockDBAPI)(nil).GetIdentity), arg0, arg1, arg2)
+}
+
+// GetIdentityProvider mocks base method.
+func (m *MockDBAPI) GetIdentityProvider(arg0 context.Context, arg1, arg2 string) (*dbmodels.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentityProvider", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dbmodels.IdentityProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentityProvider indicates an expected call of GetIdentityProvider.
+func (mr *MockDBAPIMockRecorder) GetIdentityProvider(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentityProvider", reflect.TypeOf((*MockDBAPI)(nil).GetIdentityProvider), arg0, arg1, arg2)
+}
+
+// GetInstance mocks base method.
+func (m *MockDBAPI) GetInstance(arg0 context.Context, arg1 string) (*dbmodels.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstance", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstance indicates an expected call of GetInstance.
+func (mr *MockDBAPIMockRecorder) GetInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstance", reflect.TypeOf((*MockDBAPI)(nil).GetInstance), arg0, arg1)
+}
+
+// GetInstanceByID mocks base method.
+func (m *MockDBAPI) GetInstanceByID(arg0 context.Context, arg1 string) (*dbmodels.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceByID", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceByID indicates an expected call of GetInstanceByID.
+func (mr *MockDBAPIMockRecorder) GetInstanceByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceByID", reflect.TypeOf((*MockDBAPI)(nil).GetInstanceByID), arg0, arg1)
+}
+
+// GetInvitation mocks base method.
+func (m *MockDBAPI) GetInvitation(arg0 context.Context, arg1 []byte) (*dbmodels.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockDBAPIMockRecorder) GetInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockDBAPI)(nil).GetInvitation), arg0, arg1)
+}
+
+// GetLoginLocks mocks base method.
+func (m *MockDBAPI) GetLoginLocks(arg0 context.Context, arg1 []string, arg2 time.Time) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginLocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLocks indicates an expected call of GetLoginLocks.
+func (mr *MockDBAPIMockRecorder) GetLoginLocks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLocks", reflect.TypeOf((*MockDBAPI)(nil).GetLoginLocks), arg0, arg1, arg2)
+}
+
+// GetMember mocks base method.
+func (m *MockDBAPI) GetMember(arg0 context.Context, arg1, arg2 string) (*dbmodels.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dbmodels.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockDBAPIMockRecorder) GetMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockDBAPI)(nil).GetMember), arg0, arg1, arg2)
+}
+
+// GetOAuthClient mocks base method.
+func (m *MockDBAPI) GetOAuthClient(arg0 context.Context, arg1 string) (*dbmodels.OauthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthClient", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.OauthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthClient indicates an expected call of GetOAuthClient.
+func (mr *MockDBAPIMockRecorder) GetOAuthClient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthClient", reflect.TypeOf((*MockDBAPI)(nil).GetOAuthClient), arg0, arg1)
+}
+
+// GetPasskey mocks base method.
+func (m *MockDBAPI) GetPasskey(arg0 context.Context, arg1 []byte) (*dbmodels.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasskey", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasskey indicates an expected call of GetPasskey.
+func (mr *MockDBAPIMockRecorder) GetPasskey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasskey", reflect.TypeOf((*MockDBAPI)(nil).GetPasskey), arg0, arg1)
+}
+
+// GetPasswordResetUser mocks base method.
+func (m *MockDBAPI) GetPasswordResetUser(arg0 context.Context, arg1 []byte) (*dbmodels.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetUser", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetUser indicates an expected call of GetPasswordResetUser.
+func (mr *MockDBAPIMockRecorder) GetPasswordResetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetUser", reflect.TypeOf((*MockDBAPI)(nil).GetPasswordResetUser), arg0, arg1)
+}
+
+// GetProfile mocks base method.
+func (m *MockDBAPI) GetProfile(arg0 context.Context, arg1, arg2 string) (*dbmodels.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dbmodels.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockDBAPIMockRecorder) GetProfile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockDBAPI)(nil).GetProfile), arg0, arg1, arg2)
+}
+
+// GetSession mocks base method.
+func (m *MockDBAPI) GetSession(arg0 context.Context, arg1 string) (*dbmodels.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockDBAPIMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockDBAPI)(nil).GetSession), arg0, arg1)
+}
+
+// GetTOTPSecret mocks base method.
+func (m *MockDBAPI) GetTOTPSecret(arg0 context.Context, arg1 string) (*dbmodels.TotpSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTPSecret", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.TotpSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTPSecret indicates an expected call of GetTOTPSecret.
+func (mr *MockDBAPIMockRecorder) GetTOTPSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTPSecret", reflect.TypeOf((*MockDBAPI)(nil).GetTOTPSecret), arg0, arg1)
+}
+
+// GetToken mocks base method.
+func (m *MockDBAPI) GetToken(arg0 context.Context, arg1, arg2 string, arg3 []byte) (*dbmodels.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetToken", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dbmodels.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken indicates an expected call of GetToken.
+func (mr *MockDBAPIMockRecorder) GetToken(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken", reflect.TypeOf((*MockDBAPI)(nil).GetToken), arg0, arg1, arg2, arg3)
+}
+
+// GetUser mocks base method.
+func (m *MockDBAPI) GetUser(arg0 context.Context, arg1 string) (*dbmodels.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockDBAPIMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockDBAPI)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserAndProfile mocks base method.
+func (m *MockDBAPI) GetUserAndProfile(arg0 context.Context, arg1, arg2 string) (*dbmodels.User, *dbmodels.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAndProfile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(*dbmodels.Profile)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserAndProfile indicates an expected call of GetUserAndProfile.
+func (mr *MockDBAPIMockRecorder) GetUserAndProfile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAndProfile", reflect.TypeOf((*MockDBAPI)(nil).GetUserAndProfile), arg0, arg1, arg2)
+}
+
+// HashTokens mocks base method.
+func (m *MockDBAPI) HashTokens(arg0 context.Context, arg1 bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashTokens", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HashTokens indicates an expected call of HashTokens.
+func (mr *MockDBAPIMockRecorder) HashTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashTokens", reflect.TypeOf((*MockDBAPI)(nil).HashTokens), arg0, arg1)
+}
+
+// LinkIdentity mocks base method.
+func (m *MockDBAPI) LinkIdentity(arg0 context.Context, arg1 *dbmodels.Identity, arg2, arg3 string) (*dbmodels.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockDBAPIMockRecorder) LinkIdentity(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockDBAPI)(nil).LinkIdentity), arg0, arg1, arg2, arg3)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockDBAPI) ListAPIKeys(arg0 context.Context, arg1, arg2 string) (dbmodels.APIKeySlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dbmodels.APIKeySlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockDBAPIMockRecorder) ListAPIKeys(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockDBAPI)(nil).ListAPIKeys), arg0, arg1, arg2)
+}
+
+// ListIdentityProviders mocks base method.
+func (m *MockDBAPI) ListIdentityProviders(arg0 context.Context, arg1 string) (dbmodels.IdentityProviderSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIdentityProviders", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.IdentityProviderSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIdentityProviders indicates an expected call of ListIdentityProviders.
+func (mr *MockDBAPIMockRecorder) ListIdentityProviders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIdentityProviders", reflect.TypeOf((*MockDBAPI)(nil).ListIdentityProviders), arg0, arg1)
+}
+
+// ListInstances mocks base method.
+func (m *MockDBAPI) ListInstances(arg0 context.Context, arg1 paging.Page) (dbmodels.InstanceSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstances", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.InstanceSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstances indicates an expected call of ListInstances.
+func (mr *MockDBAPIMockRecorder) ListInstances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstances", reflect.TypeOf((*MockDBAPI)(nil).ListInstances), arg0, arg1)
+}
+
+// ListInvitations mocks base method.
+func (m *MockDBAPI) ListInvitations(arg0 context.Context, arg1 string) (dbmodels.InvitationSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.InvitationSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockDBAPIMockRecorder) ListInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockDBAPI)(nil).ListInvitations), arg0, arg1)
+}
+
+// ListMembers mocks base method.
+func (m *MockDBAPI) ListMembers(arg0 context.Context, arg1 string, arg2 paging.Page) (dbmodels.ProfileSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dbmodels.ProfileSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockDBAPIMockRecorder) ListMembers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockDBAPI)(nil).ListMembers), arg0, arg1, arg2)
+}
+
+// ListPasskeys mocks base method.
+func (m *MockDBAPI) ListPasskeys(arg0 context.Context, arg1 string) (dbmodels.PasskeySlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPasskeys", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.PasskeySlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPasskeys indicates an expected call of ListPasskeys.
+func (mr *MockDBAPIMockRecorder) ListPasskeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPasskeys", reflect.TypeOf((*MockDBAPI)(nil).ListPasskeys), arg0, arg1)
+}
+
+// ListProfiles mocks base method.
+func (m *MockDBAPI) ListProfiles(arg0 context.Context, arg1 string) (dbmodels.ProfileSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProfiles", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.ProfileSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProfiles indicates an expected call of ListProfiles.
+func (mr *MockDBAPIMockRecorder) ListProfiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfiles", reflect.TypeOf((*MockDBAPI)(nil).ListProfiles), arg0, arg1)
+}
+
+// ListServiceAccounts mocks base method.
+func (m *MockDBAPI) ListServiceAccounts(arg0 context.Context, arg1 string) (dbmodels.OauthClientSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceAccounts", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.OauthClientSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceAccounts indicates an expected call of ListServiceAccounts.
+func (mr *MockDBAPIMockRecorder) ListServiceAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceAccounts", reflect.TypeOf((*MockDBAPI)(nil).ListServiceAccounts), arg0, arg1)
+}
+
+// ListSessions mocks base method.
+func (m *MockDBAPI) ListSessions(arg0 context.Context, arg1 string) (dbmodels.TokenSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.TokenSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockDBAPIMockRecorder) ListSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockDBAPI)(nil).ListSessions), arg0, arg1)
+}
+
+// LoadDenylist mocks base method.
+func (m *MockDBAPI) LoadDenylist(arg0 context.Context) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDenylist", arg0)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadDenylist indicates an expected call of LoadDenylist.
+func (mr *MockDBAPIMockRecorder) LoadDenylist(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDenylist", reflect.TypeOf((*MockDBAPI)(nil).LoadDenylist), arg0)
+}
+
+// LockLogin mocks base method.
+func (m *MockDBAPI) LockLogin(arg0 context.Context, arg1 string, arg2, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockDBAPIMockRecorder) LockLogin(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockDBAPI)(nil).LockLogin), arg0, arg1, arg2, arg3)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockDBAPI) PurgeDeleted(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockDBAPIMockRecorder) PurgeDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockDBAPI)(nil).PurgeDeleted), arg0, arg1)
+}
+
+// RecordLoginFailure mocks base method.
+func (m *MockDBAPI) RecordLoginFailure(arg0 context.Context, arg1 string, arg2, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginFailure", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockDBAPIMockRecorder) RecordLoginFailure(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockDBAPI)(nil).RecordLoginFailure), arg0, arg1, arg2, arg3)
+}
+
+// RehashPassword mocks base method.
+func (m *MockDBAPI) RehashPassword(arg0 context.Context, arg1 string, arg2, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RehashPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RehashPassword indicates an expected call of RehashPassword.
+func (mr *MockDBAPIMockRecorder) RehashPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehashPassword", reflect.TypeOf((*MockDBAPI)(nil).RehashPassword), arg0, arg1, arg2, arg3)
+}
+
+// ResetLoginAttempts mocks base method.
+func (m *MockDBAPI) ResetLoginAttempts(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetLoginAttempts indicates an expected call of ResetLoginAttempts.
+func (mr *MockDBAPIMockRecorder) ResetLoginAttempts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginAttempts", reflect.TypeOf((*MockDBAPI)(nil).ResetLoginAttempts), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockDBAPI) ResetPassword(arg0 context.Context, arg1, arg2 []byte) (*dbmodels.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockDBAPIMockRecorder) ResetPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockDBAPI)(nil).ResetPassword), arg0, arg1, arg2)
+}
+
+// Rollback mocks base method.
+func (m *MockDBAPI) Rollback(arg0 *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockDBAPIMockRecorder) Rollback(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockDBAPI)(nil).Rollback), arg0)
+}
+
+// RotateToken mocks base method.
+func (m *MockDBAPI) RotateToken(arg0 context.Context, arg1 *dbmodels.Token, arg2 []byte, arg3 time.Time, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateToken", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateToken indicates an expected call of RotateToken.
+func (mr *MockDBAPIMockRecorder) RotateToken(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateToken", reflect.TypeOf((*MockDBAPI)(nil).RotateToken), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SaveTOTPSecret mocks base method.
+func (m *MockDBAPI) SaveTOTPSecret(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTPSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTPSecret indicates an expected call of SaveTOTPSecret.
+func (mr *MockDBAPIMockRecorder) SaveTOTPSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPSecret", reflect.TypeOf((*MockDBAPI)(nil).SaveTOTPSecret), arg0, arg1, arg2)
+}
+
+// SaveToken mocks base method.
+func (m *MockDBAPI) SaveToken(arg0 context.Context, arg1 *dbmodels.Profile, arg2 []byte, arg3, arg4 time.Time, arg5, arg6, arg7, arg8 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveToken", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveToken indicates an expected call of SaveToken.
+func (mr *MockDBAPIMockRecorder) SaveToken(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveToken", reflect.TypeOf((*MockDBAPI)(nil).SaveToken), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// UpdateInstance mocks base method.
+func (m *MockDBAPI) UpdateInstance(arg0 context.Context, arg1 *dbmodels.Instance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstance", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstance indicates an expected call of UpdateInstance.
+func (mr *MockDBAPIMockRecorder) UpdateInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstance", reflect.TypeOf((*MockDBAPI)(nil).UpdateInstance), arg0, arg1)
+}
+
+// UpdatePasskeySignCount mocks base method.
+func (m *MockDBAPI) UpdatePasskeySignCount(arg0 context.Context, arg1 []byte, arg2 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasskeySignCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasskeySignCount indicates an expected call of UpdatePasskeySignCount.
+func (mr *MockDBAPIMockRecorder) UpdatePasskeySignCount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasskeySignCount", reflect.TypeOf((*MockDBAPI)(nil).UpdatePasskeySignCount), arg0, arg1, arg2)
+}
+
+// UpdateProfileRole mocks base method.
+func (m *MockDBAPI) UpdateProfileRole(arg0 context.Context, arg1 string, arg2 roles.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfileRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfileRole indicates an expected call of UpdateProfileRole.
+func (mr *MockDBAPIMockRecorder) UpdateProfileRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfileRole", reflect.TypeOf((*MockDBAPI)(nil).UpdateProfileRole), arg0, arg1, arg2)
+}
+
+// UpdateServiceAccountSecret mocks base method.
+func (m *MockDBAPI) UpdateServiceAccountSecret(arg0 context.Context, arg1, arg2 string, arg3 []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceAccountSecret", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateServiceAccountSecret indicates an expected call of UpdateServiceAccountSecret.
+func (mr *MockDBAPIMockRecorder) UpdateServiceAccountSecret(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccountSecret", reflect.TypeOf((*MockDBAPI)(nil).UpdateServiceAccountSecret), arg0, arg1, arg2, arg3)
+}
+
+// UpdateUserName mocks base method.
+func (m *MockDBAPI) UpdateUserName(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserName indicates an expected call of UpdateUserName.
+func (mr *MockDBAPIMockRecorder) UpdateUserName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserName", reflect.TypeOf((*MockDBAPI)(nil).UpdateUserName), arg0, arg1, arg2)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockDBAPI) UseRecoveryCode(arg0 context.Context, arg1 string, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockDBAPIMockRecorder) UseRecoveryCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockDBAPI)(nil).UseRecoveryCode), arg0, arg1, arg2)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockDBAPI) UseTOTPStep(arg0 context.Context, arg1 string, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockDBAPIMockRecorder) UseTOTPStep(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockDBAPI)(nil).UseTOTPStep), arg0, arg1, arg2)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockDBAPI) VerifyEmail(arg0 context.Context, arg1 []byte) (*dbmodels.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(*dbmodels.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockDBAPIMockRecorder) VerifyEmail(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveToken", reflect.TypeOf((*MockDBAPI)(nil).SaveToken), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockDBAPI)(nil).VerifyEmail), arg0, arg1)
 }
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/lib/pq"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/lib/paging"
+)
+
+// fakeDB answers the queries of the database API in place of Postgres, for the constraints that mocks can not check.
+type fakeDB struct {
+	mu    sync.Mutex
+	query func(query string, args []driver.Value) (driver.Rows, error)
+}
+
+func openFakeDB(query func(query string, args []driver.Value) (driver.Rows, error)) *sql.DB {
+	return sql.OpenDB(&fakeDB{query: query})
+}
+
+func (d *fakeDB) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *fakeDB) Driver() driver.Driver                        { return d }
+func (d *fakeDB) Open(string) (driver.Conn, error)             { return d, nil }
+func (d *fakeDB) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+func (d *fakeDB) Close() error              { return nil }
+func (d *fakeDB) Begin() (driver.Tx, error) { return d, nil }
+func (d *fakeDB) Commit() error             { return nil }
+func (d *fakeDB) Rollback() error           { return nil }
+
+func (d *fakeDB) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return d.query(query, values)
+}
+
+// fakeRows is the result of a query to a fakeDB.
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func (s *MySuite) Test_saveTokenDuplicate(assert, require *td.T) {
+	// given a tokens table with unique digests
+	digests := map[string]bool{}
+	conn := openFakeDB(func(query string, args []driver.Value) (driver.Rows, error) {
+		if !strings.HasPrefix(query, `INSERT INTO "auth"."tokens"`) {
+			return nil, errors.Errorf("unexpected query %s", query)
+		}
+		for _, arg := range args {
+			if digest, ok := arg.([]byte); ok {
+				if digests[string(digest)] {
+					return nil, &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "tokens_digest_key"`}
+				}
+				digests[string(digest)] = true
+			}
+		}
+		return &fakeRows{columns: []string{"id"}, values: [][]driver.Value{{int64(len(digests))}}}, nil
+	})
+	defer conn.Close()
+	db := dbAPI{DB: conn}
+	profile := &m.Profile{ID: xid.New().String(), UserID: xid.New().String(), InstanceID: xid.New().String()}
+	digest := []byte(xid.New().String())
+	save := func() error {
+		return db.SaveToken(context.Background(), profile, digest, time.Now().Add(time.Hour), time.Now(), xid.New().String(), "", "", "")
+	}
+
+	// when/then a digest is stored once
+	require.CmpNoError(save())
+
+	// and storing it again fails with an error callers can tell apart
+	err := save()
+	assert.True(errors.Is(err, ErrTokenDuplicate))
+	assert.True(isUniqueViolation(errors.Wrap(&pq.Error{Code: "23505"}, "dbmodels: unable to insert into tokens")))
+	assert.False(isUniqueViolation(errors.New("connection refused")))
+}
+
+func (s *MySuite) Test_listInstancesPreviousPage(assert, require *td.T) {
+	// given instances created one after another
+	ids := []string{xid.New().String(), xid.New().String(), xid.New().String()}
+	var loaded string
+	conn := openFakeDB(func(query string, args []driver.Value) (driver.Rows, error) {
+		loaded = query
+		// the two instances before the last one, last first
+		return &fakeRows{columns: []string{"id"}, values: [][]driver.Value{{ids[1]}, {ids[0]}}}, nil
+	})
+	defer conn.Close()
+	db := dbAPI{DB: conn}
+
+	// when the page before the last instance is loaded
+	instances, err := db.ListInstances(context.Background(), &paging.Paging_Previous{End: ids[2], PageSize: 2})
+
+	// then the instances right before it are loaded in reverse order and returned in order of creation
+	require.CmpNoError(err)
+	assert.Contains(loaded, `"auth"."instances"."id" < $1`)
+	assert.Contains(loaded, `ORDER BY "auth"."instances"."id" DESC LIMIT 2`)
+	require.Len(instances, 2)
+	assert.Cmp(instances[0].ID, ids[0])
+	assert.Cmp(instances[1].ID, ids[1])
+}
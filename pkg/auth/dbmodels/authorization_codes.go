@@ -0,0 +1,1314 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// AuthorizationCode is an object representing the database table.
+type AuthorizationCode struct {
+	Digest        []byte      `boil:"digest" json:"digest" toml:"digest" yaml:"digest"`
+	ClientID      string      `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	UserID        string      `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	RedirectURI   string      `boil:"redirect_uri" json:"redirect_uri" toml:"redirect_uri" yaml:"redirect_uri"`
+	Scope         string      `boil:"scope" json:"scope" toml:"scope" yaml:"scope"`
+	Nonce         null.String `boil:"nonce" json:"nonce,omitempty" toml:"nonce" yaml:"nonce,omitempty"`
+	CodeChallenge string      `boil:"code_challenge" json:"code_challenge" toml:"code_challenge" yaml:"code_challenge"`
+	AuthTime      time.Time   `boil:"auth_time" json:"auth_time" toml:"auth_time" yaml:"auth_time"`
+	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ExpiresAt     time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *authorizationCodeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L authorizationCodeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AuthorizationCodeColumns = struct {
+	Digest        string
+	ClientID      string
+	UserID        string
+	RedirectURI   string
+	Scope         string
+	Nonce         string
+	CodeChallenge string
+	AuthTime      string
+	CreatedAt     string
+	ExpiresAt     string
+}{
+	Digest:        "digest",
+	ClientID:      "client_id",
+	UserID:        "user_id",
+	RedirectURI:   "redirect_uri",
+	Scope:         "scope",
+	Nonce:         "nonce",
+	CodeChallenge: "code_challenge",
+	AuthTime:      "auth_time",
+	CreatedAt:     "created_at",
+	ExpiresAt:     "expires_at",
+}
+
+var AuthorizationCodeTableColumns = struct {
+	Digest        string
+	ClientID      string
+	UserID        string
+	RedirectURI   string
+	Scope         string
+	Nonce         string
+	CodeChallenge string
+	AuthTime      string
+	CreatedAt     string
+	ExpiresAt     string
+}{
+	Digest:        "authorization_codes.digest",
+	ClientID:      "authorization_codes.client_id",
+	UserID:        "authorization_codes.user_id",
+	RedirectURI:   "authorization_codes.redirect_uri",
+	Scope:         "authorization_codes.scope",
+	Nonce:         "authorization_codes.nonce",
+	CodeChallenge: "authorization_codes.code_challenge",
+	AuthTime:      "authorization_codes.auth_time",
+	CreatedAt:     "authorization_codes.created_at",
+	ExpiresAt:     "authorization_codes.expires_at",
+}
+
+// Generated where
+
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var AuthorizationCodeWhere = struct {
+	Digest        whereHelper__byte
+	ClientID      whereHelperstring
+	UserID        whereHelperstring
+	RedirectURI   whereHelperstring
+	Scope         whereHelperstring
+	Nonce         whereHelpernull_String
+	CodeChallenge whereHelperstring
+	AuthTime      whereHelpertime_Time
+	CreatedAt     whereHelpertime_Time
+	ExpiresAt     whereHelpertime_Time
+}{
+	Digest:        whereHelper__byte{field: "\"auth\".\"authorization_codes\".\"digest\""},
+	ClientID:      whereHelperstring{field: "\"auth\".\"authorization_codes\".\"client_id\""},
+	UserID:        whereHelperstring{field: "\"auth\".\"authorization_codes\".\"user_id\""},
+	RedirectURI:   whereHelperstring{field: "\"auth\".\"authorization_codes\".\"redirect_uri\""},
+	Scope:         whereHelperstring{field: "\"auth\".\"authorization_codes\".\"scope\""},
+	Nonce:         whereHelpernull_String{field: "\"auth\".\"authorization_codes\".\"nonce\""},
+	CodeChallenge: whereHelperstring{field: "\"auth\".\"authorization_codes\".\"code_challenge\""},
+	AuthTime:      whereHelpertime_Time{field: "\"auth\".\"authorization_codes\".\"auth_time\""},
+	CreatedAt:     whereHelpertime_Time{field: "\"auth\".\"authorization_codes\".\"created_at\""},
+	ExpiresAt:     whereHelpertime_Time{field: "\"auth\".\"authorization_codes\".\"expires_at\""},
+}
+
+// AuthorizationCodeRels is where relationship names are stored.
+var AuthorizationCodeRels = struct {
+	Client string
+	User   string
+}{
+	Client: "Client",
+	User:   "User",
+}
+
+// authorizationCodeR is where relationships are stored.
+type authorizationCodeR struct {
+	Client *OauthClient `boil:"Client" json:"Client" toml:"Client" yaml:"Client"`
+	User   *User        `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*authorizationCodeR) NewStruct() *authorizationCodeR {
+	return &authorizationCodeR{}
+}
+
+// authorizationCodeL is where Load methods for each relationship are stored.
+type authorizationCodeL struct{}
+
+var (
+	authorizationCodeAllColumns            = []string{"digest", "client_id", "user_id", "redirect_uri", "scope", "nonce", "code_challenge", "auth_time", "created_at", "expires_at"}
+	authorizationCodeColumnsWithoutDefault = []string{"digest", "client_id", "user_id", "redirect_uri", "scope", "nonce", "code_challenge", "auth_time", "expires_at"}
+	authorizationCodeColumnsWithDefault    = []string{"created_at"}
+	authorizationCodePrimaryKeyColumns     = []string{"digest"}
+)
+
+type (
+	// AuthorizationCodeSlice is an alias for a slice of pointers to AuthorizationCode.
+	// This should almost always be used instead of []AuthorizationCode.
+	AuthorizationCodeSlice []*AuthorizationCode
+	// AuthorizationCodeHook is the signature for custom AuthorizationCode hook methods
+	AuthorizationCodeHook func(context.Context, boil.ContextExecutor, *AuthorizationCode) error
+
+	authorizationCodeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	authorizationCodeType                 = reflect.TypeOf(&AuthorizationCode{})
+	authorizationCodeMapping              = queries.MakeStructMapping(authorizationCodeType)
+	authorizationCodePrimaryKeyMapping, _ = queries.BindMapping(authorizationCodeType, authorizationCodeMapping, authorizationCodePrimaryKeyColumns)
+	authorizationCodeInsertCacheMut       sync.RWMutex
+	authorizationCodeInsertCache          = make(map[string]insertCache)
+	authorizationCodeUpdateCacheMut       sync.RWMutex
+	authorizationCodeUpdateCache          = make(map[string]updateCache)
+	authorizationCodeUpsertCacheMut       sync.RWMutex
+	authorizationCodeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var authorizationCodeBeforeInsertHooks []AuthorizationCodeHook
+var authorizationCodeBeforeUpdateHooks []AuthorizationCodeHook
+var authorizationCodeBeforeDeleteHooks []AuthorizationCodeHook
+var authorizationCodeBeforeUpsertHooks []AuthorizationCodeHook
+
+var authorizationCodeAfterInsertHooks []AuthorizationCodeHook
+var authorizationCodeAfterSelectHooks []AuthorizationCodeHook
+var authorizationCodeAfterUpdateHooks []AuthorizationCodeHook
+var authorizationCodeAfterDeleteHooks []AuthorizationCodeHook
+var authorizationCodeAfterUpsertHooks []AuthorizationCodeHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AuthorizationCode) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AuthorizationCode) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AuthorizationCode) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AuthorizationCode) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AuthorizationCode) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AuthorizationCode) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AuthorizationCode) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AuthorizationCode) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AuthorizationCode) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range authorizationCodeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAuthorizationCodeHook registers your hook function for all future operations.
+func AddAuthorizationCodeHook(hookPoint boil.HookPoint, authorizationCodeHook AuthorizationCodeHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		authorizationCodeBeforeInsertHooks = append(authorizationCodeBeforeInsertHooks, authorizationCodeHook)
+	case boil.BeforeUpdateHook:
+		authorizationCodeBeforeUpdateHooks = append(authorizationCodeBeforeUpdateHooks, authorizationCodeHook)
+	case boil.BeforeDeleteHook:
+		authorizationCodeBeforeDeleteHooks = append(authorizationCodeBeforeDeleteHooks, authorizationCodeHook)
+	case boil.BeforeUpsertHook:
+		authorizationCodeBeforeUpsertHooks = append(authorizationCodeBeforeUpsertHooks, authorizationCodeHook)
+	case boil.AfterInsertHook:
+		authorizationCodeAfterInsertHooks = append(authorizationCodeAfterInsertHooks, authorizationCodeHook)
+	case boil.AfterSelectHook:
+		authorizationCodeAfterSelectHooks = append(authorizationCodeAfterSelectHooks, authorizationCodeHook)
+	case boil.AfterUpdateHook:
+		authorizationCodeAfterUpdateHooks = append(authorizationCodeAfterUpdateHooks, authorizationCodeHook)
+	case boil.AfterDeleteHook:
+		authorizationCodeAfterDeleteHooks = append(authorizationCodeAfterDeleteHooks, authorizationCodeHook)
+	case boil.AfterUpsertHook:
+		authorizationCodeAfterUpsertHooks = append(authorizationCodeAfterUpsertHooks, authorizationCodeHook)
+	}
+}
+
+// One returns a single authorizationCode record from the query.
+func (q authorizationCodeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AuthorizationCode, error) {
+	o := &AuthorizationCode{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for authorization_codes")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AuthorizationCode records from the query.
+func (q authorizationCodeQuery) All(ctx context.Context, exec boil.ContextExecutor) (AuthorizationCodeSlice, error) {
+	var o []*AuthorizationCode
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to AuthorizationCode slice")
+	}
+
+	if len(authorizationCodeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AuthorizationCode records in the query.
+func (q authorizationCodeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count authorization_codes rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q authorizationCodeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if authorization_codes exists")
+	}
+
+	return count > 0, nil
+}
+
+// Client pointed to by the foreign key.
+func (o *AuthorizationCode) Client(mods ...qm.QueryMod) oauthClientQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ClientID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := OauthClients(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"oauth_clients\"")
+
+	return query
+}
+
+// User pointed to by the foreign key.
+func (o *AuthorizationCode) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"users\"")
+
+	return query
+}
+
+// LoadClient allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (authorizationCodeL) LoadClient(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAuthorizationCode interface{}, mods queries.Applicator) error {
+	var slice []*AuthorizationCode
+	var object *AuthorizationCode
+
+	if singular {
+		object = maybeAuthorizationCode.(*AuthorizationCode)
+	} else {
+		slice = *maybeAuthorizationCode.(*[]*AuthorizationCode)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &authorizationCodeR{}
+		}
+		args = append(args, object.ClientID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &authorizationCodeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ClientID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ClientID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.oauth_clients`),
+		qm.WhereIn(`auth.oauth_clients.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load OauthClient")
+	}
+
+	var resultSlice []*OauthClient
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice OauthClient")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for oauth_clients")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oauth_clients")
+	}
+
+	if len(authorizationCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Client = foreign
+		if foreign.R == nil {
+			foreign.R = &oauthClientR{}
+		}
+		foreign.R.ClientAuthorizationCodes = append(foreign.R.ClientAuthorizationCodes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ClientID == foreign.ID {
+				local.R.Client = foreign
+				if foreign.R == nil {
+					foreign.R = &oauthClientR{}
+				}
+				foreign.R.ClientAuthorizationCodes = append(foreign.R.ClientAuthorizationCodes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (authorizationCodeL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAuthorizationCode interface{}, mods queries.Applicator) error {
+	var slice []*AuthorizationCode
+	var object *AuthorizationCode
+
+	if singular {
+		object = maybeAuthorizationCode.(*AuthorizationCode)
+	} else {
+		slice = *maybeAuthorizationCode.(*[]*AuthorizationCode)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &authorizationCodeR{}
+		}
+		args = append(args, object.UserID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &authorizationCodeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.UserID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.UserID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.users`),
+		qm.WhereIn(`auth.users.id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.users.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(authorizationCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.AuthorizationCodes = append(foreign.R.AuthorizationCodes, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserID == foreign.ID {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.AuthorizationCodes = append(foreign.R.AuthorizationCodes, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetClient of the authorizationCode to the related item.
+// Sets o.R.Client to related.
+// Adds o to related.R.ClientAuthorizationCodes.
+func (o *AuthorizationCode) SetClient(ctx context.Context, exec boil.ContextExecutor, insert bool, related *OauthClient) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"authorization_codes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"client_id"}),
+		strmangle.WhereClause("\"", "\"", 2, authorizationCodePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.Digest}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ClientID = related.ID
+	if o.R == nil {
+		o.R = &authorizationCodeR{
+			Client: related,
+		}
+	} else {
+		o.R.Client = related
+	}
+
+	if related.R == nil {
+		related.R = &oauthClientR{
+			ClientAuthorizationCodes: AuthorizationCodeSlice{o},
+		}
+	} else {
+		related.R.ClientAuthorizationCodes = append(related.R.ClientAuthorizationCodes, o)
+	}
+
+	return nil
+}
+
+// SetUser of the authorizationCode to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.AuthorizationCodes.
+func (o *AuthorizationCode) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"authorization_codes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, authorizationCodePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.Digest}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserID = related.ID
+	if o.R == nil {
+		o.R = &authorizationCodeR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			AuthorizationCodes: AuthorizationCodeSlice{o},
+		}
+	} else {
+		related.R.AuthorizationCodes = append(related.R.AuthorizationCodes, o)
+	}
+
+	return nil
+}
+
+// AuthorizationCodes retrieves all the records using an executor.
+func AuthorizationCodes(mods ...qm.QueryMod) authorizationCodeQuery {
+	mods = append(mods, qm.From("\"auth\".\"authorization_codes\""))
+	return authorizationCodeQuery{NewQuery(mods...)}
+}
+
+// FindAuthorizationCode retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAuthorizationCode(ctx context.Context, exec boil.ContextExecutor, digest []byte, selectCols ...string) (*AuthorizationCode, error) {
+	authorizationCodeObj := &AuthorizationCode{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"authorization_codes\" where \"digest\"=$1", sel,
+	)
+
+	q := queries.Raw(query, digest)
+
+	err := q.Bind(ctx, exec, authorizationCodeObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from authorization_codes")
+	}
+
+	if err = authorizationCodeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return authorizationCodeObj, err
+	}
+
+	return authorizationCodeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AuthorizationCode) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no authorization_codes provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(authorizationCodeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	authorizationCodeInsertCacheMut.RLock()
+	cache, cached := authorizationCodeInsertCache[key]
+	authorizationCodeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			authorizationCodeAllColumns,
+			authorizationCodeColumnsWithDefault,
+			authorizationCodeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(authorizationCodeType, authorizationCodeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(authorizationCodeType, authorizationCodeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"authorization_codes\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"authorization_codes\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into authorization_codes")
+	}
+
+	if !cached {
+		authorizationCodeInsertCacheMut.Lock()
+		authorizationCodeInsertCache[key] = cache
+		authorizationCodeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the AuthorizationCode.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AuthorizationCode) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	authorizationCodeUpdateCacheMut.RLock()
+	cache, cached := authorizationCodeUpdateCache[key]
+	authorizationCodeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			authorizationCodeAllColumns,
+			authorizationCodePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update authorization_codes, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"authorization_codes\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, authorizationCodePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(authorizationCodeType, authorizationCodeMapping, append(wl, authorizationCodePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update authorization_codes row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for authorization_codes")
+	}
+
+	if !cached {
+		authorizationCodeUpdateCacheMut.Lock()
+		authorizationCodeUpdateCache[key] = cache
+		authorizationCodeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q authorizationCodeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for authorization_codes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for authorization_codes")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AuthorizationCodeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authorizationCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"authorization_codes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, authorizationCodePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in authorizationCode slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all authorizationCode")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AuthorizationCode) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no authorization_codes provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(authorizationCodeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	authorizationCodeUpsertCacheMut.RLock()
+	cache, cached := authorizationCodeUpsertCache[key]
+	authorizationCodeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			authorizationCodeAllColumns,
+			authorizationCodeColumnsWithDefault,
+			authorizationCodeColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			authorizationCodeAllColumns,
+			authorizationCodePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert authorization_codes, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(authorizationCodePrimaryKeyColumns))
+			copy(conflict, authorizationCodePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"authorization_codes\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(authorizationCodeType, authorizationCodeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(authorizationCodeType, authorizationCodeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert authorization_codes")
+	}
+
+	if !cached {
+		authorizationCodeUpsertCacheMut.Lock()
+		authorizationCodeUpsertCache[key] = cache
+		authorizationCodeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single AuthorizationCode record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AuthorizationCode) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no AuthorizationCode provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), authorizationCodePrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"authorization_codes\" WHERE \"digest\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from authorization_codes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for authorization_codes")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q authorizationCodeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no authorizationCodeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from authorization_codes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for authorization_codes")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AuthorizationCodeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(authorizationCodeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authorizationCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"authorization_codes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, authorizationCodePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from authorizationCode slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for authorization_codes")
+	}
+
+	if len(authorizationCodeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AuthorizationCode) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAuthorizationCode(ctx, exec, o.Digest)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AuthorizationCodeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AuthorizationCodeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), authorizationCodePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"authorization_codes\".* FROM \"auth\".\"authorization_codes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, authorizationCodePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in AuthorizationCodeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AuthorizationCodeExists checks if the AuthorizationCode row exists.
+func AuthorizationCodeExists(ctx context.Context, exec boil.ContextExecutor, digest []byte) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"authorization_codes\" where \"digest\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, digest)
+	}
+	row := exec.QueryRowContext(ctx, sql, digest)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if authorization_codes exists")
+	}
+
+	return exists, nil
+}
@@ -4,13 +4,43 @@
 package dbmodels
 
 var TableNames = struct {
-	Instances string
-	Profiles  string
-	Tokens    string
-	Users     string
+	APIKeys            string
+	AuthorizationCodes string
+	DeniedTokens       string
+	Identities         string
+	IdentityProviders  string
+	Instances          string
+	Invitations        string
+	LoginAttempts      string
+	OauthClients       string
+	OidcStates         string
+	PasskeyChallenges  string
+	Passkeys           string
+	PasswordResets     string
+	Profiles           string
+	RecoveryCodes      string
+	Tokens             string
+	TotpSecrets        string
+	Users              string
+	Verifications      string
 }{
-	Instances: "instances",
-	Profiles:  "profiles",
-	Tokens:    "tokens",
-	Users:     "users",
+	APIKeys:            "api_keys",
+	AuthorizationCodes: "authorization_codes",
+	DeniedTokens:       "denied_tokens",
+	Identities:         "identities",
+	IdentityProviders:  "identity_providers",
+	Instances:          "instances",
+	Invitations:        "invitations",
+	LoginAttempts:      "login_attempts",
+	OauthClients:       "oauth_clients",
+	OidcStates:         "oidc_states",
+	PasskeyChallenges:  "passkey_challenges",
+	Passkeys:           "passkeys",
+	PasswordResets:     "password_resets",
+	Profiles:           "profiles",
+	RecoveryCodes:      "recovery_codes",
+	Tokens:             "tokens",
+	TotpSecrets:        "totp_secrets",
+	Users:              "users",
+	Verifications:      "verifications",
 }
@@ -0,0 +1,880 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// DeniedToken is an object representing the database table.
+type DeniedToken struct {
+	Jti       string    `boil:"jti" json:"jti" toml:"jti" yaml:"jti"`
+	ExpiresAt time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *deniedTokenR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L deniedTokenL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var DeniedTokenColumns = struct {
+	Jti       string
+	ExpiresAt string
+}{
+	Jti:       "jti",
+	ExpiresAt: "expires_at",
+}
+
+var DeniedTokenTableColumns = struct {
+	Jti       string
+	ExpiresAt string
+}{
+	Jti:       "denied_tokens.jti",
+	ExpiresAt: "denied_tokens.expires_at",
+}
+
+// Generated where
+
+var DeniedTokenWhere = struct {
+	Jti       whereHelperstring
+	ExpiresAt whereHelpertime_Time
+}{
+	Jti:       whereHelperstring{field: "\"auth\".\"denied_tokens\".\"jti\""},
+	ExpiresAt: whereHelpertime_Time{field: "\"auth\".\"denied_tokens\".\"expires_at\""},
+}
+
+// DeniedTokenRels is where relationship names are stored.
+var DeniedTokenRels = struct {
+}{}
+
+// deniedTokenR is where relationships are stored.
+type deniedTokenR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*deniedTokenR) NewStruct() *deniedTokenR {
+	return &deniedTokenR{}
+}
+
+// deniedTokenL is where Load methods for each relationship are stored.
+type deniedTokenL struct{}
+
+var (
+	deniedTokenAllColumns            = []string{"jti", "expires_at"}
+	deniedTokenColumnsWithoutDefault = []string{"jti", "expires_at"}
+	deniedTokenColumnsWithDefault    = []string{}
+	deniedTokenPrimaryKeyColumns     = []string{"jti"}
+)
+
+type (
+	// DeniedTokenSlice is an alias for a slice of pointers to DeniedToken.
+	// This should almost always be used instead of []DeniedToken.
+	DeniedTokenSlice []*DeniedToken
+	// DeniedTokenHook is the signature for custom DeniedToken hook methods
+	DeniedTokenHook func(context.Context, boil.ContextExecutor, *DeniedToken) error
+
+	deniedTokenQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	deniedTokenType                 = reflect.TypeOf(&DeniedToken{})
+	deniedTokenMapping              = queries.MakeStructMapping(deniedTokenType)
+	deniedTokenPrimaryKeyMapping, _ = queries.BindMapping(deniedTokenType, deniedTokenMapping, deniedTokenPrimaryKeyColumns)
+	deniedTokenInsertCacheMut       sync.RWMutex
+	deniedTokenInsertCache          = make(map[string]insertCache)
+	deniedTokenUpdateCacheMut       sync.RWMutex
+	deniedTokenUpdateCache          = make(map[string]updateCache)
+	deniedTokenUpsertCacheMut       sync.RWMutex
+	deniedTokenUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var deniedTokenBeforeInsertHooks []DeniedTokenHook
+var deniedTokenBeforeUpdateHooks []DeniedTokenHook
+var deniedTokenBeforeDeleteHooks []DeniedTokenHook
+var deniedTokenBeforeUpsertHooks []DeniedTokenHook
+
+var deniedTokenAfterInsertHooks []DeniedTokenHook
+var deniedTokenAfterSelectHooks []DeniedTokenHook
+var deniedTokenAfterUpdateHooks []DeniedTokenHook
+var deniedTokenAfterDeleteHooks []DeniedTokenHook
+var deniedTokenAfterUpsertHooks []DeniedTokenHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *DeniedToken) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *DeniedToken) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *DeniedToken) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *DeniedToken) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *DeniedToken) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *DeniedToken) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *DeniedToken) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *DeniedToken) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *DeniedToken) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range deniedTokenAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddDeniedTokenHook registers your hook function for all future operations.
+func AddDeniedTokenHook(hookPoint boil.HookPoint, deniedTokenHook DeniedTokenHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		deniedTokenBeforeInsertHooks = append(deniedTokenBeforeInsertHooks, deniedTokenHook)
+	case boil.BeforeUpdateHook:
+		deniedTokenBeforeUpdateHooks = append(deniedTokenBeforeUpdateHooks, deniedTokenHook)
+	case boil.BeforeDeleteHook:
+		deniedTokenBeforeDeleteHooks = append(deniedTokenBeforeDeleteHooks, deniedTokenHook)
+	case boil.BeforeUpsertHook:
+		deniedTokenBeforeUpsertHooks = append(deniedTokenBeforeUpsertHooks, deniedTokenHook)
+	case boil.AfterInsertHook:
+		deniedTokenAfterInsertHooks = append(deniedTokenAfterInsertHooks, deniedTokenHook)
+	case boil.AfterSelectHook:
+		deniedTokenAfterSelectHooks = append(deniedTokenAfterSelectHooks, deniedTokenHook)
+	case boil.AfterUpdateHook:
+		deniedTokenAfterUpdateHooks = append(deniedTokenAfterUpdateHooks, deniedTokenHook)
+	case boil.AfterDeleteHook:
+		deniedTokenAfterDeleteHooks = append(deniedTokenAfterDeleteHooks, deniedTokenHook)
+	case boil.AfterUpsertHook:
+		deniedTokenAfterUpsertHooks = append(deniedTokenAfterUpsertHooks, deniedTokenHook)
+	}
+}
+
+// One returns a single deniedToken record from the query.
+func (q deniedTokenQuery) One(ctx context.Context, exec boil.ContextExecutor) (*DeniedToken, error) {
+	o := &DeniedToken{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for denied_tokens")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all DeniedToken records from the query.
+func (q deniedTokenQuery) All(ctx context.Context, exec boil.ContextExecutor) (DeniedTokenSlice, error) {
+	var o []*DeniedToken
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to DeniedToken slice")
+	}
+
+	if len(deniedTokenAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all DeniedToken records in the query.
+func (q deniedTokenQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count denied_tokens rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q deniedTokenQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if denied_tokens exists")
+	}
+
+	return count > 0, nil
+}
+
+// DeniedTokens retrieves all the records using an executor.
+func DeniedTokens(mods ...qm.QueryMod) deniedTokenQuery {
+	mods = append(mods, qm.From("\"auth\".\"denied_tokens\""))
+	return deniedTokenQuery{NewQuery(mods...)}
+}
+
+// FindDeniedToken retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindDeniedToken(ctx context.Context, exec boil.ContextExecutor, jti string, selectCols ...string) (*DeniedToken, error) {
+	deniedTokenObj := &DeniedToken{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"denied_tokens\" where \"jti\"=$1", sel,
+	)
+
+	q := queries.Raw(query, jti)
+
+	err := q.Bind(ctx, exec, deniedTokenObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from denied_tokens")
+	}
+
+	if err = deniedTokenObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return deniedTokenObj, err
+	}
+
+	return deniedTokenObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *DeniedToken) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no denied_tokens provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(deniedTokenColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	deniedTokenInsertCacheMut.RLock()
+	cache, cached := deniedTokenInsertCache[key]
+	deniedTokenInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			deniedTokenAllColumns,
+			deniedTokenColumnsWithDefault,
+			deniedTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(deniedTokenType, deniedTokenMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(deniedTokenType, deniedTokenMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"denied_tokens\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"denied_tokens\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into denied_tokens")
+	}
+
+	if !cached {
+		deniedTokenInsertCacheMut.Lock()
+		deniedTokenInsertCache[key] = cache
+		deniedTokenInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the DeniedToken.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *DeniedToken) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	deniedTokenUpdateCacheMut.RLock()
+	cache, cached := deniedTokenUpdateCache[key]
+	deniedTokenUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			deniedTokenAllColumns,
+			deniedTokenPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update denied_tokens, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"denied_tokens\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, deniedTokenPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(deniedTokenType, deniedTokenMapping, append(wl, deniedTokenPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update denied_tokens row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for denied_tokens")
+	}
+
+	if !cached {
+		deniedTokenUpdateCacheMut.Lock()
+		deniedTokenUpdateCache[key] = cache
+		deniedTokenUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q deniedTokenQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for denied_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for denied_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o DeniedTokenSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), deniedTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"denied_tokens\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, deniedTokenPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in deniedToken slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all deniedToken")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *DeniedToken) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no denied_tokens provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(deniedTokenColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	deniedTokenUpsertCacheMut.RLock()
+	cache, cached := deniedTokenUpsertCache[key]
+	deniedTokenUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			deniedTokenAllColumns,
+			deniedTokenColumnsWithDefault,
+			deniedTokenColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			deniedTokenAllColumns,
+			deniedTokenPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert denied_tokens, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(deniedTokenPrimaryKeyColumns))
+			copy(conflict, deniedTokenPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"denied_tokens\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(deniedTokenType, deniedTokenMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(deniedTokenType, deniedTokenMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert denied_tokens")
+	}
+
+	if !cached {
+		deniedTokenUpsertCacheMut.Lock()
+		deniedTokenUpsertCache[key] = cache
+		deniedTokenUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single DeniedToken record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *DeniedToken) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no DeniedToken provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), deniedTokenPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"denied_tokens\" WHERE \"jti\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from denied_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for denied_tokens")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q deniedTokenQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no deniedTokenQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from denied_tokens")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for denied_tokens")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o DeniedTokenSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(deniedTokenBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), deniedTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"denied_tokens\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, deniedTokenPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from deniedToken slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for denied_tokens")
+	}
+
+	if len(deniedTokenAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *DeniedToken) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindDeniedToken(ctx, exec, o.Jti)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *DeniedTokenSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := DeniedTokenSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), deniedTokenPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"denied_tokens\".* FROM \"auth\".\"denied_tokens\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, deniedTokenPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in DeniedTokenSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// DeniedTokenExists checks if the DeniedToken row exists.
+func DeniedTokenExists(ctx context.Context, exec boil.ContextExecutor, jti string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"denied_tokens\" where \"jti\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, jti)
+	}
+	row := exec.QueryRowContext(ctx, sql, jti)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if denied_tokens exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1464 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// IdentityProvider is an object representing the database table.
+type IdentityProvider struct {
+	ID           string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InstanceID   string    `boil:"instance_id" json:"instance_id" toml:"instance_id" yaml:"instance_id"`
+	Slug         string    `boil:"slug" json:"slug" toml:"slug" yaml:"slug"`
+	Name         string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Issuer       string    `boil:"issuer" json:"issuer" toml:"issuer" yaml:"issuer"`
+	ClientID     string    `boil:"client_id" json:"client_id" toml:"client_id" yaml:"client_id"`
+	ClientSecret string    `boil:"client_secret" json:"client_secret" toml:"client_secret" yaml:"client_secret"`
+	Scopes       string    `boil:"scopes" json:"scopes" toml:"scopes" yaml:"scopes"`
+	CreatedAt    time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *identityProviderR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L identityProviderL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var IdentityProviderColumns = struct {
+	ID           string
+	InstanceID   string
+	Slug         string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       string
+	CreatedAt    string
+}{
+	ID:           "id",
+	InstanceID:   "instance_id",
+	Slug:         "slug",
+	Name:         "name",
+	Issuer:       "issuer",
+	ClientID:     "client_id",
+	ClientSecret: "client_secret",
+	Scopes:       "scopes",
+	CreatedAt:    "created_at",
+}
+
+var IdentityProviderTableColumns = struct {
+	ID           string
+	InstanceID   string
+	Slug         string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       string
+	CreatedAt    string
+}{
+	ID:           "identity_providers.id",
+	InstanceID:   "identity_providers.instance_id",
+	Slug:         "identity_providers.slug",
+	Name:         "identity_providers.name",
+	Issuer:       "identity_providers.issuer",
+	ClientID:     "identity_providers.client_id",
+	ClientSecret: "identity_providers.client_secret",
+	Scopes:       "identity_providers.scopes",
+	CreatedAt:    "identity_providers.created_at",
+}
+
+// Generated where
+
+var IdentityProviderWhere = struct {
+	ID           whereHelperstring
+	InstanceID   whereHelperstring
+	Slug         whereHelperstring
+	Name         whereHelperstring
+	Issuer       whereHelperstring
+	ClientID     whereHelperstring
+	ClientSecret whereHelperstring
+	Scopes       whereHelperstring
+	CreatedAt    whereHelpertime_Time
+}{
+	ID:           whereHelperstring{field: "\"auth\".\"identity_providers\".\"id\""},
+	InstanceID:   whereHelperstring{field: "\"auth\".\"identity_providers\".\"instance_id\""},
+	Slug:         whereHelperstring{field: "\"auth\".\"identity_providers\".\"slug\""},
+	Name:         whereHelperstring{field: "\"auth\".\"identity_providers\".\"name\""},
+	Issuer:       whereHelperstring{field: "\"auth\".\"identity_providers\".\"issuer\""},
+	ClientID:     whereHelperstring{field: "\"auth\".\"identity_providers\".\"client_id\""},
+	ClientSecret: whereHelperstring{field: "\"auth\".\"identity_providers\".\"client_secret\""},
+	Scopes:       whereHelperstring{field: "\"auth\".\"identity_providers\".\"scopes\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"auth\".\"identity_providers\".\"created_at\""},
+}
+
+// IdentityProviderRels is where relationship names are stored.
+var IdentityProviderRels = struct {
+	Instance           string
+	ProviderIdentities string
+	ProviderOidcStates string
+}{
+	Instance:           "Instance",
+	ProviderIdentities: "ProviderIdentities",
+	ProviderOidcStates: "ProviderOidcStates",
+}
+
+// identityProviderR is where relationships are stored.
+type identityProviderR struct {
+	Instance           *Instance      `boil:"Instance" json:"Instance" toml:"Instance" yaml:"Instance"`
+	ProviderIdentities IdentitySlice  `boil:"ProviderIdentities" json:"ProviderIdentities" toml:"ProviderIdentities" yaml:"ProviderIdentities"`
+	ProviderOidcStates OidcStateSlice `boil:"ProviderOidcStates" json:"ProviderOidcStates" toml:"ProviderOidcStates" yaml:"ProviderOidcStates"`
+}
+
+// NewStruct creates a new relationship struct
+func (*identityProviderR) NewStruct() *identityProviderR {
+	return &identityProviderR{}
+}
+
+// identityProviderL is where Load methods for each relationship are stored.
+type identityProviderL struct{}
+
+var (
+	identityProviderAllColumns            = []string{"id", "instance_id", "slug", "name", "issuer", "client_id", "client_secret", "scopes", "created_at"}
+	identityProviderColumnsWithoutDefault = []string{"id", "instance_id", "slug", "name", "issuer", "client_id", "client_secret"}
+	identityProviderColumnsWithDefault    = []string{"scopes", "created_at"}
+	identityProviderPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// IdentityProviderSlice is an alias for a slice of pointers to IdentityProvider.
+	// This should almost always be used instead of []IdentityProvider.
+	IdentityProviderSlice []*IdentityProvider
+	// IdentityProviderHook is the signature for custom IdentityProvider hook methods
+	IdentityProviderHook func(context.Context, boil.ContextExecutor, *IdentityProvider) error
+
+	identityProviderQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	identityProviderType                 = reflect.TypeOf(&IdentityProvider{})
+	identityProviderMapping              = queries.MakeStructMapping(identityProviderType)
+	identityProviderPrimaryKeyMapping, _ = queries.BindMapping(identityProviderType, identityProviderMapping, identityProviderPrimaryKeyColumns)
+	identityProviderInsertCacheMut       sync.RWMutex
+	identityProviderInsertCache          = make(map[string]insertCache)
+	identityProviderUpdateCacheMut       sync.RWMutex
+	identityProviderUpdateCache          = make(map[string]updateCache)
+	identityProviderUpsertCacheMut       sync.RWMutex
+	identityProviderUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var identityProviderBeforeInsertHooks []IdentityProviderHook
+var identityProviderBeforeUpdateHooks []IdentityProviderHook
+var identityProviderBeforeDeleteHooks []IdentityProviderHook
+var identityProviderBeforeUpsertHooks []IdentityProviderHook
+
+var identityProviderAfterInsertHooks []IdentityProviderHook
+var identityProviderAfterSelectHooks []IdentityProviderHook
+var identityProviderAfterUpdateHooks []IdentityProviderHook
+var identityProviderAfterDeleteHooks []IdentityProviderHook
+var identityProviderAfterUpsertHooks []IdentityProviderHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *IdentityProvider) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *IdentityProvider) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *IdentityProvider) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *IdentityProvider) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *IdentityProvider) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *IdentityProvider) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *IdentityProvider) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *IdentityProvider) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *IdentityProvider) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range identityProviderAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddIdentityProviderHook registers your hook function for all future operations.
+func AddIdentityProviderHook(hookPoint boil.HookPoint, identityProviderHook IdentityProviderHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		identityProviderBeforeInsertHooks = append(identityProviderBeforeInsertHooks, identityProviderHook)
+	case boil.BeforeUpdateHook:
+		identityProviderBeforeUpdateHooks = append(identityProviderBeforeUpdateHooks, identityProviderHook)
+	case boil.BeforeDeleteHook:
+		identityProviderBeforeDeleteHooks = append(identityProviderBeforeDeleteHooks, identityProviderHook)
+	case boil.BeforeUpsertHook:
+		identityProviderBeforeUpsertHooks = append(identityProviderBeforeUpsertHooks, identityProviderHook)
+	case boil.AfterInsertHook:
+		identityProviderAfterInsertHooks = append(identityProviderAfterInsertHooks, identityProviderHook)
+	case boil.AfterSelectHook:
+		identityProviderAfterSelectHooks = append(identityProviderAfterSelectHooks, identityProviderHook)
+	case boil.AfterUpdateHook:
+		identityProviderAfterUpdateHooks = append(identityProviderAfterUpdateHooks, identityProviderHook)
+	case boil.AfterDeleteHook:
+		identityProviderAfterDeleteHooks = append(identityProviderAfterDeleteHooks, identityProviderHook)
+	case boil.AfterUpsertHook:
+		identityProviderAfterUpsertHooks = append(identityProviderAfterUpsertHooks, identityProviderHook)
+	}
+}
+
+// One returns a single identityProvider record from the query.
+func (q identityProviderQuery) One(ctx context.Context, exec boil.ContextExecutor) (*IdentityProvider, error) {
+	o := &IdentityProvider{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for identity_providers")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all IdentityProvider records from the query.
+func (q identityProviderQuery) All(ctx context.Context, exec boil.ContextExecutor) (IdentityProviderSlice, error) {
+	var o []*IdentityProvider
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to IdentityProvider slice")
+	}
+
+	if len(identityProviderAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all IdentityProvider records in the query.
+func (q identityProviderQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count identity_providers rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q identityProviderQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if identity_providers exists")
+	}
+
+	return count > 0, nil
+}
+
+// Instance pointed to by the foreign key.
+func (o *IdentityProvider) Instance(mods ...qm.QueryMod) instanceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.InstanceID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Instances(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"instances\"")
+
+	return query
+}
+
+// ProviderIdentities retrieves all the identity's Identities with an executor via provider_id column.
+func (o *IdentityProvider) ProviderIdentities(mods ...qm.QueryMod) identityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"identities\".\"provider_id\"=?", o.ID),
+	)
+
+	query := Identities(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"identities\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"identities\".*"})
+	}
+
+	return query
+}
+
+// ProviderOidcStates retrieves all the oidc_state's OidcStates with an executor via provider_id column.
+func (o *IdentityProvider) ProviderOidcStates(mods ...qm.QueryMod) oidcStateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"oidc_states\".\"provider_id\"=?", o.ID),
+	)
+
+	query := OidcStates(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"oidc_states\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"oidc_states\".*"})
+	}
+
+	return query
+}
+
+// LoadInstance allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (identityProviderL) LoadInstance(ctx context.Context, e boil.ContextExecutor, singular bool, maybeIdentityProvider interface{}, mods queries.Applicator) error {
+	var slice []*IdentityProvider
+	var object *IdentityProvider
+
+	if singular {
+		object = maybeIdentityProvider.(*IdentityProvider)
+	} else {
+		slice = *maybeIdentityProvider.(*[]*IdentityProvider)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &identityProviderR{}
+		}
+		args = append(args, object.InstanceID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &identityProviderR{}
+			}
+
+			for _, a := range args {
+				if a == obj.InstanceID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.InstanceID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.instances`),
+		qm.WhereIn(`auth.instances.id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.instances.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Instance")
+	}
+
+	var resultSlice []*Instance
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Instance")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for instances")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for instances")
+	}
+
+	if len(identityProviderAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Instance = foreign
+		if foreign.R == nil {
+			foreign.R = &instanceR{}
+		}
+		foreign.R.IdentityProviders = append(foreign.R.IdentityProviders, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InstanceID == foreign.ID {
+				local.R.Instance = foreign
+				if foreign.R == nil {
+					foreign.R = &instanceR{}
+				}
+				foreign.R.IdentityProviders = append(foreign.R.IdentityProviders, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProviderIdentities allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (identityProviderL) LoadProviderIdentities(ctx context.Context, e boil.ContextExecutor, singular bool, maybeIdentityProvider interface{}, mods queries.Applicator) error {
+	var slice []*IdentityProvider
+	var object *IdentityProvider
+
+	if singular {
+		object = maybeIdentityProvider.(*IdentityProvider)
+	} else {
+		slice = *maybeIdentityProvider.(*[]*IdentityProvider)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &identityProviderR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &identityProviderR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.identities`),
+		qm.WhereIn(`auth.identities.provider_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load identities")
+	}
+
+	var resultSlice []*Identity
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice identities")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on identities")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for identities")
+	}
+
+	if len(identityAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ProviderIdentities = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &identityR{}
+			}
+			foreign.R.Provider = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ProviderID {
+				local.R.ProviderIdentities = append(local.R.ProviderIdentities, foreign)
+				if foreign.R == nil {
+					foreign.R = &identityR{}
+				}
+				foreign.R.Provider = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProviderOidcStates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (identityProviderL) LoadProviderOidcStates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeIdentityProvider interface{}, mods queries.Applicator) error {
+	var slice []*IdentityProvider
+	var object *IdentityProvider
+
+	if singular {
+		object = maybeIdentityProvider.(*IdentityProvider)
+	} else {
+		slice = *maybeIdentityProvider.(*[]*IdentityProvider)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &identityProviderR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &identityProviderR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.oidc_states`),
+		qm.WhereIn(`auth.oidc_states.provider_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load oidc_states")
+	}
+
+	var resultSlice []*OidcState
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice oidc_states")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on oidc_states")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oidc_states")
+	}
+
+	if len(oidcStateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ProviderOidcStates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &oidcStateR{}
+			}
+			foreign.R.Provider = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ProviderID {
+				local.R.ProviderOidcStates = append(local.R.ProviderOidcStates, foreign)
+				if foreign.R == nil {
+					foreign.R = &oidcStateR{}
+				}
+				foreign.R.Provider = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInstance of the identityProvider to the related item.
+// Sets o.R.Instance to related.
+// Adds o to related.R.IdentityProviders.
+func (o *IdentityProvider) SetInstance(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Instance) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"identity_providers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"instance_id"}),
+		strmangle.WhereClause("\"", "\"", 2, identityProviderPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InstanceID = related.ID
+	if o.R == nil {
+		o.R = &identityProviderR{
+			Instance: related,
+		}
+	} else {
+		o.R.Instance = related
+	}
+
+	if related.R == nil {
+		related.R = &instanceR{
+			IdentityProviders: IdentityProviderSlice{o},
+		}
+	} else {
+		related.R.IdentityProviders = append(related.R.IdentityProviders, o)
+	}
+
+	return nil
+}
+
+// AddProviderIdentities adds the given related objects to the existing relationships
+// of the identity_provider, optionally inserting them as new records.
+// Appends related to o.R.ProviderIdentities.
+// Sets related.R.Provider appropriately.
+func (o *IdentityProvider) AddProviderIdentities(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Identity) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ProviderID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"identities\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"provider_id"}),
+				strmangle.WhereClause("\"", "\"", 2, identityPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ProviderID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &identityProviderR{
+			ProviderIdentities: related,
+		}
+	} else {
+		o.R.ProviderIdentities = append(o.R.ProviderIdentities, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &identityR{
+				Provider: o,
+			}
+		} else {
+			rel.R.Provider = o
+		}
+	}
+	return nil
+}
+
+// AddProviderOidcStates adds the given related objects to the existing relationships
+// of the identity_provider, optionally inserting them as new records.
+// Appends related to o.R.ProviderOidcStates.
+// Sets related.R.Provider appropriately.
+func (o *IdentityProvider) AddProviderOidcStates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OidcState) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ProviderID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"oidc_states\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"provider_id"}),
+				strmangle.WhereClause("\"", "\"", 2, oidcStatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.State}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ProviderID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &identityProviderR{
+			ProviderOidcStates: related,
+		}
+	} else {
+		o.R.ProviderOidcStates = append(o.R.ProviderOidcStates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &oidcStateR{
+				Provider: o,
+			}
+		} else {
+			rel.R.Provider = o
+		}
+	}
+	return nil
+}
+
+// IdentityProviders retrieves all the records using an executor.
+func IdentityProviders(mods ...qm.QueryMod) identityProviderQuery {
+	mods = append(mods, qm.From("\"auth\".\"identity_providers\""))
+	return identityProviderQuery{NewQuery(mods...)}
+}
+
+// FindIdentityProvider retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindIdentityProvider(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*IdentityProvider, error) {
+	identityProviderObj := &IdentityProvider{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"identity_providers\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, identityProviderObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from identity_providers")
+	}
+
+	if err = identityProviderObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return identityProviderObj, err
+	}
+
+	return identityProviderObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *IdentityProvider) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no identity_providers provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(identityProviderColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	identityProviderInsertCacheMut.RLock()
+	cache, cached := identityProviderInsertCache[key]
+	identityProviderInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			identityProviderAllColumns,
+			identityProviderColumnsWithDefault,
+			identityProviderColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"identity_providers\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"identity_providers\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into identity_providers")
+	}
+
+	if !cached {
+		identityProviderInsertCacheMut.Lock()
+		identityProviderInsertCache[key] = cache
+		identityProviderInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the IdentityProvider.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *IdentityProvider) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	identityProviderUpdateCacheMut.RLock()
+	cache, cached := identityProviderUpdateCache[key]
+	identityProviderUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			identityProviderAllColumns,
+			identityProviderPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update identity_providers, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"identity_providers\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, identityProviderPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, append(wl, identityProviderPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update identity_providers row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for identity_providers")
+	}
+
+	if !cached {
+		identityProviderUpdateCacheMut.Lock()
+		identityProviderUpdateCache[key] = cache
+		identityProviderUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q identityProviderQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for identity_providers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for identity_providers")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o IdentityProviderSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), identityProviderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"identity_providers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, identityProviderPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in identityProvider slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all identityProvider")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *IdentityProvider) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no identity_providers provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(identityProviderColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	identityProviderUpsertCacheMut.RLock()
+	cache, cached := identityProviderUpsertCache[key]
+	identityProviderUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			identityProviderAllColumns,
+			identityProviderColumnsWithDefault,
+			identityProviderColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			identityProviderAllColumns,
+			identityProviderPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert identity_providers, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(identityProviderPrimaryKeyColumns))
+			copy(conflict, identityProviderPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"identity_providers\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(identityProviderType, identityProviderMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert identity_providers")
+	}
+
+	if !cached {
+		identityProviderUpsertCacheMut.Lock()
+		identityProviderUpsertCache[key] = cache
+		identityProviderUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single IdentityProvider record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *IdentityProvider) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no IdentityProvider provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), identityProviderPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"identity_providers\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from identity_providers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for identity_providers")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q identityProviderQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no identityProviderQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from identity_providers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for identity_providers")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o IdentityProviderSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(identityProviderBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), identityProviderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"identity_providers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, identityProviderPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from identityProvider slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for identity_providers")
+	}
+
+	if len(identityProviderAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *IdentityProvider) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindIdentityProvider(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *IdentityProviderSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := IdentityProviderSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), identityProviderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"identity_providers\".* FROM \"auth\".\"identity_providers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, identityProviderPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in IdentityProviderSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// IdentityProviderExists checks if the IdentityProvider row exists.
+func IdentityProviderExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"identity_providers\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if identity_providers exists")
+	}
+
+	return exists, nil
+}
@@ -24,67 +24,106 @@ import (
 
 // Instance is an object representing the database table.
 type Instance struct {
-	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	URL       string    `boil:"url" json:"url" toml:"url" yaml:"url"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	DeletedAt null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ID                    string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name                  string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	URL                   string    `boil:"url" json:"url" toml:"url" yaml:"url"`
+	CreatedAt             time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt             time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt             null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	RequireVerification   bool      `boil:"require_verification" json:"require_verification" toml:"require_verification" yaml:"require_verification"`
+	RequireTwoFactor      bool      `boil:"require_two_factor" json:"require_two_factor" toml:"require_two_factor" yaml:"require_two_factor"`
+	PasswordMinLength     int       `boil:"password_min_length" json:"password_min_length" toml:"password_min_length" yaml:"password_min_length"`
+	PasswordMinClasses    int       `boil:"password_min_classes" json:"password_min_classes" toml:"password_min_classes" yaml:"password_min_classes"`
+	PasswordCheckBreached bool      `boil:"password_check_breached" json:"password_check_breached" toml:"password_check_breached" yaml:"password_check_breached"`
+	PasswordMaxLength     int       `boil:"password_max_length" json:"password_max_length" toml:"password_max_length" yaml:"password_max_length"`
 
 	R *instanceR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L instanceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var InstanceColumns = struct {
-	ID        string
-	Name      string
-	URL       string
-	CreatedAt string
-	UpdatedAt string
-	DeletedAt string
+	ID                    string
+	Name                  string
+	URL                   string
+	CreatedAt             string
+	UpdatedAt             string
+	DeletedAt             string
+	RequireVerification   string
+	RequireTwoFactor      string
+	PasswordMinLength     string
+	PasswordMinClasses    string
+	PasswordCheckBreached string
+	PasswordMaxLength     string
 }{
-	ID:        "id",
-	Name:      "name",
-	URL:       "url",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
-	DeletedAt: "deleted_at",
+	ID:                    "id",
+	Name:                  "name",
+	URL:                   "url",
+	CreatedAt:             "created_at",
+	UpdatedAt:             "updated_at",
+	DeletedAt:             "deleted_at",
+	RequireVerification:   "require_verification",
+	RequireTwoFactor:      "require_two_factor",
+	PasswordMinLength:     "password_min_length",
+	PasswordMinClasses:    "password_min_classes",
+	PasswordCheckBreached: "password_check_breached",
+	PasswordMaxLength:     "password_max_length",
 }
 
 var InstanceTableColumns = struct {
-	ID        string
-	Name      string
-	URL       string
-	CreatedAt string
-	UpdatedAt string
-	DeletedAt string
+	ID                    string
+	Name                  string
+	URL                   string
+	CreatedAt             string
+	UpdatedAt             string
+	DeletedAt             string
+	RequireVerification   string
+	RequireTwoFactor      string
+	PasswordMinLength     string
+	PasswordMinClasses    string
+	PasswordCheckBreached string
+	PasswordMaxLength     string
 }{
-	ID:        "instances.id",
-	Name:      "instances.name",
-	URL:       "instances.url",
-	CreatedAt: "instances.created_at",
-	UpdatedAt: "instances.updated_at",
-	DeletedAt: "instances.deleted_at",
+	ID:                    "instances.id",
+	Name:                  "instances.name",
+	URL:                   "instances.url",
+	CreatedAt:             "instances.created_at",
+	UpdatedAt:             "instances.updated_at",
+	DeletedAt:             "instances.deleted_at",
+	RequireVerification:   "instances.require_verification",
+	RequireTwoFactor:      "instances.require_two_factor",
+	PasswordMinLength:     "instances.password_min_length",
+	PasswordMinClasses:    "instances.password_min_classes",
+	PasswordCheckBreached: "instances.password_check_breached",
+	PasswordMaxLength:     "instances.password_max_length",
 }
 
 // Generated where
 
-type whereHelperstring struct{ field string }
+type whereHelperbool struct{ field string }
 
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
 	}
 	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
 }
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
@@ -92,77 +131,56 @@ func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
 var InstanceWhere = struct {
-	ID        whereHelperstring
-	Name      whereHelperstring
-	URL       whereHelperstring
-	CreatedAt whereHelpertime_Time
-	UpdatedAt whereHelpertime_Time
-	DeletedAt whereHelpernull_Time
+	ID                    whereHelperstring
+	Name                  whereHelperstring
+	URL                   whereHelperstring
+	CreatedAt             whereHelpertime_Time
+	UpdatedAt             whereHelpertime_Time
+	DeletedAt             whereHelpernull_Time
+	RequireVerification   whereHelperbool
+	RequireTwoFactor      whereHelperbool
+	PasswordMinLength     whereHelperint
+	PasswordMinClasses    whereHelperint
+	PasswordCheckBreached whereHelperbool
+	PasswordMaxLength     whereHelperint
 }{
-	ID:        whereHelperstring{field: "\"auth\".\"instances\".\"id\""},
-	Name:      whereHelperstring{field: "\"auth\".\"instances\".\"name\""},
-	URL:       whereHelperstring{field: "\"auth\".\"instances\".\"url\""},
-	CreatedAt: whereHelpertime_Time{field: "\"auth\".\"instances\".\"created_at\""},
-	UpdatedAt: whereHelpertime_Time{field: "\"auth\".\"instances\".\"updated_at\""},
-	DeletedAt: whereHelpernull_Time{field: "\"auth\".\"instances\".\"deleted_at\""},
+	ID:                    whereHelperstring{field: "\"auth\".\"instances\".\"id\""},
+	Name:                  whereHelperstring{field: "\"auth\".\"instances\".\"name\""},
+	URL:                   whereHelperstring{field: "\"auth\".\"instances\".\"url\""},
+	CreatedAt:             whereHelpertime_Time{field: "\"auth\".\"instances\".\"created_at\""},
+	UpdatedAt:             whereHelpertime_Time{field: "\"auth\".\"instances\".\"updated_at\""},
+	DeletedAt:             whereHelpernull_Time{field: "\"auth\".\"instances\".\"deleted_at\""},
+	RequireVerification:   whereHelperbool{field: "\"auth\".\"instances\".\"require_verification\""},
+	RequireTwoFactor:      whereHelperbool{field: "\"auth\".\"instances\".\"require_two_factor\""},
+	PasswordMinLength:     whereHelperint{field: "\"auth\".\"instances\".\"password_min_length\""},
+	PasswordMinClasses:    whereHelperint{field: "\"auth\".\"instances\".\"password_min_classes\""},
+	PasswordCheckBreached: whereHelperbool{field: "\"auth\".\"instances\".\"password_check_breached\""},
+	PasswordMaxLength:     whereHelperint{field: "\"auth\".\"instances\".\"password_max_length\""},
 }
 
 // InstanceRels is where relationship names are stored.
 var InstanceRels = struct {
-	Profiles string
+	APIKeys           string
+	IdentityProviders string
+	Invitations       string
+	OauthClients      string
+	Profiles          string
 }{
-	Profiles: "Profiles",
+	APIKeys:           "APIKeys",
+	IdentityProviders: "IdentityProviders",
+	Invitations:       "Invitations",
+	OauthClients:      "OauthClients",
+	Profiles:          "Profiles",
 }
 
 // instanceR is where relationships are stored.
 type instanceR struct {
-	Profiles ProfileSlice `boil:"Profiles" json:"Profiles" toml:"Profiles" yaml:"Profiles"`
+	APIKeys           APIKeySlice           `boil:"APIKeys" json:"APIKeys" toml:"APIKeys" yaml:"APIKeys"`
+	IdentityProviders IdentityProviderSlice `boil:"IdentityProviders" json:"IdentityProviders" toml:"IdentityProviders" yaml:"IdentityProviders"`
+	Invitations       InvitationSlice       `boil:"Invitations" json:"Invitations" toml:"Invitations" yaml:"Invitations"`
+	OauthClients      OauthClientSlice      `boil:"OauthClients" json:"OauthClients" toml:"OauthClients" yaml:"OauthClients"`
+	Profiles          ProfileSlice          `boil:"Profiles" json:"Profiles" toml:"Profiles" yaml:"Profiles"`
 }
 
 // NewStruct creates a new relationship struct
@@ -174,9 +192,9 @@ func (*instanceR) NewStruct() *instanceR {
 type instanceL struct{}
 
 var (
-	instanceAllColumns            = []string{"id", "name", "url", "created_at", "updated_at", "deleted_at"}
+	instanceAllColumns            = []string{"id", "name", "url", "created_at", "updated_at", "deleted_at", "require_verification", "require_two_factor", "password_min_length", "password_min_classes", "password_check_breached", "password_max_length"}
 	instanceColumnsWithoutDefault = []string{"id", "name", "url", "deleted_at"}
-	instanceColumnsWithDefault    = []string{"created_at", "updated_at"}
+	instanceColumnsWithDefault    = []string{"created_at", "updated_at", "require_verification", "require_two_factor", "password_min_length", "password_min_classes", "password_check_breached", "password_max_length"}
 	instancePrimaryKeyColumns     = []string{"id"}
 )
 
@@ -455,6 +473,90 @@ func (q instanceQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (b
 	return count > 0, nil
 }
 
+// APIKeys retrieves all the api_key's APIKeys with an executor.
+func (o *Instance) APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"api_keys\".\"instance_id\"=?", o.ID),
+	)
+
+	query := APIKeys(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"api_keys\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"api_keys\".*"})
+	}
+
+	return query
+}
+
+// IdentityProviders retrieves all the identity_provider's IdentityProviders with an executor.
+func (o *Instance) IdentityProviders(mods ...qm.QueryMod) identityProviderQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"identity_providers\".\"instance_id\"=?", o.ID),
+	)
+
+	query := IdentityProviders(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"identity_providers\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"identity_providers\".*"})
+	}
+
+	return query
+}
+
+// Invitations retrieves all the invitation's Invitations with an executor.
+func (o *Instance) Invitations(mods ...qm.QueryMod) invitationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"invitations\".\"instance_id\"=?", o.ID),
+	)
+
+	query := Invitations(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"invitations\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"invitations\".*"})
+	}
+
+	return query
+}
+
+// OauthClients retrieves all the oauth_client's OauthClients with an executor.
+func (o *Instance) OauthClients(mods ...qm.QueryMod) oauthClientQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"oauth_clients\".\"instance_id\"=?", o.ID),
+	)
+
+	query := OauthClients(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"oauth_clients\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"oauth_clients\".*"})
+	}
+
+	return query
+}
+
 // Profiles retrieves all the profile's Profiles with an executor.
 func (o *Instance) Profiles(mods ...qm.QueryMod) profileQuery {
 	var queryMods []qm.QueryMod
@@ -477,9 +579,9 @@ func (o *Instance) Profiles(mods ...qm.QueryMod) profileQuery {
 	return query
 }
 
-// LoadProfiles allows an eager lookup of values, cached into the
+// LoadAPIKeys allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (instanceL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInstance interface{}, mods queries.Applicator) error {
+func (instanceL) LoadAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInstance interface{}, mods queries.Applicator) error {
 	var slice []*Instance
 	var object *Instance
 
@@ -517,9 +619,8 @@ func (instanceL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singu
 	}
 
 	query := NewQuery(
-		qm.From(`auth.profiles`),
-		qm.WhereIn(`auth.profiles.instance_id in ?`, args...),
-		qmhelper.WhereIsNull(`auth.profiles.deleted_at`),
+		qm.From(`auth.api_keys`),
+		qm.WhereIn(`auth.api_keys.instance_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -527,22 +628,22 @@ func (instanceL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singu
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load profiles")
+		return errors.Wrap(err, "failed to eager load api_keys")
 	}
 
-	var resultSlice []*Profile
+	var resultSlice []*APIKey
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice profiles")
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on profiles")
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for profiles")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
 	}
 
-	if len(profileAfterSelectHooks) != 0 {
+	if len(apiKeyAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -550,10 +651,10 @@ func (instanceL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singu
 		}
 	}
 	if singular {
-		object.R.Profiles = resultSlice
+		object.R.APIKeys = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &profileR{}
+				foreign.R = &apiKeyR{}
 			}
 			foreign.R.Instance = object
 		}
@@ -563,9 +664,107 @@ func (instanceL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singu
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.InstanceID {
-				local.R.Profiles = append(local.R.Profiles, foreign)
+				local.R.APIKeys = append(local.R.APIKeys, foreign)
 				if foreign.R == nil {
-					foreign.R = &profileR{}
+					foreign.R = &apiKeyR{}
+				}
+				foreign.R.Instance = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadIdentityProviders allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (instanceL) LoadIdentityProviders(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInstance interface{}, mods queries.Applicator) error {
+	var slice []*Instance
+	var object *Instance
+
+	if singular {
+		object = maybeInstance.(*Instance)
+	} else {
+		slice = *maybeInstance.(*[]*Instance)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &instanceR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &instanceR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.identity_providers`),
+		qm.WhereIn(`auth.identity_providers.instance_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load identity_providers")
+	}
+
+	var resultSlice []*IdentityProvider
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice identity_providers")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on identity_providers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for identity_providers")
+	}
+
+	if len(identityProviderAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.IdentityProviders = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &identityProviderR{}
+			}
+			foreign.R.Instance = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InstanceID {
+				local.R.IdentityProviders = append(local.R.IdentityProviders, foreign)
+				if foreign.R == nil {
+					foreign.R = &identityProviderR{}
 				}
 				foreign.R.Instance = local
 				break
@@ -576,6 +775,513 @@ func (instanceL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singu
 	return nil
 }
 
+// LoadInvitations allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (instanceL) LoadInvitations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInstance interface{}, mods queries.Applicator) error {
+	var slice []*Instance
+	var object *Instance
+
+	if singular {
+		object = maybeInstance.(*Instance)
+	} else {
+		slice = *maybeInstance.(*[]*Instance)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &instanceR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &instanceR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.invitations`),
+		qm.WhereIn(`auth.invitations.instance_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invitations")
+	}
+
+	var resultSlice []*Invitation
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invitations")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invitations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invitations")
+	}
+
+	if len(invitationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Invitations = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invitationR{}
+			}
+			foreign.R.Instance = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InstanceID {
+				local.R.Invitations = append(local.R.Invitations, foreign)
+				if foreign.R == nil {
+					foreign.R = &invitationR{}
+				}
+				foreign.R.Instance = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOauthClients allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (instanceL) LoadOauthClients(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInstance interface{}, mods queries.Applicator) error {
+	var slice []*Instance
+	var object *Instance
+
+	if singular {
+		object = maybeInstance.(*Instance)
+	} else {
+		slice = *maybeInstance.(*[]*Instance)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &instanceR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &instanceR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.oauth_clients`),
+		qm.WhereIn(`auth.oauth_clients.instance_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load oauth_clients")
+	}
+
+	var resultSlice []*OauthClient
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice oauth_clients")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on oauth_clients")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oauth_clients")
+	}
+
+	if len(oauthClientAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.OauthClients = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &oauthClientR{}
+			}
+			foreign.R.Instance = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InstanceID {
+				local.R.OauthClients = append(local.R.OauthClients, foreign)
+				if foreign.R == nil {
+					foreign.R = &oauthClientR{}
+				}
+				foreign.R.Instance = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProfiles allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (instanceL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInstance interface{}, mods queries.Applicator) error {
+	var slice []*Instance
+	var object *Instance
+
+	if singular {
+		object = maybeInstance.(*Instance)
+	} else {
+		slice = *maybeInstance.(*[]*Instance)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &instanceR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &instanceR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.profiles`),
+		qm.WhereIn(`auth.profiles.instance_id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.profiles.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load profiles")
+	}
+
+	var resultSlice []*Profile
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice profiles")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on profiles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for profiles")
+	}
+
+	if len(profileAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Profiles = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &profileR{}
+			}
+			foreign.R.Instance = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.InstanceID {
+				local.R.Profiles = append(local.R.Profiles, foreign)
+				if foreign.R == nil {
+					foreign.R = &profileR{}
+				}
+				foreign.R.Instance = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAPIKeys adds the given related objects to the existing relationships
+// of the instance, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.Instance appropriately.
+func (o *Instance) AddAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InstanceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"instance_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InstanceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &instanceR{
+			APIKeys: related,
+		}
+	} else {
+		o.R.APIKeys = append(o.R.APIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				Instance: o,
+			}
+		} else {
+			rel.R.Instance = o
+		}
+	}
+	return nil
+}
+
+// AddIdentityProviders adds the given related objects to the existing relationships
+// of the instance, optionally inserting them as new records.
+// Appends related to o.R.IdentityProviders.
+// Sets related.R.Instance appropriately.
+func (o *Instance) AddIdentityProviders(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*IdentityProvider) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InstanceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"identity_providers\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"instance_id"}),
+				strmangle.WhereClause("\"", "\"", 2, identityProviderPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InstanceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &instanceR{
+			IdentityProviders: related,
+		}
+	} else {
+		o.R.IdentityProviders = append(o.R.IdentityProviders, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &identityProviderR{
+				Instance: o,
+			}
+		} else {
+			rel.R.Instance = o
+		}
+	}
+	return nil
+}
+
+// AddInvitations adds the given related objects to the existing relationships
+// of the instance, optionally inserting them as new records.
+// Appends related to o.R.Invitations.
+// Sets related.R.Instance appropriately.
+func (o *Instance) AddInvitations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Invitation) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InstanceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"invitations\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"instance_id"}),
+				strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InstanceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &instanceR{
+			Invitations: related,
+		}
+	} else {
+		o.R.Invitations = append(o.R.Invitations, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invitationR{
+				Instance: o,
+			}
+		} else {
+			rel.R.Instance = o
+		}
+	}
+	return nil
+}
+
+// AddOauthClients adds the given related objects to the existing relationships
+// of the instance, optionally inserting them as new records.
+// Appends related to o.R.OauthClients.
+// Sets related.R.Instance appropriately.
+func (o *Instance) AddOauthClients(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OauthClient) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.InstanceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"oauth_clients\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"instance_id"}),
+				strmangle.WhereClause("\"", "\"", 2, oauthClientPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.InstanceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &instanceR{
+			OauthClients: related,
+		}
+	} else {
+		o.R.OauthClients = append(o.R.OauthClients, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &oauthClientR{
+				Instance: o,
+			}
+		} else {
+			rel.R.Instance = o
+		}
+	}
+	return nil
+}
+
 // AddProfiles adds the given related objects to the existing relationships
 // of the instance, optionally inserting them as new records.
 // Appends related to o.R.Profiles.
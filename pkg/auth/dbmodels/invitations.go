@@ -0,0 +1,1315 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Invitation is an object representing the database table.
+type Invitation struct {
+	ID         string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InstanceID string      `boil:"instance_id" json:"instance_id" toml:"instance_id" yaml:"instance_id"`
+	Email      string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	Role       string      `boil:"role" json:"role" toml:"role" yaml:"role"`
+	Digest     []byte      `boil:"digest" json:"digest" toml:"digest" yaml:"digest"`
+	InvitedBy  null.String `boil:"invited_by" json:"invited_by,omitempty" toml:"invited_by" yaml:"invited_by,omitempty"`
+	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ExpiresAt  time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *invitationR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L invitationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InvitationColumns = struct {
+	ID         string
+	InstanceID string
+	Email      string
+	Role       string
+	Digest     string
+	InvitedBy  string
+	CreatedAt  string
+	ExpiresAt  string
+}{
+	ID:         "id",
+	InstanceID: "instance_id",
+	Email:      "email",
+	Role:       "role",
+	Digest:     "digest",
+	InvitedBy:  "invited_by",
+	CreatedAt:  "created_at",
+	ExpiresAt:  "expires_at",
+}
+
+var InvitationTableColumns = struct {
+	ID         string
+	InstanceID string
+	Email      string
+	Role       string
+	Digest     string
+	InvitedBy  string
+	CreatedAt  string
+	ExpiresAt  string
+}{
+	ID:         "invitations.id",
+	InstanceID: "invitations.instance_id",
+	Email:      "invitations.email",
+	Role:       "invitations.role",
+	Digest:     "invitations.digest",
+	InvitedBy:  "invitations.invited_by",
+	CreatedAt:  "invitations.created_at",
+	ExpiresAt:  "invitations.expires_at",
+}
+
+// Generated where
+
+var InvitationWhere = struct {
+	ID         whereHelperstring
+	InstanceID whereHelperstring
+	Email      whereHelperstring
+	Role       whereHelperstring
+	Digest     whereHelper__byte
+	InvitedBy  whereHelpernull_String
+	CreatedAt  whereHelpertime_Time
+	ExpiresAt  whereHelpertime_Time
+}{
+	ID:         whereHelperstring{field: "\"auth\".\"invitations\".\"id\""},
+	InstanceID: whereHelperstring{field: "\"auth\".\"invitations\".\"instance_id\""},
+	Email:      whereHelperstring{field: "\"auth\".\"invitations\".\"email\""},
+	Role:       whereHelperstring{field: "\"auth\".\"invitations\".\"role\""},
+	Digest:     whereHelper__byte{field: "\"auth\".\"invitations\".\"digest\""},
+	InvitedBy:  whereHelpernull_String{field: "\"auth\".\"invitations\".\"invited_by\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"auth\".\"invitations\".\"created_at\""},
+	ExpiresAt:  whereHelpertime_Time{field: "\"auth\".\"invitations\".\"expires_at\""},
+}
+
+// InvitationRels is where relationship names are stored.
+var InvitationRels = struct {
+	Instance      string
+	InvitedByUser string
+}{
+	Instance:      "Instance",
+	InvitedByUser: "InvitedByUser",
+}
+
+// invitationR is where relationships are stored.
+type invitationR struct {
+	Instance      *Instance `boil:"Instance" json:"Instance" toml:"Instance" yaml:"Instance"`
+	InvitedByUser *User     `boil:"InvitedByUser" json:"InvitedByUser" toml:"InvitedByUser" yaml:"InvitedByUser"`
+}
+
+// NewStruct creates a new relationship struct
+func (*invitationR) NewStruct() *invitationR {
+	return &invitationR{}
+}
+
+// invitationL is where Load methods for each relationship are stored.
+type invitationL struct{}
+
+var (
+	invitationAllColumns            = []string{"id", "instance_id", "email", "role", "digest", "invited_by", "created_at", "expires_at"}
+	invitationColumnsWithoutDefault = []string{"id", "instance_id", "email", "role", "digest", "invited_by", "expires_at"}
+	invitationColumnsWithDefault    = []string{"created_at"}
+	invitationPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// InvitationSlice is an alias for a slice of pointers to Invitation.
+	// This should almost always be used instead of []Invitation.
+	InvitationSlice []*Invitation
+	// InvitationHook is the signature for custom Invitation hook methods
+	InvitationHook func(context.Context, boil.ContextExecutor, *Invitation) error
+
+	invitationQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	invitationType                 = reflect.TypeOf(&Invitation{})
+	invitationMapping              = queries.MakeStructMapping(invitationType)
+	invitationPrimaryKeyMapping, _ = queries.BindMapping(invitationType, invitationMapping, invitationPrimaryKeyColumns)
+	invitationInsertCacheMut       sync.RWMutex
+	invitationInsertCache          = make(map[string]insertCache)
+	invitationUpdateCacheMut       sync.RWMutex
+	invitationUpdateCache          = make(map[string]updateCache)
+	invitationUpsertCacheMut       sync.RWMutex
+	invitationUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var invitationBeforeInsertHooks []InvitationHook
+var invitationBeforeUpdateHooks []InvitationHook
+var invitationBeforeDeleteHooks []InvitationHook
+var invitationBeforeUpsertHooks []InvitationHook
+
+var invitationAfterInsertHooks []InvitationHook
+var invitationAfterSelectHooks []InvitationHook
+var invitationAfterUpdateHooks []InvitationHook
+var invitationAfterDeleteHooks []InvitationHook
+var invitationAfterUpsertHooks []InvitationHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Invitation) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Invitation) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Invitation) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Invitation) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Invitation) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Invitation) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Invitation) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Invitation) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Invitation) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range invitationAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInvitationHook registers your hook function for all future operations.
+func AddInvitationHook(hookPoint boil.HookPoint, invitationHook InvitationHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		invitationBeforeInsertHooks = append(invitationBeforeInsertHooks, invitationHook)
+	case boil.BeforeUpdateHook:
+		invitationBeforeUpdateHooks = append(invitationBeforeUpdateHooks, invitationHook)
+	case boil.BeforeDeleteHook:
+		invitationBeforeDeleteHooks = append(invitationBeforeDeleteHooks, invitationHook)
+	case boil.BeforeUpsertHook:
+		invitationBeforeUpsertHooks = append(invitationBeforeUpsertHooks, invitationHook)
+	case boil.AfterInsertHook:
+		invitationAfterInsertHooks = append(invitationAfterInsertHooks, invitationHook)
+	case boil.AfterSelectHook:
+		invitationAfterSelectHooks = append(invitationAfterSelectHooks, invitationHook)
+	case boil.AfterUpdateHook:
+		invitationAfterUpdateHooks = append(invitationAfterUpdateHooks, invitationHook)
+	case boil.AfterDeleteHook:
+		invitationAfterDeleteHooks = append(invitationAfterDeleteHooks, invitationHook)
+	case boil.AfterUpsertHook:
+		invitationAfterUpsertHooks = append(invitationAfterUpsertHooks, invitationHook)
+	}
+}
+
+// One returns a single invitation record from the query.
+func (q invitationQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Invitation, error) {
+	o := &Invitation{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for invitations")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Invitation records from the query.
+func (q invitationQuery) All(ctx context.Context, exec boil.ContextExecutor) (InvitationSlice, error) {
+	var o []*Invitation
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to Invitation slice")
+	}
+
+	if len(invitationAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Invitation records in the query.
+func (q invitationQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count invitations rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q invitationQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if invitations exists")
+	}
+
+	return count > 0, nil
+}
+
+// Instance pointed to by the foreign key.
+func (o *Invitation) Instance(mods ...qm.QueryMod) instanceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.InstanceID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Instances(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"instances\"")
+
+	return query
+}
+
+// InvitedByUser pointed to by the foreign key.
+func (o *Invitation) InvitedByUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.InvitedBy),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"users\"")
+
+	return query
+}
+
+// LoadInstance allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invitationL) LoadInstance(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvitation interface{}, mods queries.Applicator) error {
+	var slice []*Invitation
+	var object *Invitation
+
+	if singular {
+		object = maybeInvitation.(*Invitation)
+	} else {
+		slice = *maybeInvitation.(*[]*Invitation)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &invitationR{}
+		}
+		args = append(args, object.InstanceID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invitationR{}
+			}
+
+			for _, a := range args {
+				if a == obj.InstanceID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.InstanceID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.instances`),
+		qm.WhereIn(`auth.instances.id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.instances.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Instance")
+	}
+
+	var resultSlice []*Instance
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Instance")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for instances")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for instances")
+	}
+
+	if len(invitationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Instance = foreign
+		if foreign.R == nil {
+			foreign.R = &instanceR{}
+		}
+		foreign.R.Invitations = append(foreign.R.Invitations, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InstanceID == foreign.ID {
+				local.R.Instance = foreign
+				if foreign.R == nil {
+					foreign.R = &instanceR{}
+				}
+				foreign.R.Invitations = append(foreign.R.Invitations, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvitedByUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (invitationL) LoadInvitedByUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInvitation interface{}, mods queries.Applicator) error {
+	var slice []*Invitation
+	var object *Invitation
+
+	if singular {
+		object = maybeInvitation.(*Invitation)
+	} else {
+		slice = *maybeInvitation.(*[]*Invitation)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &invitationR{}
+		}
+		if !queries.IsNil(object.InvitedBy) {
+			args = append(args, object.InvitedBy)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &invitationR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.InvitedBy) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.InvitedBy) {
+				args = append(args, obj.InvitedBy)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.users`),
+		qm.WhereIn(`auth.users.id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.users.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(invitationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.InvitedByUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.InvitedByInvitations = append(foreign.R.InvitedByInvitations, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.InvitedBy, foreign.ID) {
+				local.R.InvitedByUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.InvitedByInvitations = append(foreign.R.InvitedByInvitations, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInstance of the invitation to the related item.
+// Sets o.R.Instance to related.
+// Adds o to related.R.Invitations.
+func (o *Invitation) SetInstance(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Instance) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"invitations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"instance_id"}),
+		strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InstanceID = related.ID
+	if o.R == nil {
+		o.R = &invitationR{
+			Instance: related,
+		}
+	} else {
+		o.R.Instance = related
+	}
+
+	if related.R == nil {
+		related.R = &instanceR{
+			Invitations: InvitationSlice{o},
+		}
+	} else {
+		related.R.Invitations = append(related.R.Invitations, o)
+	}
+
+	return nil
+}
+
+// SetInvitedByUser of the invitation to the related item.
+// Sets o.R.InvitedByUser to related.
+// Adds o to related.R.InvitedByInvitations.
+func (o *Invitation) SetInvitedByUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"invitations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"invited_by"}),
+		strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.InvitedBy, related.ID)
+	if o.R == nil {
+		o.R = &invitationR{
+			InvitedByUser: related,
+		}
+	} else {
+		o.R.InvitedByUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			InvitedByInvitations: InvitationSlice{o},
+		}
+	} else {
+		related.R.InvitedByInvitations = append(related.R.InvitedByInvitations, o)
+	}
+
+	return nil
+}
+
+// RemoveInvitedByUser relationship.
+// Sets o.R.InvitedByUser to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Invitation) RemoveInvitedByUser(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.InvitedBy, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("invited_by")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.InvitedByUser = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.InvitedByInvitations {
+		if queries.Equal(o.InvitedBy, ri.InvitedBy) {
+			continue
+		}
+
+		ln := len(related.R.InvitedByInvitations)
+		if ln > 1 && i < ln-1 {
+			related.R.InvitedByInvitations[i] = related.R.InvitedByInvitations[ln-1]
+		}
+		related.R.InvitedByInvitations = related.R.InvitedByInvitations[:ln-1]
+		break
+	}
+	return nil
+}
+
+// Invitations retrieves all the records using an executor.
+func Invitations(mods ...qm.QueryMod) invitationQuery {
+	mods = append(mods, qm.From("\"auth\".\"invitations\""))
+	return invitationQuery{NewQuery(mods...)}
+}
+
+// FindInvitation retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInvitation(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*Invitation, error) {
+	invitationObj := &Invitation{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"invitations\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, invitationObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from invitations")
+	}
+
+	if err = invitationObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return invitationObj, err
+	}
+
+	return invitationObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Invitation) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no invitations provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invitationColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	invitationInsertCacheMut.RLock()
+	cache, cached := invitationInsertCache[key]
+	invitationInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			invitationAllColumns,
+			invitationColumnsWithDefault,
+			invitationColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(invitationType, invitationMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(invitationType, invitationMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"invitations\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"invitations\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into invitations")
+	}
+
+	if !cached {
+		invitationInsertCacheMut.Lock()
+		invitationInsertCache[key] = cache
+		invitationInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Invitation.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Invitation) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	invitationUpdateCacheMut.RLock()
+	cache, cached := invitationUpdateCache[key]
+	invitationUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			invitationAllColumns,
+			invitationPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update invitations, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"invitations\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, invitationPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(invitationType, invitationMapping, append(wl, invitationPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update invitations row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for invitations")
+	}
+
+	if !cached {
+		invitationUpdateCacheMut.Lock()
+		invitationUpdateCache[key] = cache
+		invitationUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q invitationQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for invitations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for invitations")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InvitationSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"invitations\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, invitationPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in invitation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all invitation")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Invitation) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no invitations provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(invitationColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	invitationUpsertCacheMut.RLock()
+	cache, cached := invitationUpsertCache[key]
+	invitationUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			invitationAllColumns,
+			invitationColumnsWithDefault,
+			invitationColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			invitationAllColumns,
+			invitationPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert invitations, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(invitationPrimaryKeyColumns))
+			copy(conflict, invitationPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"invitations\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(invitationType, invitationMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(invitationType, invitationMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert invitations")
+	}
+
+	if !cached {
+		invitationUpsertCacheMut.Lock()
+		invitationUpsertCache[key] = cache
+		invitationUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Invitation record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Invitation) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no Invitation provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), invitationPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"invitations\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from invitations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for invitations")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q invitationQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no invitationQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from invitations")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for invitations")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InvitationSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(invitationBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"invitations\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, invitationPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from invitation slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for invitations")
+	}
+
+	if len(invitationAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Invitation) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInvitation(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InvitationSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InvitationSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), invitationPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"invitations\".* FROM \"auth\".\"invitations\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, invitationPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in InvitationSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InvitationExists checks if the Invitation row exists.
+func InvitationExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"invitations\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if invitations exists")
+	}
+
+	return exists, nil
+}
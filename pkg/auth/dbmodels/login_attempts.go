@@ -0,0 +1,895 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// LoginAttempt is an object representing the database table.
+type LoginAttempt struct {
+	Key         string    `boil:"key" json:"key" toml:"key" yaml:"key"`
+	Failures    int       `boil:"failures" json:"failures" toml:"failures" yaml:"failures"`
+	LockedUntil null.Time `boil:"locked_until" json:"locked_until,omitempty" toml:"locked_until" yaml:"locked_until,omitempty"`
+	ExpiresAt   time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *loginAttemptR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L loginAttemptL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var LoginAttemptColumns = struct {
+	Key         string
+	Failures    string
+	LockedUntil string
+	ExpiresAt   string
+}{
+	Key:         "key",
+	Failures:    "failures",
+	LockedUntil: "locked_until",
+	ExpiresAt:   "expires_at",
+}
+
+var LoginAttemptTableColumns = struct {
+	Key         string
+	Failures    string
+	LockedUntil string
+	ExpiresAt   string
+}{
+	Key:         "login_attempts.key",
+	Failures:    "login_attempts.failures",
+	LockedUntil: "login_attempts.locked_until",
+	ExpiresAt:   "login_attempts.expires_at",
+}
+
+// Generated where
+
+var LoginAttemptWhere = struct {
+	Key         whereHelperstring
+	Failures    whereHelperint
+	LockedUntil whereHelpernull_Time
+	ExpiresAt   whereHelpertime_Time
+}{
+	Key:         whereHelperstring{field: "\"auth\".\"login_attempts\".\"key\""},
+	Failures:    whereHelperint{field: "\"auth\".\"login_attempts\".\"failures\""},
+	LockedUntil: whereHelpernull_Time{field: "\"auth\".\"login_attempts\".\"locked_until\""},
+	ExpiresAt:   whereHelpertime_Time{field: "\"auth\".\"login_attempts\".\"expires_at\""},
+}
+
+// LoginAttemptRels is where relationship names are stored.
+var LoginAttemptRels = struct {
+}{}
+
+// loginAttemptR is where relationships are stored.
+type loginAttemptR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*loginAttemptR) NewStruct() *loginAttemptR {
+	return &loginAttemptR{}
+}
+
+// loginAttemptL is where Load methods for each relationship are stored.
+type loginAttemptL struct{}
+
+var (
+	loginAttemptAllColumns            = []string{"key", "failures", "locked_until", "expires_at"}
+	loginAttemptColumnsWithoutDefault = []string{"key", "failures", "locked_until", "expires_at"}
+	loginAttemptColumnsWithDefault    = []string{}
+	loginAttemptPrimaryKeyColumns     = []string{"key"}
+)
+
+type (
+	// LoginAttemptSlice is an alias for a slice of pointers to LoginAttempt.
+	// This should almost always be used instead of []LoginAttempt.
+	LoginAttemptSlice []*LoginAttempt
+	// LoginAttemptHook is the signature for custom LoginAttempt hook methods
+	LoginAttemptHook func(context.Context, boil.ContextExecutor, *LoginAttempt) error
+
+	loginAttemptQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	loginAttemptType                 = reflect.TypeOf(&LoginAttempt{})
+	loginAttemptMapping              = queries.MakeStructMapping(loginAttemptType)
+	loginAttemptPrimaryKeyMapping, _ = queries.BindMapping(loginAttemptType, loginAttemptMapping, loginAttemptPrimaryKeyColumns)
+	loginAttemptInsertCacheMut       sync.RWMutex
+	loginAttemptInsertCache          = make(map[string]insertCache)
+	loginAttemptUpdateCacheMut       sync.RWMutex
+	loginAttemptUpdateCache          = make(map[string]updateCache)
+	loginAttemptUpsertCacheMut       sync.RWMutex
+	loginAttemptUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var loginAttemptBeforeInsertHooks []LoginAttemptHook
+var loginAttemptBeforeUpdateHooks []LoginAttemptHook
+var loginAttemptBeforeDeleteHooks []LoginAttemptHook
+var loginAttemptBeforeUpsertHooks []LoginAttemptHook
+
+var loginAttemptAfterInsertHooks []LoginAttemptHook
+var loginAttemptAfterSelectHooks []LoginAttemptHook
+var loginAttemptAfterUpdateHooks []LoginAttemptHook
+var loginAttemptAfterDeleteHooks []LoginAttemptHook
+var loginAttemptAfterUpsertHooks []LoginAttemptHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *LoginAttempt) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *LoginAttempt) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *LoginAttempt) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *LoginAttempt) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *LoginAttempt) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *LoginAttempt) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *LoginAttempt) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *LoginAttempt) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *LoginAttempt) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range loginAttemptAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddLoginAttemptHook registers your hook function for all future operations.
+func AddLoginAttemptHook(hookPoint boil.HookPoint, loginAttemptHook LoginAttemptHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		loginAttemptBeforeInsertHooks = append(loginAttemptBeforeInsertHooks, loginAttemptHook)
+	case boil.BeforeUpdateHook:
+		loginAttemptBeforeUpdateHooks = append(loginAttemptBeforeUpdateHooks, loginAttemptHook)
+	case boil.BeforeDeleteHook:
+		loginAttemptBeforeDeleteHooks = append(loginAttemptBeforeDeleteHooks, loginAttemptHook)
+	case boil.BeforeUpsertHook:
+		loginAttemptBeforeUpsertHooks = append(loginAttemptBeforeUpsertHooks, loginAttemptHook)
+	case boil.AfterInsertHook:
+		loginAttemptAfterInsertHooks = append(loginAttemptAfterInsertHooks, loginAttemptHook)
+	case boil.AfterSelectHook:
+		loginAttemptAfterSelectHooks = append(loginAttemptAfterSelectHooks, loginAttemptHook)
+	case boil.AfterUpdateHook:
+		loginAttemptAfterUpdateHooks = append(loginAttemptAfterUpdateHooks, loginAttemptHook)
+	case boil.AfterDeleteHook:
+		loginAttemptAfterDeleteHooks = append(loginAttemptAfterDeleteHooks, loginAttemptHook)
+	case boil.AfterUpsertHook:
+		loginAttemptAfterUpsertHooks = append(loginAttemptAfterUpsertHooks, loginAttemptHook)
+	}
+}
+
+// One returns a single loginAttempt record from the query.
+func (q loginAttemptQuery) One(ctx context.Context, exec boil.ContextExecutor) (*LoginAttempt, error) {
+	o := &LoginAttempt{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for login_attempts")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all LoginAttempt records from the query.
+func (q loginAttemptQuery) All(ctx context.Context, exec boil.ContextExecutor) (LoginAttemptSlice, error) {
+	var o []*LoginAttempt
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to LoginAttempt slice")
+	}
+
+	if len(loginAttemptAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all LoginAttempt records in the query.
+func (q loginAttemptQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count login_attempts rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q loginAttemptQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if login_attempts exists")
+	}
+
+	return count > 0, nil
+}
+
+// LoginAttempts retrieves all the records using an executor.
+func LoginAttempts(mods ...qm.QueryMod) loginAttemptQuery {
+	mods = append(mods, qm.From("\"auth\".\"login_attempts\""))
+	return loginAttemptQuery{NewQuery(mods...)}
+}
+
+// FindLoginAttempt retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindLoginAttempt(ctx context.Context, exec boil.ContextExecutor, key string, selectCols ...string) (*LoginAttempt, error) {
+	loginAttemptObj := &LoginAttempt{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"login_attempts\" where \"key\"=$1", sel,
+	)
+
+	q := queries.Raw(query, key)
+
+	err := q.Bind(ctx, exec, loginAttemptObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from login_attempts")
+	}
+
+	if err = loginAttemptObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return loginAttemptObj, err
+	}
+
+	return loginAttemptObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *LoginAttempt) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no login_attempts provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(loginAttemptColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	loginAttemptInsertCacheMut.RLock()
+	cache, cached := loginAttemptInsertCache[key]
+	loginAttemptInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			loginAttemptAllColumns,
+			loginAttemptColumnsWithDefault,
+			loginAttemptColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(loginAttemptType, loginAttemptMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(loginAttemptType, loginAttemptMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"login_attempts\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"login_attempts\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into login_attempts")
+	}
+
+	if !cached {
+		loginAttemptInsertCacheMut.Lock()
+		loginAttemptInsertCache[key] = cache
+		loginAttemptInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the LoginAttempt.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *LoginAttempt) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	loginAttemptUpdateCacheMut.RLock()
+	cache, cached := loginAttemptUpdateCache[key]
+	loginAttemptUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			loginAttemptAllColumns,
+			loginAttemptPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update login_attempts, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"login_attempts\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, loginAttemptPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(loginAttemptType, loginAttemptMapping, append(wl, loginAttemptPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update login_attempts row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for login_attempts")
+	}
+
+	if !cached {
+		loginAttemptUpdateCacheMut.Lock()
+		loginAttemptUpdateCache[key] = cache
+		loginAttemptUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q loginAttemptQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for login_attempts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for login_attempts")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o LoginAttemptSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"login_attempts\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, loginAttemptPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in loginAttempt slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all loginAttempt")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *LoginAttempt) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no login_attempts provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(loginAttemptColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	loginAttemptUpsertCacheMut.RLock()
+	cache, cached := loginAttemptUpsertCache[key]
+	loginAttemptUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			loginAttemptAllColumns,
+			loginAttemptColumnsWithDefault,
+			loginAttemptColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			loginAttemptAllColumns,
+			loginAttemptPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert login_attempts, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(loginAttemptPrimaryKeyColumns))
+			copy(conflict, loginAttemptPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"login_attempts\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(loginAttemptType, loginAttemptMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(loginAttemptType, loginAttemptMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert login_attempts")
+	}
+
+	if !cached {
+		loginAttemptUpsertCacheMut.Lock()
+		loginAttemptUpsertCache[key] = cache
+		loginAttemptUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single LoginAttempt record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *LoginAttempt) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no LoginAttempt provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), loginAttemptPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"login_attempts\" WHERE \"key\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from login_attempts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for login_attempts")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q loginAttemptQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no loginAttemptQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from login_attempts")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for login_attempts")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o LoginAttemptSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(loginAttemptBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"login_attempts\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, loginAttemptPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from loginAttempt slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for login_attempts")
+	}
+
+	if len(loginAttemptAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *LoginAttempt) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindLoginAttempt(ctx, exec, o.Key)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *LoginAttemptSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := LoginAttemptSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), loginAttemptPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"login_attempts\".* FROM \"auth\".\"login_attempts\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, loginAttemptPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in LoginAttemptSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// LoginAttemptExists checks if the LoginAttempt row exists.
+func LoginAttemptExists(ctx context.Context, exec boil.ContextExecutor, key string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"login_attempts\" where \"key\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, key)
+	}
+	row := exec.QueryRowContext(ctx, sql, key)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if login_attempts exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1556 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// OauthClient is an object representing the database table.
+type OauthClient struct {
+	ID           string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	InstanceID   string      `boil:"instance_id" json:"instance_id" toml:"instance_id" yaml:"instance_id"`
+	Name         string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Secret       null.Bytes  `boil:"secret" json:"secret,omitempty" toml:"secret" yaml:"secret,omitempty"`
+	RedirectUris string      `boil:"redirect_uris" json:"redirect_uris" toml:"redirect_uris" yaml:"redirect_uris"`
+	CreatedAt    time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Role         null.String `boil:"role" json:"role,omitempty" toml:"role" yaml:"role,omitempty"`
+	Scopes       string      `boil:"scopes" json:"scopes" toml:"scopes" yaml:"scopes"`
+
+	R *oauthClientR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L oauthClientL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var OauthClientColumns = struct {
+	ID           string
+	InstanceID   string
+	Name         string
+	Secret       string
+	RedirectUris string
+	CreatedAt    string
+	Role         string
+	Scopes       string
+}{
+	ID:           "id",
+	InstanceID:   "instance_id",
+	Name:         "name",
+	Secret:       "secret",
+	RedirectUris: "redirect_uris",
+	CreatedAt:    "created_at",
+	Role:         "role",
+	Scopes:       "scopes",
+}
+
+var OauthClientTableColumns = struct {
+	ID           string
+	InstanceID   string
+	Name         string
+	Secret       string
+	RedirectUris string
+	CreatedAt    string
+	Role         string
+	Scopes       string
+}{
+	ID:           "oauth_clients.id",
+	InstanceID:   "oauth_clients.instance_id",
+	Name:         "oauth_clients.name",
+	Secret:       "oauth_clients.secret",
+	RedirectUris: "oauth_clients.redirect_uris",
+	CreatedAt:    "oauth_clients.created_at",
+	Role:         "oauth_clients.role",
+	Scopes:       "oauth_clients.scopes",
+}
+
+// Generated where
+
+type whereHelpernull_Bytes struct{ field string }
+
+func (w whereHelpernull_Bytes) EQ(x null.Bytes) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Bytes) NEQ(x null.Bytes) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Bytes) LT(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Bytes) LTE(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Bytes) GT(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Bytes) GTE(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Bytes) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Bytes) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var OauthClientWhere = struct {
+	ID           whereHelperstring
+	InstanceID   whereHelperstring
+	Name         whereHelperstring
+	Secret       whereHelpernull_Bytes
+	RedirectUris whereHelperstring
+	CreatedAt    whereHelpertime_Time
+	Role         whereHelpernull_String
+	Scopes       whereHelperstring
+}{
+	ID:           whereHelperstring{field: "\"auth\".\"oauth_clients\".\"id\""},
+	InstanceID:   whereHelperstring{field: "\"auth\".\"oauth_clients\".\"instance_id\""},
+	Name:         whereHelperstring{field: "\"auth\".\"oauth_clients\".\"name\""},
+	Secret:       whereHelpernull_Bytes{field: "\"auth\".\"oauth_clients\".\"secret\""},
+	RedirectUris: whereHelperstring{field: "\"auth\".\"oauth_clients\".\"redirect_uris\""},
+	CreatedAt:    whereHelpertime_Time{field: "\"auth\".\"oauth_clients\".\"created_at\""},
+	Role:         whereHelpernull_String{field: "\"auth\".\"oauth_clients\".\"role\""},
+	Scopes:       whereHelperstring{field: "\"auth\".\"oauth_clients\".\"scopes\""},
+}
+
+// OauthClientRels is where relationship names are stored.
+var OauthClientRels = struct {
+	Instance                 string
+	ClientAuthorizationCodes string
+	ClientTokens             string
+}{
+	Instance:                 "Instance",
+	ClientAuthorizationCodes: "ClientAuthorizationCodes",
+	ClientTokens:             "ClientTokens",
+}
+
+// oauthClientR is where relationships are stored.
+type oauthClientR struct {
+	Instance                 *Instance              `boil:"Instance" json:"Instance" toml:"Instance" yaml:"Instance"`
+	ClientAuthorizationCodes AuthorizationCodeSlice `boil:"ClientAuthorizationCodes" json:"ClientAuthorizationCodes" toml:"ClientAuthorizationCodes" yaml:"ClientAuthorizationCodes"`
+	ClientTokens             TokenSlice             `boil:"ClientTokens" json:"ClientTokens" toml:"ClientTokens" yaml:"ClientTokens"`
+}
+
+// NewStruct creates a new relationship struct
+func (*oauthClientR) NewStruct() *oauthClientR {
+	return &oauthClientR{}
+}
+
+// oauthClientL is where Load methods for each relationship are stored.
+type oauthClientL struct{}
+
+var (
+	oauthClientAllColumns            = []string{"id", "instance_id", "name", "secret", "redirect_uris", "created_at", "role", "scopes"}
+	oauthClientColumnsWithoutDefault = []string{"id", "instance_id", "name", "secret", "redirect_uris", "role"}
+	oauthClientColumnsWithDefault    = []string{"created_at", "scopes"}
+	oauthClientPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// OauthClientSlice is an alias for a slice of pointers to OauthClient.
+	// This should almost always be used instead of []OauthClient.
+	OauthClientSlice []*OauthClient
+	// OauthClientHook is the signature for custom OauthClient hook methods
+	OauthClientHook func(context.Context, boil.ContextExecutor, *OauthClient) error
+
+	oauthClientQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	oauthClientType                 = reflect.TypeOf(&OauthClient{})
+	oauthClientMapping              = queries.MakeStructMapping(oauthClientType)
+	oauthClientPrimaryKeyMapping, _ = queries.BindMapping(oauthClientType, oauthClientMapping, oauthClientPrimaryKeyColumns)
+	oauthClientInsertCacheMut       sync.RWMutex
+	oauthClientInsertCache          = make(map[string]insertCache)
+	oauthClientUpdateCacheMut       sync.RWMutex
+	oauthClientUpdateCache          = make(map[string]updateCache)
+	oauthClientUpsertCacheMut       sync.RWMutex
+	oauthClientUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var oauthClientBeforeInsertHooks []OauthClientHook
+var oauthClientBeforeUpdateHooks []OauthClientHook
+var oauthClientBeforeDeleteHooks []OauthClientHook
+var oauthClientBeforeUpsertHooks []OauthClientHook
+
+var oauthClientAfterInsertHooks []OauthClientHook
+var oauthClientAfterSelectHooks []OauthClientHook
+var oauthClientAfterUpdateHooks []OauthClientHook
+var oauthClientAfterDeleteHooks []OauthClientHook
+var oauthClientAfterUpsertHooks []OauthClientHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *OauthClient) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *OauthClient) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *OauthClient) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *OauthClient) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *OauthClient) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *OauthClient) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *OauthClient) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *OauthClient) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *OauthClient) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oauthClientAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddOauthClientHook registers your hook function for all future operations.
+func AddOauthClientHook(hookPoint boil.HookPoint, oauthClientHook OauthClientHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		oauthClientBeforeInsertHooks = append(oauthClientBeforeInsertHooks, oauthClientHook)
+	case boil.BeforeUpdateHook:
+		oauthClientBeforeUpdateHooks = append(oauthClientBeforeUpdateHooks, oauthClientHook)
+	case boil.BeforeDeleteHook:
+		oauthClientBeforeDeleteHooks = append(oauthClientBeforeDeleteHooks, oauthClientHook)
+	case boil.BeforeUpsertHook:
+		oauthClientBeforeUpsertHooks = append(oauthClientBeforeUpsertHooks, oauthClientHook)
+	case boil.AfterInsertHook:
+		oauthClientAfterInsertHooks = append(oauthClientAfterInsertHooks, oauthClientHook)
+	case boil.AfterSelectHook:
+		oauthClientAfterSelectHooks = append(oauthClientAfterSelectHooks, oauthClientHook)
+	case boil.AfterUpdateHook:
+		oauthClientAfterUpdateHooks = append(oauthClientAfterUpdateHooks, oauthClientHook)
+	case boil.AfterDeleteHook:
+		oauthClientAfterDeleteHooks = append(oauthClientAfterDeleteHooks, oauthClientHook)
+	case boil.AfterUpsertHook:
+		oauthClientAfterUpsertHooks = append(oauthClientAfterUpsertHooks, oauthClientHook)
+	}
+}
+
+// One returns a single oauthClient record from the query.
+func (q oauthClientQuery) One(ctx context.Context, exec boil.ContextExecutor) (*OauthClient, error) {
+	o := &OauthClient{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for oauth_clients")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all OauthClient records from the query.
+func (q oauthClientQuery) All(ctx context.Context, exec boil.ContextExecutor) (OauthClientSlice, error) {
+	var o []*OauthClient
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to OauthClient slice")
+	}
+
+	if len(oauthClientAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all OauthClient records in the query.
+func (q oauthClientQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count oauth_clients rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q oauthClientQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if oauth_clients exists")
+	}
+
+	return count > 0, nil
+}
+
+// Instance pointed to by the foreign key.
+func (o *OauthClient) Instance(mods ...qm.QueryMod) instanceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.InstanceID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Instances(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"instances\"")
+
+	return query
+}
+
+// ClientAuthorizationCodes retrieves all the authorization_code's AuthorizationCodes with an executor via client_id column.
+func (o *OauthClient) ClientAuthorizationCodes(mods ...qm.QueryMod) authorizationCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"authorization_codes\".\"client_id\"=?", o.ID),
+	)
+
+	query := AuthorizationCodes(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"authorization_codes\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"authorization_codes\".*"})
+	}
+
+	return query
+}
+
+// ClientTokens retrieves all the token's Tokens with an executor via client_id column.
+func (o *OauthClient) ClientTokens(mods ...qm.QueryMod) tokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"tokens\".\"client_id\"=?", o.ID),
+	)
+
+	query := Tokens(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"tokens\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"tokens\".*"})
+	}
+
+	return query
+}
+
+// LoadInstance allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (oauthClientL) LoadInstance(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOauthClient interface{}, mods queries.Applicator) error {
+	var slice []*OauthClient
+	var object *OauthClient
+
+	if singular {
+		object = maybeOauthClient.(*OauthClient)
+	} else {
+		slice = *maybeOauthClient.(*[]*OauthClient)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &oauthClientR{}
+		}
+		args = append(args, object.InstanceID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &oauthClientR{}
+			}
+
+			for _, a := range args {
+				if a == obj.InstanceID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.InstanceID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.instances`),
+		qm.WhereIn(`auth.instances.id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.instances.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Instance")
+	}
+
+	var resultSlice []*Instance
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Instance")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for instances")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for instances")
+	}
+
+	if len(oauthClientAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Instance = foreign
+		if foreign.R == nil {
+			foreign.R = &instanceR{}
+		}
+		foreign.R.OauthClients = append(foreign.R.OauthClients, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.InstanceID == foreign.ID {
+				local.R.Instance = foreign
+				if foreign.R == nil {
+					foreign.R = &instanceR{}
+				}
+				foreign.R.OauthClients = append(foreign.R.OauthClients, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadClientAuthorizationCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (oauthClientL) LoadClientAuthorizationCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOauthClient interface{}, mods queries.Applicator) error {
+	var slice []*OauthClient
+	var object *OauthClient
+
+	if singular {
+		object = maybeOauthClient.(*OauthClient)
+	} else {
+		slice = *maybeOauthClient.(*[]*OauthClient)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &oauthClientR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &oauthClientR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.authorization_codes`),
+		qm.WhereIn(`auth.authorization_codes.client_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load authorization_codes")
+	}
+
+	var resultSlice []*AuthorizationCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice authorization_codes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on authorization_codes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for authorization_codes")
+	}
+
+	if len(authorizationCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ClientAuthorizationCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &authorizationCodeR{}
+			}
+			foreign.R.Client = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ClientID {
+				local.R.ClientAuthorizationCodes = append(local.R.ClientAuthorizationCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &authorizationCodeR{}
+				}
+				foreign.R.Client = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadClientTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (oauthClientL) LoadClientTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOauthClient interface{}, mods queries.Applicator) error {
+	var slice []*OauthClient
+	var object *OauthClient
+
+	if singular {
+		object = maybeOauthClient.(*OauthClient)
+	} else {
+		slice = *maybeOauthClient.(*[]*OauthClient)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &oauthClientR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &oauthClientR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.tokens`),
+		qm.WhereIn(`auth.tokens.client_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load tokens")
+	}
+
+	var resultSlice []*Token
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tokens")
+	}
+
+	if len(tokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ClientTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tokenR{}
+			}
+			foreign.R.Client = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ClientID) {
+				local.R.ClientTokens = append(local.R.ClientTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &tokenR{}
+				}
+				foreign.R.Client = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInstance of the oauthClient to the related item.
+// Sets o.R.Instance to related.
+// Adds o to related.R.OauthClients.
+func (o *OauthClient) SetInstance(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Instance) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"oauth_clients\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"instance_id"}),
+		strmangle.WhereClause("\"", "\"", 2, oauthClientPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.InstanceID = related.ID
+	if o.R == nil {
+		o.R = &oauthClientR{
+			Instance: related,
+		}
+	} else {
+		o.R.Instance = related
+	}
+
+	if related.R == nil {
+		related.R = &instanceR{
+			OauthClients: OauthClientSlice{o},
+		}
+	} else {
+		related.R.OauthClients = append(related.R.OauthClients, o)
+	}
+
+	return nil
+}
+
+// AddClientAuthorizationCodes adds the given related objects to the existing relationships
+// of the oauth_client, optionally inserting them as new records.
+// Appends related to o.R.ClientAuthorizationCodes.
+// Sets related.R.Client appropriately.
+func (o *OauthClient) AddClientAuthorizationCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AuthorizationCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ClientID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"authorization_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"client_id"}),
+				strmangle.WhereClause("\"", "\"", 2, authorizationCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.Digest}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ClientID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &oauthClientR{
+			ClientAuthorizationCodes: related,
+		}
+	} else {
+		o.R.ClientAuthorizationCodes = append(o.R.ClientAuthorizationCodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &authorizationCodeR{
+				Client: o,
+			}
+		} else {
+			rel.R.Client = o
+		}
+	}
+	return nil
+}
+
+// AddClientTokens adds the given related objects to the existing relationships
+// of the oauth_client, optionally inserting them as new records.
+// Appends related to o.R.ClientTokens.
+// Sets related.R.Client appropriately.
+func (o *OauthClient) AddClientTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Token) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ClientID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"client_id"}),
+				strmangle.WhereClause("\"", "\"", 2, tokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ClientID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &oauthClientR{
+			ClientTokens: related,
+		}
+	} else {
+		o.R.ClientTokens = append(o.R.ClientTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &tokenR{
+				Client: o,
+			}
+		} else {
+			rel.R.Client = o
+		}
+	}
+	return nil
+}
+
+// SetClientTokens removes all previously related items of the
+// oauth_client replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Client's ClientTokens accordingly.
+// Replaces o.R.ClientTokens with related.
+// Sets related.R.Client's ClientTokens accordingly.
+func (o *OauthClient) SetClientTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Token) error {
+	query := "update \"auth\".\"tokens\" set \"client_id\" = null where \"client_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ClientTokens {
+			queries.SetScanner(&rel.ClientID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Client = nil
+		}
+
+		o.R.ClientTokens = nil
+	}
+	return o.AddClientTokens(ctx, exec, insert, related...)
+}
+
+// RemoveClientTokens relationships from objects passed in.
+// Removes related items from R.ClientTokens (uses pointer comparison, removal does not keep order)
+// Sets related.R.Client.
+func (o *OauthClient) RemoveClientTokens(ctx context.Context, exec boil.ContextExecutor, related ...*Token) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ClientID, nil)
+		if rel.R != nil {
+			rel.R.Client = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("client_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ClientTokens {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ClientTokens)
+			if ln > 1 && i < ln-1 {
+				o.R.ClientTokens[i] = o.R.ClientTokens[ln-1]
+			}
+			o.R.ClientTokens = o.R.ClientTokens[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// OauthClients retrieves all the records using an executor.
+func OauthClients(mods ...qm.QueryMod) oauthClientQuery {
+	mods = append(mods, qm.From("\"auth\".\"oauth_clients\""))
+	return oauthClientQuery{NewQuery(mods...)}
+}
+
+// FindOauthClient retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindOauthClient(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*OauthClient, error) {
+	oauthClientObj := &OauthClient{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"oauth_clients\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, oauthClientObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from oauth_clients")
+	}
+
+	if err = oauthClientObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return oauthClientObj, err
+	}
+
+	return oauthClientObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *OauthClient) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no oauth_clients provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oauthClientColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	oauthClientInsertCacheMut.RLock()
+	cache, cached := oauthClientInsertCache[key]
+	oauthClientInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			oauthClientAllColumns,
+			oauthClientColumnsWithDefault,
+			oauthClientColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(oauthClientType, oauthClientMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(oauthClientType, oauthClientMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"oauth_clients\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"oauth_clients\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into oauth_clients")
+	}
+
+	if !cached {
+		oauthClientInsertCacheMut.Lock()
+		oauthClientInsertCache[key] = cache
+		oauthClientInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the OauthClient.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *OauthClient) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	oauthClientUpdateCacheMut.RLock()
+	cache, cached := oauthClientUpdateCache[key]
+	oauthClientUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			oauthClientAllColumns,
+			oauthClientPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update oauth_clients, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"oauth_clients\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, oauthClientPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(oauthClientType, oauthClientMapping, append(wl, oauthClientPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update oauth_clients row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for oauth_clients")
+	}
+
+	if !cached {
+		oauthClientUpdateCacheMut.Lock()
+		oauthClientUpdateCache[key] = cache
+		oauthClientUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q oauthClientQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for oauth_clients")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for oauth_clients")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o OauthClientSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oauthClientPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"oauth_clients\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, oauthClientPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in oauthClient slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all oauthClient")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *OauthClient) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no oauth_clients provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oauthClientColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	oauthClientUpsertCacheMut.RLock()
+	cache, cached := oauthClientUpsertCache[key]
+	oauthClientUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			oauthClientAllColumns,
+			oauthClientColumnsWithDefault,
+			oauthClientColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			oauthClientAllColumns,
+			oauthClientPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert oauth_clients, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(oauthClientPrimaryKeyColumns))
+			copy(conflict, oauthClientPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"oauth_clients\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(oauthClientType, oauthClientMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(oauthClientType, oauthClientMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert oauth_clients")
+	}
+
+	if !cached {
+		oauthClientUpsertCacheMut.Lock()
+		oauthClientUpsertCache[key] = cache
+		oauthClientUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single OauthClient record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *OauthClient) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no OauthClient provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), oauthClientPrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"oauth_clients\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from oauth_clients")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for oauth_clients")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q oauthClientQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no oauthClientQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from oauth_clients")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for oauth_clients")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o OauthClientSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(oauthClientBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oauthClientPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"oauth_clients\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oauthClientPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from oauthClient slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for oauth_clients")
+	}
+
+	if len(oauthClientAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *OauthClient) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindOauthClient(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OauthClientSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := OauthClientSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oauthClientPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"oauth_clients\".* FROM \"auth\".\"oauth_clients\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oauthClientPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in OauthClientSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// OauthClientExists checks if the OauthClient row exists.
+func OauthClientExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"oauth_clients\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if oauth_clients exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1091 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// OidcState is an object representing the database table.
+type OidcState struct {
+	State      string    `boil:"state" json:"state" toml:"state" yaml:"state"`
+	ProviderID string    `boil:"provider_id" json:"provider_id" toml:"provider_id" yaml:"provider_id"`
+	Nonce      string    `boil:"nonce" json:"nonce" toml:"nonce" yaml:"nonce"`
+	Verifier   string    `boil:"verifier" json:"verifier" toml:"verifier" yaml:"verifier"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ExpiresAt  time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *oidcStateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L oidcStateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var OidcStateColumns = struct {
+	State      string
+	ProviderID string
+	Nonce      string
+	Verifier   string
+	CreatedAt  string
+	ExpiresAt  string
+}{
+	State:      "state",
+	ProviderID: "provider_id",
+	Nonce:      "nonce",
+	Verifier:   "verifier",
+	CreatedAt:  "created_at",
+	ExpiresAt:  "expires_at",
+}
+
+var OidcStateTableColumns = struct {
+	State      string
+	ProviderID string
+	Nonce      string
+	Verifier   string
+	CreatedAt  string
+	ExpiresAt  string
+}{
+	State:      "oidc_states.state",
+	ProviderID: "oidc_states.provider_id",
+	Nonce:      "oidc_states.nonce",
+	Verifier:   "oidc_states.verifier",
+	CreatedAt:  "oidc_states.created_at",
+	ExpiresAt:  "oidc_states.expires_at",
+}
+
+// Generated where
+
+var OidcStateWhere = struct {
+	State      whereHelperstring
+	ProviderID whereHelperstring
+	Nonce      whereHelperstring
+	Verifier   whereHelperstring
+	CreatedAt  whereHelpertime_Time
+	ExpiresAt  whereHelpertime_Time
+}{
+	State:      whereHelperstring{field: "\"auth\".\"oidc_states\".\"state\""},
+	ProviderID: whereHelperstring{field: "\"auth\".\"oidc_states\".\"provider_id\""},
+	Nonce:      whereHelperstring{field: "\"auth\".\"oidc_states\".\"nonce\""},
+	Verifier:   whereHelperstring{field: "\"auth\".\"oidc_states\".\"verifier\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"auth\".\"oidc_states\".\"created_at\""},
+	ExpiresAt:  whereHelpertime_Time{field: "\"auth\".\"oidc_states\".\"expires_at\""},
+}
+
+// OidcStateRels is where relationship names are stored.
+var OidcStateRels = struct {
+	Provider string
+}{
+	Provider: "Provider",
+}
+
+// oidcStateR is where relationships are stored.
+type oidcStateR struct {
+	Provider *IdentityProvider `boil:"Provider" json:"Provider" toml:"Provider" yaml:"Provider"`
+}
+
+// NewStruct creates a new relationship struct
+func (*oidcStateR) NewStruct() *oidcStateR {
+	return &oidcStateR{}
+}
+
+// oidcStateL is where Load methods for each relationship are stored.
+type oidcStateL struct{}
+
+var (
+	oidcStateAllColumns            = []string{"state", "provider_id", "nonce", "verifier", "created_at", "expires_at"}
+	oidcStateColumnsWithoutDefault = []string{"state", "provider_id", "nonce", "verifier", "expires_at"}
+	oidcStateColumnsWithDefault    = []string{"created_at"}
+	oidcStatePrimaryKeyColumns     = []string{"state"}
+)
+
+type (
+	// OidcStateSlice is an alias for a slice of pointers to OidcState.
+	// This should almost always be used instead of []OidcState.
+	OidcStateSlice []*OidcState
+	// OidcStateHook is the signature for custom OidcState hook methods
+	OidcStateHook func(context.Context, boil.ContextExecutor, *OidcState) error
+
+	oidcStateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	oidcStateType                 = reflect.TypeOf(&OidcState{})
+	oidcStateMapping              = queries.MakeStructMapping(oidcStateType)
+	oidcStatePrimaryKeyMapping, _ = queries.BindMapping(oidcStateType, oidcStateMapping, oidcStatePrimaryKeyColumns)
+	oidcStateInsertCacheMut       sync.RWMutex
+	oidcStateInsertCache          = make(map[string]insertCache)
+	oidcStateUpdateCacheMut       sync.RWMutex
+	oidcStateUpdateCache          = make(map[string]updateCache)
+	oidcStateUpsertCacheMut       sync.RWMutex
+	oidcStateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var oidcStateBeforeInsertHooks []OidcStateHook
+var oidcStateBeforeUpdateHooks []OidcStateHook
+var oidcStateBeforeDeleteHooks []OidcStateHook
+var oidcStateBeforeUpsertHooks []OidcStateHook
+
+var oidcStateAfterInsertHooks []OidcStateHook
+var oidcStateAfterSelectHooks []OidcStateHook
+var oidcStateAfterUpdateHooks []OidcStateHook
+var oidcStateAfterDeleteHooks []OidcStateHook
+var oidcStateAfterUpsertHooks []OidcStateHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *OidcState) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *OidcState) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *OidcState) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *OidcState) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *OidcState) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *OidcState) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *OidcState) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *OidcState) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *OidcState) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range oidcStateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddOidcStateHook registers your hook function for all future operations.
+func AddOidcStateHook(hookPoint boil.HookPoint, oidcStateHook OidcStateHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		oidcStateBeforeInsertHooks = append(oidcStateBeforeInsertHooks, oidcStateHook)
+	case boil.BeforeUpdateHook:
+		oidcStateBeforeUpdateHooks = append(oidcStateBeforeUpdateHooks, oidcStateHook)
+	case boil.BeforeDeleteHook:
+		oidcStateBeforeDeleteHooks = append(oidcStateBeforeDeleteHooks, oidcStateHook)
+	case boil.BeforeUpsertHook:
+		oidcStateBeforeUpsertHooks = append(oidcStateBeforeUpsertHooks, oidcStateHook)
+	case boil.AfterInsertHook:
+		oidcStateAfterInsertHooks = append(oidcStateAfterInsertHooks, oidcStateHook)
+	case boil.AfterSelectHook:
+		oidcStateAfterSelectHooks = append(oidcStateAfterSelectHooks, oidcStateHook)
+	case boil.AfterUpdateHook:
+		oidcStateAfterUpdateHooks = append(oidcStateAfterUpdateHooks, oidcStateHook)
+	case boil.AfterDeleteHook:
+		oidcStateAfterDeleteHooks = append(oidcStateAfterDeleteHooks, oidcStateHook)
+	case boil.AfterUpsertHook:
+		oidcStateAfterUpsertHooks = append(oidcStateAfterUpsertHooks, oidcStateHook)
+	}
+}
+
+// One returns a single oidcState record from the query.
+func (q oidcStateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*OidcState, error) {
+	o := &OidcState{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for oidc_states")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all OidcState records from the query.
+func (q oidcStateQuery) All(ctx context.Context, exec boil.ContextExecutor) (OidcStateSlice, error) {
+	var o []*OidcState
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to OidcState slice")
+	}
+
+	if len(oidcStateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all OidcState records in the query.
+func (q oidcStateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count oidc_states rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q oidcStateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if oidc_states exists")
+	}
+
+	return count > 0, nil
+}
+
+// Provider pointed to by the foreign key.
+func (o *OidcState) Provider(mods ...qm.QueryMod) identityProviderQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ProviderID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := IdentityProviders(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"identity_providers\"")
+
+	return query
+}
+
+// LoadProvider allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (oidcStateL) LoadProvider(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOidcState interface{}, mods queries.Applicator) error {
+	var slice []*OidcState
+	var object *OidcState
+
+	if singular {
+		object = maybeOidcState.(*OidcState)
+	} else {
+		slice = *maybeOidcState.(*[]*OidcState)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &oidcStateR{}
+		}
+		args = append(args, object.ProviderID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &oidcStateR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ProviderID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ProviderID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.identity_providers`),
+		qm.WhereIn(`auth.identity_providers.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load IdentityProvider")
+	}
+
+	var resultSlice []*IdentityProvider
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice IdentityProvider")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for identity_providers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for identity_providers")
+	}
+
+	if len(oidcStateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Provider = foreign
+		if foreign.R == nil {
+			foreign.R = &identityProviderR{}
+		}
+		foreign.R.ProviderOidcStates = append(foreign.R.ProviderOidcStates, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ProviderID == foreign.ID {
+				local.R.Provider = foreign
+				if foreign.R == nil {
+					foreign.R = &identityProviderR{}
+				}
+				foreign.R.ProviderOidcStates = append(foreign.R.ProviderOidcStates, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetProvider of the oidcState to the related item.
+// Sets o.R.Provider to related.
+// Adds o to related.R.ProviderOidcStates.
+func (o *OidcState) SetProvider(ctx context.Context, exec boil.ContextExecutor, insert bool, related *IdentityProvider) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"oidc_states\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"provider_id"}),
+		strmangle.WhereClause("\"", "\"", 2, oidcStatePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.State}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ProviderID = related.ID
+	if o.R == nil {
+		o.R = &oidcStateR{
+			Provider: related,
+		}
+	} else {
+		o.R.Provider = related
+	}
+
+	if related.R == nil {
+		related.R = &identityProviderR{
+			ProviderOidcStates: OidcStateSlice{o},
+		}
+	} else {
+		related.R.ProviderOidcStates = append(related.R.ProviderOidcStates, o)
+	}
+
+	return nil
+}
+
+// OidcStates retrieves all the records using an executor.
+func OidcStates(mods ...qm.QueryMod) oidcStateQuery {
+	mods = append(mods, qm.From("\"auth\".\"oidc_states\""))
+	return oidcStateQuery{NewQuery(mods...)}
+}
+
+// FindOidcState retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindOidcState(ctx context.Context, exec boil.ContextExecutor, state string, selectCols ...string) (*OidcState, error) {
+	oidcStateObj := &OidcState{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"oidc_states\" where \"state\"=$1", sel,
+	)
+
+	q := queries.Raw(query, state)
+
+	err := q.Bind(ctx, exec, oidcStateObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from oidc_states")
+	}
+
+	if err = oidcStateObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return oidcStateObj, err
+	}
+
+	return oidcStateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *OidcState) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no oidc_states provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oidcStateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	oidcStateInsertCacheMut.RLock()
+	cache, cached := oidcStateInsertCache[key]
+	oidcStateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			oidcStateAllColumns,
+			oidcStateColumnsWithDefault,
+			oidcStateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(oidcStateType, oidcStateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(oidcStateType, oidcStateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"oidc_states\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"oidc_states\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into oidc_states")
+	}
+
+	if !cached {
+		oidcStateInsertCacheMut.Lock()
+		oidcStateInsertCache[key] = cache
+		oidcStateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the OidcState.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *OidcState) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	oidcStateUpdateCacheMut.RLock()
+	cache, cached := oidcStateUpdateCache[key]
+	oidcStateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			oidcStateAllColumns,
+			oidcStatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update oidc_states, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"oidc_states\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, oidcStatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(oidcStateType, oidcStateMapping, append(wl, oidcStatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update oidc_states row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for oidc_states")
+	}
+
+	if !cached {
+		oidcStateUpdateCacheMut.Lock()
+		oidcStateUpdateCache[key] = cache
+		oidcStateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q oidcStateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for oidc_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for oidc_states")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o OidcStateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"oidc_states\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, oidcStatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in oidcState slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all oidcState")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *OidcState) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no oidc_states provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(oidcStateColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	oidcStateUpsertCacheMut.RLock()
+	cache, cached := oidcStateUpsertCache[key]
+	oidcStateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			oidcStateAllColumns,
+			oidcStateColumnsWithDefault,
+			oidcStateColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			oidcStateAllColumns,
+			oidcStatePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert oidc_states, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(oidcStatePrimaryKeyColumns))
+			copy(conflict, oidcStatePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"oidc_states\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(oidcStateType, oidcStateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(oidcStateType, oidcStateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert oidc_states")
+	}
+
+	if !cached {
+		oidcStateUpsertCacheMut.Lock()
+		oidcStateUpsertCache[key] = cache
+		oidcStateUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single OidcState record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *OidcState) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no OidcState provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), oidcStatePrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"oidc_states\" WHERE \"state\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from oidc_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for oidc_states")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q oidcStateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no oidcStateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from oidc_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for oidc_states")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o OidcStateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(oidcStateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"oidc_states\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oidcStatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from oidcState slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for oidc_states")
+	}
+
+	if len(oidcStateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *OidcState) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindOidcState(ctx, exec, o.State)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *OidcStateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := OidcStateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), oidcStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"oidc_states\".* FROM \"auth\".\"oidc_states\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, oidcStatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in OidcStateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// OidcStateExists checks if the OidcState row exists.
+func OidcStateExists(ctx context.Context, exec boil.ContextExecutor, state string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"oidc_states\" where \"state\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, state)
+	}
+	row := exec.QueryRowContext(ctx, sql, state)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if oidc_states exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,35 @@
+package dbmodels
+
+import (
+	"github.com/smartnuance/saas-kit/pkg/lib/paging"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Page loads a page of rows ordered by the xid column, which orders them by creation.
+// The rows of a previous page are loaded in reverse order, so they have to be reversed after loading.
+func (w whereHelperstring) Page(page paging.Page) qm.QueryMod {
+	switch spec := page.(type) {
+	case *paging.Paging_First:
+		return all(qm.OrderBy(w.field), qm.Limit(page.Size()))
+	case *paging.Paging_Previous:
+		// the rows right before end are the last ones in reverse order
+		return all(w.LT(spec.End), qm.OrderBy(w.field+" DESC"), qm.Limit(page.Size()))
+	case *paging.Paging_Current:
+		return all(w.GTE(spec.Start), w.LTE(spec.End), qm.OrderBy(w.field), qm.Limit(page.Size()))
+	case *paging.Paging_Next:
+		return all(w.GT(spec.Start), qm.OrderBy(w.field), qm.Limit(page.Size()))
+	default:
+		// identity function not modifying query
+		return qm.QueryModFunc(func(q *queries.Query) {})
+	}
+}
+
+// all applies the mods together.
+func all(mods ...qm.QueryMod) qm.QueryMod {
+	return qm.QueryModFunc(func(q *queries.Query) {
+		for _, mod := range mods {
+			mod.Apply(q)
+		}
+	})
+}
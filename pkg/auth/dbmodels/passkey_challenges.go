@@ -0,0 +1,1124 @@
+// Code generated by SQLBoiler 4.8.3 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package dbmodels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// PasskeyChallenge is an object representing the database table.
+type PasskeyChallenge struct {
+	ID        int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserID    null.String `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	Challenge []byte      `boil:"challenge" json:"challenge" toml:"challenge" yaml:"challenge"`
+	CreatedAt time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ExpiresAt time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+
+	R *passkeyChallengeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L passkeyChallengeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PasskeyChallengeColumns = struct {
+	ID        string
+	UserID    string
+	Challenge string
+	CreatedAt string
+	ExpiresAt string
+}{
+	ID:        "id",
+	UserID:    "user_id",
+	Challenge: "challenge",
+	CreatedAt: "created_at",
+	ExpiresAt: "expires_at",
+}
+
+var PasskeyChallengeTableColumns = struct {
+	ID        string
+	UserID    string
+	Challenge string
+	CreatedAt string
+	ExpiresAt string
+}{
+	ID:        "passkey_challenges.id",
+	UserID:    "passkey_challenges.user_id",
+	Challenge: "passkey_challenges.challenge",
+	CreatedAt: "passkey_challenges.created_at",
+	ExpiresAt: "passkey_challenges.expires_at",
+}
+
+// Generated where
+
+var PasskeyChallengeWhere = struct {
+	ID        whereHelperint64
+	UserID    whereHelpernull_String
+	Challenge whereHelper__byte
+	CreatedAt whereHelpertime_Time
+	ExpiresAt whereHelpertime_Time
+}{
+	ID:        whereHelperint64{field: "\"auth\".\"passkey_challenges\".\"id\""},
+	UserID:    whereHelpernull_String{field: "\"auth\".\"passkey_challenges\".\"user_id\""},
+	Challenge: whereHelper__byte{field: "\"auth\".\"passkey_challenges\".\"challenge\""},
+	CreatedAt: whereHelpertime_Time{field: "\"auth\".\"passkey_challenges\".\"created_at\""},
+	ExpiresAt: whereHelpertime_Time{field: "\"auth\".\"passkey_challenges\".\"expires_at\""},
+}
+
+// PasskeyChallengeRels is where relationship names are stored.
+var PasskeyChallengeRels = struct {
+	User string
+}{
+	User: "User",
+}
+
+// passkeyChallengeR is where relationships are stored.
+type passkeyChallengeR struct {
+	User *User `boil:"User" json:"User" toml:"User" yaml:"User"`
+}
+
+// NewStruct creates a new relationship struct
+func (*passkeyChallengeR) NewStruct() *passkeyChallengeR {
+	return &passkeyChallengeR{}
+}
+
+// passkeyChallengeL is where Load methods for each relationship are stored.
+type passkeyChallengeL struct{}
+
+var (
+	passkeyChallengeAllColumns            = []string{"id", "user_id", "challenge", "created_at", "expires_at"}
+	passkeyChallengeColumnsWithoutDefault = []string{"user_id", "challenge", "expires_at"}
+	passkeyChallengeColumnsWithDefault    = []string{"id", "created_at"}
+	passkeyChallengePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// PasskeyChallengeSlice is an alias for a slice of pointers to PasskeyChallenge.
+	// This should almost always be used instead of []PasskeyChallenge.
+	PasskeyChallengeSlice []*PasskeyChallenge
+	// PasskeyChallengeHook is the signature for custom PasskeyChallenge hook methods
+	PasskeyChallengeHook func(context.Context, boil.ContextExecutor, *PasskeyChallenge) error
+
+	passkeyChallengeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	passkeyChallengeType                 = reflect.TypeOf(&PasskeyChallenge{})
+	passkeyChallengeMapping              = queries.MakeStructMapping(passkeyChallengeType)
+	passkeyChallengePrimaryKeyMapping, _ = queries.BindMapping(passkeyChallengeType, passkeyChallengeMapping, passkeyChallengePrimaryKeyColumns)
+	passkeyChallengeInsertCacheMut       sync.RWMutex
+	passkeyChallengeInsertCache          = make(map[string]insertCache)
+	passkeyChallengeUpdateCacheMut       sync.RWMutex
+	passkeyChallengeUpdateCache          = make(map[string]updateCache)
+	passkeyChallengeUpsertCacheMut       sync.RWMutex
+	passkeyChallengeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var passkeyChallengeBeforeInsertHooks []PasskeyChallengeHook
+var passkeyChallengeBeforeUpdateHooks []PasskeyChallengeHook
+var passkeyChallengeBeforeDeleteHooks []PasskeyChallengeHook
+var passkeyChallengeBeforeUpsertHooks []PasskeyChallengeHook
+
+var passkeyChallengeAfterInsertHooks []PasskeyChallengeHook
+var passkeyChallengeAfterSelectHooks []PasskeyChallengeHook
+var passkeyChallengeAfterUpdateHooks []PasskeyChallengeHook
+var passkeyChallengeAfterDeleteHooks []PasskeyChallengeHook
+var passkeyChallengeAfterUpsertHooks []PasskeyChallengeHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PasskeyChallenge) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PasskeyChallenge) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PasskeyChallenge) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PasskeyChallenge) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PasskeyChallenge) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PasskeyChallenge) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PasskeyChallenge) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PasskeyChallenge) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PasskeyChallenge) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range passkeyChallengeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPasskeyChallengeHook registers your hook function for all future operations.
+func AddPasskeyChallengeHook(hookPoint boil.HookPoint, passkeyChallengeHook PasskeyChallengeHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		passkeyChallengeBeforeInsertHooks = append(passkeyChallengeBeforeInsertHooks, passkeyChallengeHook)
+	case boil.BeforeUpdateHook:
+		passkeyChallengeBeforeUpdateHooks = append(passkeyChallengeBeforeUpdateHooks, passkeyChallengeHook)
+	case boil.BeforeDeleteHook:
+		passkeyChallengeBeforeDeleteHooks = append(passkeyChallengeBeforeDeleteHooks, passkeyChallengeHook)
+	case boil.BeforeUpsertHook:
+		passkeyChallengeBeforeUpsertHooks = append(passkeyChallengeBeforeUpsertHooks, passkeyChallengeHook)
+	case boil.AfterInsertHook:
+		passkeyChallengeAfterInsertHooks = append(passkeyChallengeAfterInsertHooks, passkeyChallengeHook)
+	case boil.AfterSelectHook:
+		passkeyChallengeAfterSelectHooks = append(passkeyChallengeAfterSelectHooks, passkeyChallengeHook)
+	case boil.AfterUpdateHook:
+		passkeyChallengeAfterUpdateHooks = append(passkeyChallengeAfterUpdateHooks, passkeyChallengeHook)
+	case boil.AfterDeleteHook:
+		passkeyChallengeAfterDeleteHooks = append(passkeyChallengeAfterDeleteHooks, passkeyChallengeHook)
+	case boil.AfterUpsertHook:
+		passkeyChallengeAfterUpsertHooks = append(passkeyChallengeAfterUpsertHooks, passkeyChallengeHook)
+	}
+}
+
+// One returns a single passkeyChallenge record from the query.
+func (q passkeyChallengeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PasskeyChallenge, error) {
+	o := &PasskeyChallenge{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: failed to execute a one query for passkey_challenges")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PasskeyChallenge records from the query.
+func (q passkeyChallengeQuery) All(ctx context.Context, exec boil.ContextExecutor) (PasskeyChallengeSlice, error) {
+	var o []*PasskeyChallenge
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "dbmodels: failed to assign all query results to PasskeyChallenge slice")
+	}
+
+	if len(passkeyChallengeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PasskeyChallenge records in the query.
+func (q passkeyChallengeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to count passkey_challenges rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q passkeyChallengeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: failed to check if passkey_challenges exists")
+	}
+
+	return count > 0, nil
+}
+
+// User pointed to by the foreign key.
+func (o *PasskeyChallenge) User(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.UserID),
+		qmhelper.WhereIsNull("deleted_at"),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"users\"")
+
+	return query
+}
+
+// LoadUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (passkeyChallengeL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybePasskeyChallenge interface{}, mods queries.Applicator) error {
+	var slice []*PasskeyChallenge
+	var object *PasskeyChallenge
+
+	if singular {
+		object = maybePasskeyChallenge.(*PasskeyChallenge)
+	} else {
+		slice = *maybePasskeyChallenge.(*[]*PasskeyChallenge)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &passkeyChallengeR{}
+		}
+		if !queries.IsNil(object.UserID) {
+			args = append(args, object.UserID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &passkeyChallengeR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.UserID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.UserID) {
+				args = append(args, obj.UserID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.users`),
+		qm.WhereIn(`auth.users.id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.users.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(passkeyChallengeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.User = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.PasskeyChallenges = append(foreign.R.PasskeyChallenges, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.UserID, foreign.ID) {
+				local.R.User = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.PasskeyChallenges = append(foreign.R.PasskeyChallenges, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetUser of the passkeyChallenge to the related item.
+// Sets o.R.User to related.
+// Adds o to related.R.PasskeyChallenges.
+func (o *PasskeyChallenge) SetUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"passkey_challenges\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+		strmangle.WhereClause("\"", "\"", 2, passkeyChallengePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.UserID, related.ID)
+	if o.R == nil {
+		o.R = &passkeyChallengeR{
+			User: related,
+		}
+	} else {
+		o.R.User = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			PasskeyChallenges: PasskeyChallengeSlice{o},
+		}
+	} else {
+		related.R.PasskeyChallenges = append(related.R.PasskeyChallenges, o)
+	}
+
+	return nil
+}
+
+// RemoveUser relationship.
+// Sets o.R.User to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *PasskeyChallenge) RemoveUser(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.UserID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("user_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.User = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.PasskeyChallenges {
+		if queries.Equal(o.UserID, ri.UserID) {
+			continue
+		}
+
+		ln := len(related.R.PasskeyChallenges)
+		if ln > 1 && i < ln-1 {
+			related.R.PasskeyChallenges[i] = related.R.PasskeyChallenges[ln-1]
+		}
+		related.R.PasskeyChallenges = related.R.PasskeyChallenges[:ln-1]
+		break
+	}
+	return nil
+}
+
+// PasskeyChallenges retrieves all the records using an executor.
+func PasskeyChallenges(mods ...qm.QueryMod) passkeyChallengeQuery {
+	mods = append(mods, qm.From("\"auth\".\"passkey_challenges\""))
+	return passkeyChallengeQuery{NewQuery(mods...)}
+}
+
+// FindPasskeyChallenge retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPasskeyChallenge(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*PasskeyChallenge, error) {
+	passkeyChallengeObj := &PasskeyChallenge{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"auth\".\"passkey_challenges\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, passkeyChallengeObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "dbmodels: unable to select from passkey_challenges")
+	}
+
+	if err = passkeyChallengeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return passkeyChallengeObj, err
+	}
+
+	return passkeyChallengeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PasskeyChallenge) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no passkey_challenges provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(passkeyChallengeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	passkeyChallengeInsertCacheMut.RLock()
+	cache, cached := passkeyChallengeInsertCache[key]
+	passkeyChallengeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			passkeyChallengeAllColumns,
+			passkeyChallengeColumnsWithDefault,
+			passkeyChallengeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(passkeyChallengeType, passkeyChallengeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(passkeyChallengeType, passkeyChallengeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"auth\".\"passkey_challenges\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"auth\".\"passkey_challenges\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to insert into passkey_challenges")
+	}
+
+	if !cached {
+		passkeyChallengeInsertCacheMut.Lock()
+		passkeyChallengeInsertCache[key] = cache
+		passkeyChallengeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PasskeyChallenge.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PasskeyChallenge) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	passkeyChallengeUpdateCacheMut.RLock()
+	cache, cached := passkeyChallengeUpdateCache[key]
+	passkeyChallengeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			passkeyChallengeAllColumns,
+			passkeyChallengePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("dbmodels: unable to update passkey_challenges, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"auth\".\"passkey_challenges\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, passkeyChallengePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(passkeyChallengeType, passkeyChallengeMapping, append(wl, passkeyChallengePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update passkey_challenges row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by update for passkey_challenges")
+	}
+
+	if !cached {
+		passkeyChallengeUpdateCacheMut.Lock()
+		passkeyChallengeUpdateCache[key] = cache
+		passkeyChallengeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q passkeyChallengeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all for passkey_challenges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected for passkey_challenges")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PasskeyChallengeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("dbmodels: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), passkeyChallengePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"auth\".\"passkey_challenges\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, passkeyChallengePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to update all in passkeyChallenge slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to retrieve rows affected all in update all passkeyChallenge")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *PasskeyChallenge) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("dbmodels: no passkey_challenges provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(passkeyChallengeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	passkeyChallengeUpsertCacheMut.RLock()
+	cache, cached := passkeyChallengeUpsertCache[key]
+	passkeyChallengeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			passkeyChallengeAllColumns,
+			passkeyChallengeColumnsWithDefault,
+			passkeyChallengeColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			passkeyChallengeAllColumns,
+			passkeyChallengePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("dbmodels: unable to upsert passkey_challenges, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(passkeyChallengePrimaryKeyColumns))
+			copy(conflict, passkeyChallengePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"auth\".\"passkey_challenges\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(passkeyChallengeType, passkeyChallengeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(passkeyChallengeType, passkeyChallengeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to upsert passkey_challenges")
+	}
+
+	if !cached {
+		passkeyChallengeUpsertCacheMut.Lock()
+		passkeyChallengeUpsertCache[key] = cache
+		passkeyChallengeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single PasskeyChallenge record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PasskeyChallenge) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("dbmodels: no PasskeyChallenge provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), passkeyChallengePrimaryKeyMapping)
+	sql := "DELETE FROM \"auth\".\"passkey_challenges\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete from passkey_challenges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by delete for passkey_challenges")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q passkeyChallengeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("dbmodels: no passkeyChallengeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from passkey_challenges")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for passkey_challenges")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PasskeyChallengeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(passkeyChallengeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), passkeyChallengePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"auth\".\"passkey_challenges\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, passkeyChallengePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: unable to delete all from passkeyChallenge slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "dbmodels: failed to get rows affected by deleteall for passkey_challenges")
+	}
+
+	if len(passkeyChallengeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PasskeyChallenge) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPasskeyChallenge(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PasskeyChallengeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PasskeyChallengeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), passkeyChallengePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"auth\".\"passkey_challenges\".* FROM \"auth\".\"passkey_challenges\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, passkeyChallengePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "dbmodels: unable to reload all in PasskeyChallengeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PasskeyChallengeExists checks if the PasskeyChallenge row exists.
+func PasskeyChallengeExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"auth\".\"passkey_challenges\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "dbmodels: unable to check if passkey_challenges exists")
+	}
+
+	return exists, nil
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,103 +24,176 @@ import (
 
 // Token is an object representing the database table.
 type Token struct {
-	ID        int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
-	UserID    string    `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	ProfileID string    `boil:"profile_id" json:"profile_id" toml:"profile_id" yaml:"profile_id"`
-	Token     string    `boil:"token" json:"token" toml:"token" yaml:"token"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	ExpiresAt time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	ID         int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserID     string      `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	ProfileID  string      `boil:"profile_id" json:"profile_id" toml:"profile_id" yaml:"profile_id"`
+	Token      null.String `boil:"token" json:"token,omitempty" toml:"token" yaml:"token,omitempty"`
+	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ExpiresAt  time.Time   `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
+	Family     string      `boil:"family" json:"family" toml:"family" yaml:"family"`
+	ParentID   null.Int64  `boil:"parent_id" json:"parent_id,omitempty" toml:"parent_id" yaml:"parent_id,omitempty"`
+	ConsumedAt null.Time   `boil:"consumed_at" json:"consumed_at,omitempty" toml:"consumed_at" yaml:"consumed_at,omitempty"`
+	Digest     null.Bytes  `boil:"digest" json:"digest,omitempty" toml:"digest" yaml:"digest,omitempty"`
+	UserAgent  null.String `boil:"user_agent" json:"user_agent,omitempty" toml:"user_agent" yaml:"user_agent,omitempty"`
+	IP         null.String `boil:"ip" json:"ip,omitempty" toml:"ip" yaml:"ip,omitempty"`
+	LoggedInAt time.Time   `boil:"logged_in_at" json:"logged_in_at" toml:"logged_in_at" yaml:"logged_in_at"`
+	AccessJti  null.String `boil:"access_jti" json:"access_jti,omitempty" toml:"access_jti" yaml:"access_jti,omitempty"`
+	ClientID   null.String `boil:"client_id" json:"client_id,omitempty" toml:"client_id" yaml:"client_id,omitempty"`
 
 	R *tokenR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L tokenL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var TokenColumns = struct {
-	ID        string
-	UserID    string
-	ProfileID string
-	Token     string
-	CreatedAt string
-	ExpiresAt string
+	ID         string
+	UserID     string
+	ProfileID  string
+	Token      string
+	CreatedAt  string
+	ExpiresAt  string
+	Family     string
+	ParentID   string
+	ConsumedAt string
+	Digest     string
+	UserAgent  string
+	IP         string
+	LoggedInAt string
+	AccessJti  string
+	ClientID   string
 }{
-	ID:        "id",
-	UserID:    "user_id",
-	ProfileID: "profile_id",
-	Token:     "token",
-	CreatedAt: "created_at",
-	ExpiresAt: "expires_at",
+	ID:         "id",
+	UserID:     "user_id",
+	ProfileID:  "profile_id",
+	Token:      "token",
+	CreatedAt:  "created_at",
+	ExpiresAt:  "expires_at",
+	Family:     "family",
+	ParentID:   "parent_id",
+	ConsumedAt: "consumed_at",
+	Digest:     "digest",
+	UserAgent:  "user_agent",
+	IP:         "ip",
+	LoggedInAt: "logged_in_at",
+	AccessJti:  "access_jti",
+	ClientID:   "client_id",
 }
 
 var TokenTableColumns = struct {
-	ID        string
-	UserID    string
-	ProfileID string
-	Token     string
-	CreatedAt string
-	ExpiresAt string
+	ID         string
+	UserID     string
+	ProfileID  string
+	Token      string
+	CreatedAt  string
+	ExpiresAt  string
+	Family     string
+	ParentID   string
+	ConsumedAt string
+	Digest     string
+	UserAgent  string
+	IP         string
+	LoggedInAt string
+	AccessJti  string
+	ClientID   string
 }{
-	ID:        "tokens.id",
-	UserID:    "tokens.user_id",
-	ProfileID: "tokens.profile_id",
-	Token:     "tokens.token",
-	CreatedAt: "tokens.created_at",
-	ExpiresAt: "tokens.expires_at",
+	ID:         "tokens.id",
+	UserID:     "tokens.user_id",
+	ProfileID:  "tokens.profile_id",
+	Token:      "tokens.token",
+	CreatedAt:  "tokens.created_at",
+	ExpiresAt:  "tokens.expires_at",
+	Family:     "tokens.family",
+	ParentID:   "tokens.parent_id",
+	ConsumedAt: "tokens.consumed_at",
+	Digest:     "tokens.digest",
+	UserAgent:  "tokens.user_agent",
+	IP:         "tokens.ip",
+	LoggedInAt: "tokens.logged_in_at",
+	AccessJti:  "tokens.access_jti",
+	ClientID:   "tokens.client_id",
 }
 
 // Generated where
 
-type whereHelperint64 struct{ field string }
+type whereHelpernull_Int64 struct{ field string }
 
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var TokenWhere = struct {
-	ID        whereHelperint64
-	UserID    whereHelperstring
-	ProfileID whereHelperstring
-	Token     whereHelperstring
-	CreatedAt whereHelpertime_Time
-	ExpiresAt whereHelpertime_Time
+	ID         whereHelperint64
+	UserID     whereHelperstring
+	ProfileID  whereHelperstring
+	Token      whereHelpernull_String
+	CreatedAt  whereHelpertime_Time
+	ExpiresAt  whereHelpertime_Time
+	Family     whereHelperstring
+	ParentID   whereHelpernull_Int64
+	ConsumedAt whereHelpernull_Time
+	Digest     whereHelpernull_Bytes
+	UserAgent  whereHelpernull_String
+	IP         whereHelpernull_String
+	LoggedInAt whereHelpertime_Time
+	AccessJti  whereHelpernull_String
+	ClientID   whereHelpernull_String
 }{
-	ID:        whereHelperint64{field: "\"auth\".\"tokens\".\"id\""},
-	UserID:    whereHelperstring{field: "\"auth\".\"tokens\".\"user_id\""},
-	ProfileID: whereHelperstring{field: "\"auth\".\"tokens\".\"profile_id\""},
-	Token:     whereHelperstring{field: "\"auth\".\"tokens\".\"token\""},
-	CreatedAt: whereHelpertime_Time{field: "\"auth\".\"tokens\".\"created_at\""},
-	ExpiresAt: whereHelpertime_Time{field: "\"auth\".\"tokens\".\"expires_at\""},
+	ID:         whereHelperint64{field: "\"auth\".\"tokens\".\"id\""},
+	UserID:     whereHelperstring{field: "\"auth\".\"tokens\".\"user_id\""},
+	ProfileID:  whereHelperstring{field: "\"auth\".\"tokens\".\"profile_id\""},
+	Token:      whereHelpernull_String{field: "\"auth\".\"tokens\".\"token\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"auth\".\"tokens\".\"created_at\""},
+	ExpiresAt:  whereHelpertime_Time{field: "\"auth\".\"tokens\".\"expires_at\""},
+	Family:     whereHelperstring{field: "\"auth\".\"tokens\".\"family\""},
+	ParentID:   whereHelpernull_Int64{field: "\"auth\".\"tokens\".\"parent_id\""},
+	ConsumedAt: whereHelpernull_Time{field: "\"auth\".\"tokens\".\"consumed_at\""},
+	Digest:     whereHelpernull_Bytes{field: "\"auth\".\"tokens\".\"digest\""},
+	UserAgent:  whereHelpernull_String{field: "\"auth\".\"tokens\".\"user_agent\""},
+	IP:         whereHelpernull_String{field: "\"auth\".\"tokens\".\"ip\""},
+	LoggedInAt: whereHelpertime_Time{field: "\"auth\".\"tokens\".\"logged_in_at\""},
+	AccessJti:  whereHelpernull_String{field: "\"auth\".\"tokens\".\"access_jti\""},
+	ClientID:   whereHelpernull_String{field: "\"auth\".\"tokens\".\"client_id\""},
 }
 
 // TokenRels is where relationship names are stored.
 var TokenRels = struct {
-	Profile string
-	User    string
+	Client       string
+	Parent       string
+	Profile      string
+	User         string
+	ParentTokens string
 }{
-	Profile: "Profile",
-	User:    "User",
+	Client:       "Client",
+	Parent:       "Parent",
+	Profile:      "Profile",
+	User:         "User",
+	ParentTokens: "ParentTokens",
 }
 
 // tokenR is where relationships are stored.
 type tokenR struct {
-	Profile *Profile `boil:"Profile" json:"Profile" toml:"Profile" yaml:"Profile"`
-	User    *User    `boil:"User" json:"User" toml:"User" yaml:"User"`
+	Client       *OauthClient `boil:"Client" json:"Client" toml:"Client" yaml:"Client"`
+	Parent       *Token       `boil:"Parent" json:"Parent" toml:"Parent" yaml:"Parent"`
+	Profile      *Profile     `boil:"Profile" json:"Profile" toml:"Profile" yaml:"Profile"`
+	User         *User        `boil:"User" json:"User" toml:"User" yaml:"User"`
+	ParentTokens TokenSlice   `boil:"ParentTokens" json:"ParentTokens" toml:"ParentTokens" yaml:"ParentTokens"`
 }
 
 // NewStruct creates a new relationship struct
@@ -131,9 +205,9 @@ func (*tokenR) NewStruct() *tokenR {
 type tokenL struct{}
 
 var (
-	tokenAllColumns            = []string{"id", "user_id", "profile_id", "token", "created_at", "expires_at"}
-	tokenColumnsWithoutDefault = []string{"user_id", "profile_id", "token", "expires_at"}
-	tokenColumnsWithDefault    = []string{"id", "created_at"}
+	tokenAllColumns            = []string{"id", "user_id", "profile_id", "token", "created_at", "expires_at", "family", "parent_id", "consumed_at", "digest", "user_agent", "ip", "logged_in_at", "access_jti", "client_id"}
+	tokenColumnsWithoutDefault = []string{"user_id", "profile_id", "token", "expires_at", "family", "parent_id", "consumed_at", "digest", "user_agent", "ip", "access_jti", "client_id"}
+	tokenColumnsWithDefault    = []string{"id", "created_at", "logged_in_at"}
 	tokenPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -412,6 +486,34 @@ func (q tokenQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool
 	return count > 0, nil
 }
 
+// Client pointed to by the foreign key.
+func (o *Token) Client(mods ...qm.QueryMod) oauthClientQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ClientID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := OauthClients(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"oauth_clients\"")
+
+	return query
+}
+
+// Parent pointed to by the foreign key.
+func (o *Token) Parent(mods ...qm.QueryMod) tokenQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ParentID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Tokens(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"tokens\"")
+
+	return query
+}
+
 // Profile pointed to by the foreign key.
 func (o *Token) Profile(mods ...qm.QueryMod) profileQuery {
 	queryMods := []qm.QueryMod{
@@ -442,6 +544,243 @@ func (o *Token) User(mods ...qm.QueryMod) userQuery {
 	return query
 }
 
+// ParentTokens retrieves all the token's Tokens with an executor via parent_id column.
+func (o *Token) ParentTokens(mods ...qm.QueryMod) tokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"tokens\".\"parent_id\"=?", o.ID),
+	)
+
+	query := Tokens(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"tokens\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"tokens\".*"})
+	}
+
+	return query
+}
+
+// LoadClient allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (tokenL) LoadClient(ctx context.Context, e boil.ContextExecutor, singular bool, maybeToken interface{}, mods queries.Applicator) error {
+	var slice []*Token
+	var object *Token
+
+	if singular {
+		object = maybeToken.(*Token)
+	} else {
+		slice = *maybeToken.(*[]*Token)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &tokenR{}
+		}
+		if !queries.IsNil(object.ClientID) {
+			args = append(args, object.ClientID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tokenR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ClientID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.ClientID) {
+				args = append(args, obj.ClientID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.oauth_clients`),
+		qm.WhereIn(`auth.oauth_clients.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load OauthClient")
+	}
+
+	var resultSlice []*OauthClient
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice OauthClient")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for oauth_clients")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oauth_clients")
+	}
+
+	if len(tokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Client = foreign
+		if foreign.R == nil {
+			foreign.R = &oauthClientR{}
+		}
+		foreign.R.ClientTokens = append(foreign.R.ClientTokens, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ClientID, foreign.ID) {
+				local.R.Client = foreign
+				if foreign.R == nil {
+					foreign.R = &oauthClientR{}
+				}
+				foreign.R.ClientTokens = append(foreign.R.ClientTokens, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadParent allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (tokenL) LoadParent(ctx context.Context, e boil.ContextExecutor, singular bool, maybeToken interface{}, mods queries.Applicator) error {
+	var slice []*Token
+	var object *Token
+
+	if singular {
+		object = maybeToken.(*Token)
+	} else {
+		slice = *maybeToken.(*[]*Token)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &tokenR{}
+		}
+		if !queries.IsNil(object.ParentID) {
+			args = append(args, object.ParentID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tokenR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ParentID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.ParentID) {
+				args = append(args, obj.ParentID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.tokens`),
+		qm.WhereIn(`auth.tokens.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Token")
+	}
+
+	var resultSlice []*Token
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Token")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tokens")
+	}
+
+	if len(tokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Parent = foreign
+		if foreign.R == nil {
+			foreign.R = &tokenR{}
+		}
+		foreign.R.ParentTokens = append(foreign.R.ParentTokens, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ParentID, foreign.ID) {
+				local.R.Parent = foreign
+				if foreign.R == nil {
+					foreign.R = &tokenR{}
+				}
+				foreign.R.ParentTokens = append(foreign.R.ParentTokens, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadProfile allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (tokenL) LoadProfile(ctx context.Context, e boil.ContextExecutor, singular bool, maybeToken interface{}, mods queries.Applicator) error {
@@ -652,6 +991,264 @@ func (tokenL) LoadUser(ctx context.Context, e boil.ContextExecutor, singular boo
 	return nil
 }
 
+// LoadParentTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (tokenL) LoadParentTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeToken interface{}, mods queries.Applicator) error {
+	var slice []*Token
+	var object *Token
+
+	if singular {
+		object = maybeToken.(*Token)
+	} else {
+		slice = *maybeToken.(*[]*Token)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &tokenR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tokenR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.tokens`),
+		qm.WhereIn(`auth.tokens.parent_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load tokens")
+	}
+
+	var resultSlice []*Token
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tokens")
+	}
+
+	if len(tokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ParentTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tokenR{}
+			}
+			foreign.R.Parent = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ParentID) {
+				local.R.ParentTokens = append(local.R.ParentTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &tokenR{}
+				}
+				foreign.R.Parent = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetClient of the token to the related item.
+// Sets o.R.Client to related.
+// Adds o to related.R.ClientTokens.
+func (o *Token) SetClient(ctx context.Context, exec boil.ContextExecutor, insert bool, related *OauthClient) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"tokens\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"client_id"}),
+		strmangle.WhereClause("\"", "\"", 2, tokenPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ClientID, related.ID)
+	if o.R == nil {
+		o.R = &tokenR{
+			Client: related,
+		}
+	} else {
+		o.R.Client = related
+	}
+
+	if related.R == nil {
+		related.R = &oauthClientR{
+			ClientTokens: TokenSlice{o},
+		}
+	} else {
+		related.R.ClientTokens = append(related.R.ClientTokens, o)
+	}
+
+	return nil
+}
+
+// RemoveClient relationship.
+// Sets o.R.Client to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Token) RemoveClient(ctx context.Context, exec boil.ContextExecutor, related *OauthClient) error {
+	var err error
+
+	queries.SetScanner(&o.ClientID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("client_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Client = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ClientTokens {
+		if queries.Equal(o.ClientID, ri.ClientID) {
+			continue
+		}
+
+		ln := len(related.R.ClientTokens)
+		if ln > 1 && i < ln-1 {
+			related.R.ClientTokens[i] = related.R.ClientTokens[ln-1]
+		}
+		related.R.ClientTokens = related.R.ClientTokens[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetParent of the token to the related item.
+// Sets o.R.Parent to related.
+// Adds o to related.R.ParentTokens.
+func (o *Token) SetParent(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Token) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"auth\".\"tokens\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"parent_id"}),
+		strmangle.WhereClause("\"", "\"", 2, tokenPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ParentID, related.ID)
+	if o.R == nil {
+		o.R = &tokenR{
+			Parent: related,
+		}
+	} else {
+		o.R.Parent = related
+	}
+
+	if related.R == nil {
+		related.R = &tokenR{
+			ParentTokens: TokenSlice{o},
+		}
+	} else {
+		related.R.ParentTokens = append(related.R.ParentTokens, o)
+	}
+
+	return nil
+}
+
+// RemoveParent relationship.
+// Sets o.R.Parent to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Token) RemoveParent(ctx context.Context, exec boil.ContextExecutor, related *Token) error {
+	var err error
+
+	queries.SetScanner(&o.ParentID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("parent_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Parent = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.ParentTokens {
+		if queries.Equal(o.ParentID, ri.ParentID) {
+			continue
+		}
+
+		ln := len(related.R.ParentTokens)
+		if ln > 1 && i < ln-1 {
+			related.R.ParentTokens[i] = related.R.ParentTokens[ln-1]
+		}
+		related.R.ParentTokens = related.R.ParentTokens[:ln-1]
+		break
+	}
+	return nil
+}
+
 // SetProfile of the token to the related item.
 // Sets o.R.Profile to related.
 // Adds o to related.R.Tokens.
@@ -746,6 +1343,133 @@ func (o *Token) SetUser(ctx context.Context, exec boil.ContextExecutor, insert b
 	return nil
 }
 
+// AddParentTokens adds the given related objects to the existing relationships
+// of the token, optionally inserting them as new records.
+// Appends related to o.R.ParentTokens.
+// Sets related.R.Parent appropriately.
+func (o *Token) AddParentTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Token) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ParentID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"parent_id"}),
+				strmangle.WhereClause("\"", "\"", 2, tokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ParentID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &tokenR{
+			ParentTokens: related,
+		}
+	} else {
+		o.R.ParentTokens = append(o.R.ParentTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &tokenR{
+				Parent: o,
+			}
+		} else {
+			rel.R.Parent = o
+		}
+	}
+	return nil
+}
+
+// SetParentTokens removes all previously related items of the
+// token replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Parent's ParentTokens accordingly.
+// Replaces o.R.ParentTokens with related.
+// Sets related.R.Parent's ParentTokens accordingly.
+func (o *Token) SetParentTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Token) error {
+	query := "update \"auth\".\"tokens\" set \"parent_id\" = null where \"parent_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ParentTokens {
+			queries.SetScanner(&rel.ParentID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Parent = nil
+		}
+
+		o.R.ParentTokens = nil
+	}
+	return o.AddParentTokens(ctx, exec, insert, related...)
+}
+
+// RemoveParentTokens relationships from objects passed in.
+// Removes related items from R.ParentTokens (uses pointer comparison, removal does not keep order)
+// Sets related.R.Parent.
+func (o *Token) RemoveParentTokens(ctx context.Context, exec boil.ContextExecutor, related ...*Token) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ParentID, nil)
+		if rel.R != nil {
+			rel.R.Parent = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("parent_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ParentTokens {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ParentTokens)
+			if ln > 1 && i < ln-1 {
+				o.R.ParentTokens[i] = o.R.ParentTokens[ln-1]
+			}
+			o.R.ParentTokens = o.R.ParentTokens[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // Tokens retrieves all the records using an executor.
 func Tokens(mods ...qm.QueryMod) tokenQuery {
 	mods = append(mods, qm.From("\"auth\".\"tokens\""))
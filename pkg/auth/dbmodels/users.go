@@ -32,6 +32,7 @@ type User struct {
 	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt   time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
 	DeletedAt   null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	ErasedAt    null.Time   `boil:"erased_at" json:"erased_at,omitempty" toml:"erased_at" yaml:"erased_at,omitempty"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -46,6 +47,7 @@ var UserColumns = struct {
 	CreatedAt   string
 	UpdatedAt   string
 	DeletedAt   string
+	ErasedAt    string
 }{
 	ID:          "id",
 	Name:        "name",
@@ -55,6 +57,7 @@ var UserColumns = struct {
 	CreatedAt:   "created_at",
 	UpdatedAt:   "updated_at",
 	DeletedAt:   "deleted_at",
+	ErasedAt:    "erased_at",
 }
 
 var UserTableColumns = struct {
@@ -66,6 +69,7 @@ var UserTableColumns = struct {
 	CreatedAt   string
 	UpdatedAt   string
 	DeletedAt   string
+	ErasedAt    string
 }{
 	ID:          "users.id",
 	Name:        "users.name",
@@ -75,19 +79,11 @@ var UserTableColumns = struct {
 	CreatedAt:   "users.created_at",
 	UpdatedAt:   "users.updated_at",
 	DeletedAt:   "users.deleted_at",
+	ErasedAt:    "users.erased_at",
 }
 
 // Generated where
 
-type whereHelper__byte struct{ field string }
-
-func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
 var UserWhere = struct {
 	ID          whereHelperstring
 	Name        whereHelpernull_String
@@ -97,6 +93,7 @@ var UserWhere = struct {
 	CreatedAt   whereHelpertime_Time
 	UpdatedAt   whereHelpertime_Time
 	DeletedAt   whereHelpernull_Time
+	ErasedAt    whereHelpernull_Time
 }{
 	ID:          whereHelperstring{field: "\"auth\".\"users\".\"id\""},
 	Name:        whereHelpernull_String{field: "\"auth\".\"users\".\"name\""},
@@ -106,21 +103,52 @@ var UserWhere = struct {
 	CreatedAt:   whereHelpertime_Time{field: "\"auth\".\"users\".\"created_at\""},
 	UpdatedAt:   whereHelpertime_Time{field: "\"auth\".\"users\".\"updated_at\""},
 	DeletedAt:   whereHelpernull_Time{field: "\"auth\".\"users\".\"deleted_at\""},
+	ErasedAt:    whereHelpernull_Time{field: "\"auth\".\"users\".\"erased_at\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-	Profiles string
-	Tokens   string
+	APIKeys              string
+	AuthorizationCodes   string
+	Identities           string
+	InvitedByInvitations string
+	PasskeyChallenges    string
+	Passkeys             string
+	PasswordResets       string
+	Profiles             string
+	RecoveryCodes        string
+	Tokens               string
+	TotpSecrets          string
+	Verifications        string
 }{
-	Profiles: "Profiles",
-	Tokens:   "Tokens",
+	APIKeys:              "APIKeys",
+	AuthorizationCodes:   "AuthorizationCodes",
+	Identities:           "Identities",
+	InvitedByInvitations: "InvitedByInvitations",
+	PasskeyChallenges:    "PasskeyChallenges",
+	Passkeys:             "Passkeys",
+	PasswordResets:       "PasswordResets",
+	Profiles:             "Profiles",
+	RecoveryCodes:        "RecoveryCodes",
+	Tokens:               "Tokens",
+	TotpSecrets:          "TotpSecrets",
+	Verifications:        "Verifications",
 }
 
 // userR is where relationships are stored.
 type userR struct {
-	Profiles ProfileSlice `boil:"Profiles" json:"Profiles" toml:"Profiles" yaml:"Profiles"`
-	Tokens   TokenSlice   `boil:"Tokens" json:"Tokens" toml:"Tokens" yaml:"Tokens"`
+	APIKeys              APIKeySlice            `boil:"APIKeys" json:"APIKeys" toml:"APIKeys" yaml:"APIKeys"`
+	AuthorizationCodes   AuthorizationCodeSlice `boil:"AuthorizationCodes" json:"AuthorizationCodes" toml:"AuthorizationCodes" yaml:"AuthorizationCodes"`
+	Identities           IdentitySlice          `boil:"Identities" json:"Identities" toml:"Identities" yaml:"Identities"`
+	InvitedByInvitations InvitationSlice        `boil:"InvitedByInvitations" json:"InvitedByInvitations" toml:"InvitedByInvitations" yaml:"InvitedByInvitations"`
+	PasskeyChallenges    PasskeyChallengeSlice  `boil:"PasskeyChallenges" json:"PasskeyChallenges" toml:"PasskeyChallenges" yaml:"PasskeyChallenges"`
+	Passkeys             PasskeySlice           `boil:"Passkeys" json:"Passkeys" toml:"Passkeys" yaml:"Passkeys"`
+	PasswordResets       PasswordResetSlice     `boil:"PasswordResets" json:"PasswordResets" toml:"PasswordResets" yaml:"PasswordResets"`
+	Profiles             ProfileSlice           `boil:"Profiles" json:"Profiles" toml:"Profiles" yaml:"Profiles"`
+	RecoveryCodes        RecoveryCodeSlice      `boil:"RecoveryCodes" json:"RecoveryCodes" toml:"RecoveryCodes" yaml:"RecoveryCodes"`
+	Tokens               TokenSlice             `boil:"Tokens" json:"Tokens" toml:"Tokens" yaml:"Tokens"`
+	TotpSecrets          TotpSecretSlice        `boil:"TotpSecrets" json:"TotpSecrets" toml:"TotpSecrets" yaml:"TotpSecrets"`
+	Verifications        VerificationSlice      `boil:"Verifications" json:"Verifications" toml:"Verifications" yaml:"Verifications"`
 }
 
 // NewStruct creates a new relationship struct
@@ -132,8 +160,8 @@ func (*userR) NewStruct() *userR {
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "name", "email", "password", "activated_at", "created_at", "updated_at", "deleted_at"}
-	userColumnsWithoutDefault = []string{"id", "name", "email", "password", "activated_at", "deleted_at"}
+	userAllColumns            = []string{"id", "name", "email", "password", "activated_at", "created_at", "updated_at", "deleted_at", "erased_at"}
+	userColumnsWithoutDefault = []string{"id", "name", "email", "password", "activated_at", "deleted_at", "erased_at"}
 	userColumnsWithDefault    = []string{"created_at", "updated_at"}
 	userPrimaryKeyColumns     = []string{"id"}
 )
@@ -413,6 +441,153 @@ func (q userQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool,
 	return count > 0, nil
 }
 
+// APIKeys retrieves all the api_key's APIKeys with an executor.
+func (o *User) APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"api_keys\".\"user_id\"=?", o.ID),
+	)
+
+	query := APIKeys(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"api_keys\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"api_keys\".*"})
+	}
+
+	return query
+}
+
+// AuthorizationCodes retrieves all the authorization_code's AuthorizationCodes with an executor.
+func (o *User) AuthorizationCodes(mods ...qm.QueryMod) authorizationCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"authorization_codes\".\"user_id\"=?", o.ID),
+	)
+
+	query := AuthorizationCodes(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"authorization_codes\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"authorization_codes\".*"})
+	}
+
+	return query
+}
+
+// Identities retrieves all the identity's Identities with an executor.
+func (o *User) Identities(mods ...qm.QueryMod) identityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"identities\".\"user_id\"=?", o.ID),
+	)
+
+	query := Identities(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"identities\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"identities\".*"})
+	}
+
+	return query
+}
+
+// InvitedByInvitations retrieves all the invitation's Invitations with an executor via invited_by column.
+func (o *User) InvitedByInvitations(mods ...qm.QueryMod) invitationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"invitations\".\"invited_by\"=?", o.ID),
+	)
+
+	query := Invitations(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"invitations\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"invitations\".*"})
+	}
+
+	return query
+}
+
+// PasskeyChallenges retrieves all the passkey_challenge's PasskeyChallenges with an executor.
+func (o *User) PasskeyChallenges(mods ...qm.QueryMod) passkeyChallengeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"passkey_challenges\".\"user_id\"=?", o.ID),
+	)
+
+	query := PasskeyChallenges(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"passkey_challenges\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"passkey_challenges\".*"})
+	}
+
+	return query
+}
+
+// Passkeys retrieves all the passkey's Passkeys with an executor.
+func (o *User) Passkeys(mods ...qm.QueryMod) passkeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"passkeys\".\"user_id\"=?", o.ID),
+	)
+
+	query := Passkeys(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"passkeys\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"passkeys\".*"})
+	}
+
+	return query
+}
+
+// PasswordResets retrieves all the password_reset's PasswordResets with an executor.
+func (o *User) PasswordResets(mods ...qm.QueryMod) passwordResetQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"password_resets\".\"user_id\"=?", o.ID),
+	)
+
+	query := PasswordResets(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"password_resets\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"password_resets\".*"})
+	}
+
+	return query
+}
+
 // Profiles retrieves all the profile's Profiles with an executor.
 func (o *User) Profiles(mods ...qm.QueryMod) profileQuery {
 	var queryMods []qm.QueryMod
@@ -435,6 +610,27 @@ func (o *User) Profiles(mods ...qm.QueryMod) profileQuery {
 	return query
 }
 
+// RecoveryCodes retrieves all the recovery_code's RecoveryCodes with an executor.
+func (o *User) RecoveryCodes(mods ...qm.QueryMod) recoveryCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"recovery_codes\".\"user_id\"=?", o.ID),
+	)
+
+	query := RecoveryCodes(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"recovery_codes\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"recovery_codes\".*"})
+	}
+
+	return query
+}
+
 // Tokens retrieves all the token's Tokens with an executor.
 func (o *User) Tokens(mods ...qm.QueryMod) tokenQuery {
 	var queryMods []qm.QueryMod
@@ -456,9 +652,51 @@ func (o *User) Tokens(mods ...qm.QueryMod) tokenQuery {
 	return query
 }
 
-// LoadProfiles allows an eager lookup of values, cached into the
+// TotpSecrets retrieves all the totp_secret's TotpSecrets with an executor.
+func (o *User) TotpSecrets(mods ...qm.QueryMod) totpSecretQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"totp_secrets\".\"user_id\"=?", o.ID),
+	)
+
+	query := TotpSecrets(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"totp_secrets\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"totp_secrets\".*"})
+	}
+
+	return query
+}
+
+// Verifications retrieves all the verification's Verifications with an executor.
+func (o *User) Verifications(mods ...qm.QueryMod) verificationQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"auth\".\"verifications\".\"user_id\"=?", o.ID),
+	)
+
+	query := Verifications(queryMods...)
+	queries.SetFrom(query.Query, "\"auth\".\"verifications\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"auth\".\"verifications\".*"})
+	}
+
+	return query
+}
+
+// LoadAPIKeys allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -496,9 +734,8 @@ func (userL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular
 	}
 
 	query := NewQuery(
-		qm.From(`auth.profiles`),
-		qm.WhereIn(`auth.profiles.user_id in ?`, args...),
-		qmhelper.WhereIsNull(`auth.profiles.deleted_at`),
+		qm.From(`auth.api_keys`),
+		qm.WhereIn(`auth.api_keys.user_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -506,22 +743,22 @@ func (userL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load profiles")
+		return errors.Wrap(err, "failed to eager load api_keys")
 	}
 
-	var resultSlice []*Profile
+	var resultSlice []*APIKey
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice profiles")
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on profiles")
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for profiles")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
 	}
 
-	if len(profileAfterSelectHooks) != 0 {
+	if len(apiKeyAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -529,10 +766,10 @@ func (userL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular
 		}
 	}
 	if singular {
-		object.R.Profiles = resultSlice
+		object.R.APIKeys = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &profileR{}
+				foreign.R = &apiKeyR{}
 			}
 			foreign.R.User = object
 		}
@@ -542,9 +779,9 @@ func (userL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.UserID {
-				local.R.Profiles = append(local.R.Profiles, foreign)
+				local.R.APIKeys = append(local.R.APIKeys, foreign)
 				if foreign.R == nil {
-					foreign.R = &profileR{}
+					foreign.R = &apiKeyR{}
 				}
 				foreign.R.User = local
 				break
@@ -555,9 +792,9 @@ func (userL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
-// LoadTokens allows an eager lookup of values, cached into the
+// LoadAuthorizationCodes allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadAuthorizationCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -595,8 +832,8 @@ func (userL) LoadTokens(ctx context.Context, e boil.ContextExecutor, singular bo
 	}
 
 	query := NewQuery(
-		qm.From(`auth.tokens`),
-		qm.WhereIn(`auth.tokens.user_id in ?`, args...),
+		qm.From(`auth.authorization_codes`),
+		qm.WhereIn(`auth.authorization_codes.user_id in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -604,22 +841,22 @@ func (userL) LoadTokens(ctx context.Context, e boil.ContextExecutor, singular bo
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load tokens")
+		return errors.Wrap(err, "failed to eager load authorization_codes")
 	}
 
-	var resultSlice []*Token
+	var resultSlice []*AuthorizationCode
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice tokens")
+		return errors.Wrap(err, "failed to bind eager loaded slice authorization_codes")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on tokens")
+		return errors.Wrap(err, "failed to close results in eager load on authorization_codes")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tokens")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for authorization_codes")
 	}
 
-	if len(tokenAfterSelectHooks) != 0 {
+	if len(authorizationCodeAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -627,10 +864,10 @@ func (userL) LoadTokens(ctx context.Context, e boil.ContextExecutor, singular bo
 		}
 	}
 	if singular {
-		object.R.Tokens = resultSlice
+		object.R.AuthorizationCodes = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &tokenR{}
+				foreign.R = &authorizationCodeR{}
 			}
 			foreign.R.User = object
 		}
@@ -640,9 +877,9 @@ func (userL) LoadTokens(ctx context.Context, e boil.ContextExecutor, singular bo
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.UserID {
-				local.R.Tokens = append(local.R.Tokens, foreign)
+				local.R.AuthorizationCodes = append(local.R.AuthorizationCodes, foreign)
 				if foreign.R == nil {
-					foreign.R = &tokenR{}
+					foreign.R = &authorizationCodeR{}
 				}
 				foreign.R.User = local
 				break
@@ -653,60 +890,1613 @@ func (userL) LoadTokens(ctx context.Context, e boil.ContextExecutor, singular bo
 	return nil
 }
 
-// AddProfiles adds the given related objects to the existing relationships
-// of the user, optionally inserting them as new records.
-// Appends related to o.R.Profiles.
-// Sets related.R.User appropriately.
-func (o *User) AddProfiles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Profile) error {
-	var err error
-	for _, rel := range related {
-		if insert {
-			rel.UserID = o.ID
-			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
-			}
-		} else {
-			updateQuery := fmt.Sprintf(
-				"UPDATE \"auth\".\"profiles\" SET %s WHERE %s",
-				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
-				strmangle.WhereClause("\"", "\"", 2, profilePrimaryKeyColumns),
-			)
-			values := []interface{}{o.ID, rel.ID}
+// LoadIdentities allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadIdentities(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
 
-			if boil.IsDebug(ctx) {
-				writer := boil.DebugWriterFrom(ctx)
-				fmt.Fprintln(writer, updateQuery)
-				fmt.Fprintln(writer, values)
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
 			}
-			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-				return errors.Wrap(err, "failed to update foreign table")
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
 			}
 
-			rel.UserID = o.ID
+			args = append(args, obj.ID)
 		}
 	}
 
-	if o.R == nil {
-		o.R = &userR{
-			Profiles: related,
-		}
-	} else {
-		o.R.Profiles = append(o.R.Profiles, related...)
+	if len(args) == 0 {
+		return nil
 	}
 
-	for _, rel := range related {
-		if rel.R == nil {
-			rel.R = &profileR{
-				User: o,
-			}
-		} else {
-			rel.R.User = o
-		}
+	query := NewQuery(
+		qm.From(`auth.identities`),
+		qm.WhereIn(`auth.identities.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
 	}
-	return nil
-}
 
-// AddTokens adds the given related objects to the existing relationships
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load identities")
+	}
+
+	var resultSlice []*Identity
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice identities")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on identities")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for identities")
+	}
+
+	if len(identityAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Identities = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &identityR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Identities = append(local.R.Identities, foreign)
+				if foreign.R == nil {
+					foreign.R = &identityR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInvitedByInvitations allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadInvitedByInvitations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.invitations`),
+		qm.WhereIn(`auth.invitations.invited_by in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load invitations")
+	}
+
+	var resultSlice []*Invitation
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice invitations")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on invitations")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for invitations")
+	}
+
+	if len(invitationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.InvitedByInvitations = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &invitationR{}
+			}
+			foreign.R.InvitedByUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.InvitedBy) {
+				local.R.InvitedByInvitations = append(local.R.InvitedByInvitations, foreign)
+				if foreign.R == nil {
+					foreign.R = &invitationR{}
+				}
+				foreign.R.InvitedByUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPasskeyChallenges allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPasskeyChallenges(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.passkey_challenges`),
+		qm.WhereIn(`auth.passkey_challenges.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load passkey_challenges")
+	}
+
+	var resultSlice []*PasskeyChallenge
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice passkey_challenges")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on passkey_challenges")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for passkey_challenges")
+	}
+
+	if len(passkeyChallengeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PasskeyChallenges = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &passkeyChallengeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserID) {
+				local.R.PasskeyChallenges = append(local.R.PasskeyChallenges, foreign)
+				if foreign.R == nil {
+					foreign.R = &passkeyChallengeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPasskeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPasskeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.passkeys`),
+		qm.WhereIn(`auth.passkeys.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load passkeys")
+	}
+
+	var resultSlice []*Passkey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice passkeys")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on passkeys")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for passkeys")
+	}
+
+	if len(passkeyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Passkeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &passkeyR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Passkeys = append(local.R.Passkeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &passkeyR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadPasswordResets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadPasswordResets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.password_resets`),
+		qm.WhereIn(`auth.password_resets.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load password_resets")
+	}
+
+	var resultSlice []*PasswordReset
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice password_resets")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on password_resets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for password_resets")
+	}
+
+	if len(passwordResetAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.PasswordResets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &passwordResetR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.PasswordResets = append(local.R.PasswordResets, foreign)
+				if foreign.R == nil {
+					foreign.R = &passwordResetR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProfiles allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadProfiles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.profiles`),
+		qm.WhereIn(`auth.profiles.user_id in ?`, args...),
+		qmhelper.WhereIsNull(`auth.profiles.deleted_at`),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load profiles")
+	}
+
+	var resultSlice []*Profile
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice profiles")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on profiles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for profiles")
+	}
+
+	if len(profileAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Profiles = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &profileR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Profiles = append(local.R.Profiles, foreign)
+				if foreign.R == nil {
+					foreign.R = &profileR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRecoveryCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadRecoveryCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.recovery_codes`),
+		qm.WhereIn(`auth.recovery_codes.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load recovery_codes")
+	}
+
+	var resultSlice []*RecoveryCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice recovery_codes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on recovery_codes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for recovery_codes")
+	}
+
+	if len(recoveryCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.RecoveryCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &recoveryCodeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.RecoveryCodes = append(local.R.RecoveryCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &recoveryCodeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.tokens`),
+		qm.WhereIn(`auth.tokens.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load tokens")
+	}
+
+	var resultSlice []*Token
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tokens")
+	}
+
+	if len(tokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Tokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Tokens = append(local.R.Tokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &tokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTotpSecrets allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadTotpSecrets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.totp_secrets`),
+		qm.WhereIn(`auth.totp_secrets.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load totp_secrets")
+	}
+
+	var resultSlice []*TotpSecret
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice totp_secrets")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on totp_secrets")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for totp_secrets")
+	}
+
+	if len(totpSecretAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TotpSecrets = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &totpSecretR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.TotpSecrets = append(local.R.TotpSecrets, foreign)
+				if foreign.R == nil {
+					foreign.R = &totpSecretR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVerifications allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadVerifications(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`auth.verifications`),
+		qm.WhereIn(`auth.verifications.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load verifications")
+	}
+
+	var resultSlice []*Verification
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice verifications")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on verifications")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for verifications")
+	}
+
+	if len(verificationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Verifications = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &verificationR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Verifications = append(local.R.Verifications, foreign)
+				if foreign.R == nil {
+					foreign.R = &verificationR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAPIKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.User appropriately.
+func (o *User) AddAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			APIKeys: related,
+		}
+	} else {
+		o.R.APIKeys = append(o.R.APIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddAuthorizationCodes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.AuthorizationCodes.
+// Sets related.R.User appropriately.
+func (o *User) AddAuthorizationCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AuthorizationCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"authorization_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, authorizationCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.Digest}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			AuthorizationCodes: related,
+		}
+	} else {
+		o.R.AuthorizationCodes = append(o.R.AuthorizationCodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &authorizationCodeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddIdentities adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Identities.
+// Sets related.R.User appropriately.
+func (o *User) AddIdentities(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Identity) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"identities\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, identityPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Identities: related,
+		}
+	} else {
+		o.R.Identities = append(o.R.Identities, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &identityR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddInvitedByInvitations adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.InvitedByInvitations.
+// Sets related.R.InvitedByUser appropriately.
+func (o *User) AddInvitedByInvitations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Invitation) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.InvitedBy, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"invitations\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"invited_by"}),
+				strmangle.WhereClause("\"", "\"", 2, invitationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.InvitedBy, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			InvitedByInvitations: related,
+		}
+	} else {
+		o.R.InvitedByInvitations = append(o.R.InvitedByInvitations, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &invitationR{
+				InvitedByUser: o,
+			}
+		} else {
+			rel.R.InvitedByUser = o
+		}
+	}
+	return nil
+}
+
+// SetInvitedByInvitations removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.InvitedByUser's InvitedByInvitations accordingly.
+// Replaces o.R.InvitedByInvitations with related.
+// Sets related.R.InvitedByUser's InvitedByInvitations accordingly.
+func (o *User) SetInvitedByInvitations(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Invitation) error {
+	query := "update \"auth\".\"invitations\" set \"invited_by\" = null where \"invited_by\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.InvitedByInvitations {
+			queries.SetScanner(&rel.InvitedBy, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.InvitedByUser = nil
+		}
+
+		o.R.InvitedByInvitations = nil
+	}
+	return o.AddInvitedByInvitations(ctx, exec, insert, related...)
+}
+
+// RemoveInvitedByInvitations relationships from objects passed in.
+// Removes related items from R.InvitedByInvitations (uses pointer comparison, removal does not keep order)
+// Sets related.R.InvitedByUser.
+func (o *User) RemoveInvitedByInvitations(ctx context.Context, exec boil.ContextExecutor, related ...*Invitation) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.InvitedBy, nil)
+		if rel.R != nil {
+			rel.R.InvitedByUser = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("invited_by")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.InvitedByInvitations {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.InvitedByInvitations)
+			if ln > 1 && i < ln-1 {
+				o.R.InvitedByInvitations[i] = o.R.InvitedByInvitations[ln-1]
+			}
+			o.R.InvitedByInvitations = o.R.InvitedByInvitations[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddPasskeyChallenges adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.PasskeyChallenges.
+// Sets related.R.User appropriately.
+func (o *User) AddPasskeyChallenges(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PasskeyChallenge) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"passkey_challenges\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, passkeyChallengePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			PasskeyChallenges: related,
+		}
+	} else {
+		o.R.PasskeyChallenges = append(o.R.PasskeyChallenges, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &passkeyChallengeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// SetPasskeyChallenges removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.User's PasskeyChallenges accordingly.
+// Replaces o.R.PasskeyChallenges with related.
+// Sets related.R.User's PasskeyChallenges accordingly.
+func (o *User) SetPasskeyChallenges(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PasskeyChallenge) error {
+	query := "update \"auth\".\"passkey_challenges\" set \"user_id\" = null where \"user_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.PasskeyChallenges {
+			queries.SetScanner(&rel.UserID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.User = nil
+		}
+
+		o.R.PasskeyChallenges = nil
+	}
+	return o.AddPasskeyChallenges(ctx, exec, insert, related...)
+}
+
+// RemovePasskeyChallenges relationships from objects passed in.
+// Removes related items from R.PasskeyChallenges (uses pointer comparison, removal does not keep order)
+// Sets related.R.User.
+func (o *User) RemovePasskeyChallenges(ctx context.Context, exec boil.ContextExecutor, related ...*PasskeyChallenge) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserID, nil)
+		if rel.R != nil {
+			rel.R.User = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("user_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.PasskeyChallenges {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.PasskeyChallenges)
+			if ln > 1 && i < ln-1 {
+				o.R.PasskeyChallenges[i] = o.R.PasskeyChallenges[ln-1]
+			}
+			o.R.PasskeyChallenges = o.R.PasskeyChallenges[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddPasskeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Passkeys.
+// Sets related.R.User appropriately.
+func (o *User) AddPasskeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Passkey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"passkeys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, passkeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Passkeys: related,
+		}
+	} else {
+		o.R.Passkeys = append(o.R.Passkeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &passkeyR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddPasswordResets adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.PasswordResets.
+// Sets related.R.User appropriately.
+func (o *User) AddPasswordResets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*PasswordReset) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"password_resets\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, passwordResetPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			PasswordResets: related,
+		}
+	} else {
+		o.R.PasswordResets = append(o.R.PasswordResets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &passwordResetR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddProfiles adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Profiles.
+// Sets related.R.User appropriately.
+func (o *User) AddProfiles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Profile) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"profiles\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, profilePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Profiles: related,
+		}
+	} else {
+		o.R.Profiles = append(o.R.Profiles, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &profileR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddRecoveryCodes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.RecoveryCodes.
+// Sets related.R.User appropriately.
+func (o *User) AddRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RecoveryCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"recovery_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, recoveryCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			RecoveryCodes: related,
+		}
+	} else {
+		o.R.RecoveryCodes = append(o.R.RecoveryCodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &recoveryCodeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddTokens adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
 // Appends related to o.R.Tokens.
 // Sets related.R.User appropriately.
@@ -759,6 +2549,112 @@ func (o *User) AddTokens(ctx context.Context, exec boil.ContextExecutor, insert
 	return nil
 }
 
+// AddTotpSecrets adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.TotpSecrets.
+// Sets related.R.User appropriately.
+func (o *User) AddTotpSecrets(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TotpSecret) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"totp_secrets\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, totpSecretPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.UserID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			TotpSecrets: related,
+		}
+	} else {
+		o.R.TotpSecrets = append(o.R.TotpSecrets, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &totpSecretR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddVerifications adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Verifications.
+// Sets related.R.User appropriately.
+func (o *User) AddVerifications(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Verification) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"auth\".\"verifications\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, verificationPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Verifications: related,
+		}
+	} else {
+		o.R.Verifications = append(o.R.Verifications, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &verificationR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"auth\".\"users\""), qmhelper.WhereIsNull("\"auth\".\"users\".\"deleted_at\""))
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultGCBatchSize limits the number of tokens deleted per statement to keep locks short.
+const DefaultGCBatchSize = 1000
+
+// TokenGC periodically deletes expired refresh tokens, which are never valid again.
+type TokenGC struct {
+	DBAPI DBAPI
+	// Interval is the time between two collections
+	Interval time.Duration
+	// BatchSize is the maximum number of tokens deleted per statement
+	BatchSize int
+	// Now returns the current time; tokens expired before are collected
+	Now func() time.Time
+	// Retention is the time soft-deleted users, profiles and instances are kept before they are purged; nothing is purged if zero
+	Retention time.Duration
+}
+
+func NewTokenGC(dbAPI DBAPI, interval time.Duration) *TokenGC {
+	return &TokenGC{
+		DBAPI:     dbAPI,
+		Interval:  interval,
+		BatchSize: DefaultGCBatchSize,
+		Now:       time.Now,
+	}
+}
+
+// Collect deletes all tokens expired by now in batches and returns the number of deleted tokens.
+// Denylist entries of expired access tokens, expired passkey challenges, OIDC logins, authorization codes, API keys, login attempts and invitations are deleted as well, but not counted.
+// Soft-deleted rows are purged once they are older than the retention.
+func (gc *TokenGC) Collect(ctx context.Context) (deleted int64, err error) {
+	before := gc.Now()
+	for {
+		var n int64
+		n, err = gc.DBAPI.DeleteExpiredTokens(ctx, before, gc.BatchSize)
+		deleted += n
+		if err != nil {
+			return
+		}
+		if n < int64(gc.BatchSize) {
+			break
+		}
+	}
+
+	_, err = gc.DBAPI.DeleteExpiredDenials(ctx, before)
+	if err != nil {
+		return
+	}
+	_, err = gc.DBAPI.DeleteExpiredPasskeyChallenges(ctx, before)
+	if err != nil {
+		return
+	}
+	_, err = gc.DBAPI.DeleteExpiredOIDCStates(ctx, before)
+	if err != nil {
+		return
+	}
+	_, err = gc.DBAPI.DeleteExpiredAuthorizationCodes(ctx, before)
+	if err != nil {
+		return
+	}
+	_, err = gc.DBAPI.DeleteExpiredAPIKeys(ctx, before)
+	if err != nil {
+		return
+	}
+	_, err = gc.DBAPI.DeleteExpiredLoginAttempts(ctx, before)
+	if err != nil {
+		return
+	}
+	_, err = gc.DBAPI.DeleteExpiredInvitations(ctx, before)
+	if err != nil {
+		return
+	}
+	if gc.Retention > 0 {
+		_, err = gc.DBAPI.PurgeDeleted(ctx, before.Add(-gc.Retention))
+	}
+	return
+}
+
+// Run collects expired tokens periodically until ctx is done.
+func (gc *TokenGC) Run(ctx context.Context) error {
+	ticker := time.NewTicker(gc.Interval)
+	defer ticker.Stop()
+	for {
+		deleted, err := gc.Collect(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Int64("deleted", deleted).Msg("collecting expired tokens failed")
+		} else {
+			log.Info().Int64("deleted", deleted).Msg("collected expired tokens")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
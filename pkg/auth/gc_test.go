@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func (s *MySuite) Test_collectExpiredTokens(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	now := time.Date(2022, 2, 1, 12, 0, 0, 0, time.UTC)
+	gc := NewTokenGC(mock, time.Hour)
+	gc.BatchSize = 2
+	gc.Now = func() time.Time { return now }
+	gc.Retention = 30 * 24 * time.Hour
+
+	gomock.InOrder(
+		mock.EXPECT().
+			DeleteExpiredTokens(gomock.Any(), gomock.Eq(now), gomock.Eq(2)).
+			Return(int64(2), nil),
+		mock.EXPECT().
+			DeleteExpiredTokens(gomock.Any(), gomock.Eq(now), gomock.Eq(2)).
+			Return(int64(1), nil),
+		mock.EXPECT().
+			DeleteExpiredDenials(gomock.Any(), gomock.Eq(now)).
+			Return(int64(5), nil),
+		mock.EXPECT().
+			DeleteExpiredPasskeyChallenges(gomock.Any(), gomock.Eq(now)).
+			Return(int64(0), nil),
+		mock.EXPECT().
+			DeleteExpiredOIDCStates(gomock.Any(), gomock.Eq(now)).
+			Return(int64(0), nil),
+		mock.EXPECT().
+			DeleteExpiredAuthorizationCodes(gomock.Any(), gomock.Eq(now)).
+			Return(int64(0), nil),
+		mock.EXPECT().
+			DeleteExpiredAPIKeys(gomock.Any(), gomock.Eq(now)).
+			Return(int64(0), nil),
+		mock.EXPECT().
+			DeleteExpiredLoginAttempts(gomock.Any(), gomock.Eq(now)).
+			Return(int64(0), nil),
+		mock.EXPECT().
+			DeleteExpiredInvitations(gomock.Any(), gomock.Eq(now)).
+			Return(int64(0), nil),
+		mock.EXPECT().
+			PurgeDeleted(gomock.Any(), gomock.Eq(now.Add(-30*24*time.Hour))).
+			Return(int64(0), nil),
+	)
+
+	// when
+	deleted, err := gc.Collect(context.Background())
+
+	// then
+	assert.CmpNoError(err)
+	assert.Cmp(deleted, int64(3))
+}
@@ -0,0 +1,382 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/golang-jwt/jwt/v4"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	authtokens "github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/volatiletech/null/v8"
+)
+
+// AuthorizationCodeExpiry is the time a client has to redeem an authorization code.
+const AuthorizationCodeExpiry = time.Minute
+
+// OpenIDConfiguration is the provider metadata served under /.well-known/openid-configuration
+type OpenIDConfiguration struct {
+	oidc.Discovery
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+}
+
+// AuthorizeRequest describes the authorization request of a client
+type AuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type"`
+	ClientID            string `form:"client_id" json:"client_id"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string `form:"scope" json:"scope"`
+	State               string `form:"state" json:"state"`
+	Nonce               string `form:"nonce" json:"nonce"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+// TokenRequest describes the form posted by a client to the token endpoint
+type TokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// UserinfoResponse describes the authorized user by standard claims
+type UserinfoResponse struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name,omitempty"`
+}
+
+// OAuthError is an error response of the authorization and token endpoints, see RFC 6749 sections 4.1.2.1 and 5.2.
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *OAuthError) Error() string {
+	if len(e.Description) == 0 {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+func oauthError(code, description string) error {
+	return errors.WithStack(&OAuthError{Code: code, Description: description})
+}
+
+// OpenIDConfiguration describes the auth service as OpenID Connect provider.
+func (s *Service) OpenIDConfiguration() OpenIDConfiguration {
+	issuer := strings.TrimSuffix(s.OIDCIssuer, "/")
+	return OpenIDConfiguration{
+		Discovery: oidc.Discovery{
+			Issuer:                s.OIDCIssuer,
+			AuthorizationEndpoint: issuer + "/authorize",
+			TokenEndpoint:         issuer + "/token",
+			UserinfoEndpoint:      issuer + "/userinfo",
+			JWKSURI:               issuer + tokens.JWKSPath,
+		},
+		ResponseTypesSupported:            []string{"code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		ScopesSupported:                   []string{"openid", "email", "profile"},
+		ClaimsSupported:                   []string{"sub", "email", "email_verified", "name"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token", "client_credentials"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+	}
+}
+
+// Authorize checks the authorization request of a client and returns the URL of the login page to redirect the user to.
+// The login page authenticates the user and approves the request with ApproveAuthorization.
+// If the request is invalid but the client's redirect URI is valid, the returned URL redirects the error back to the client.
+func (s *Service) Authorize(ctx *gin.Context) (redirectURL string, err error) {
+	var req AuthorizeRequest
+	err = ctx.ShouldBindQuery(&req)
+	if err != nil {
+		err = errors.WithStack(ErrInvalidAuthorizeRequest)
+		return
+	}
+	client, err := s.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		var oauthErr *OAuthError
+		if errors.As(err, &oauthErr) {
+			redirectURL = withQuery(req.RedirectURI, url.Values{"error": {oauthErr.Code}, "state": {req.State}})
+		}
+		return
+	}
+	if len(s.OIDCLoginURL) == 0 {
+		err = errors.WithStack(ErrMissingLoginURL)
+		return
+	}
+
+	q := ctx.Request.URL.Query()
+	q.Set("instance", client.R.Instance.URL)
+	return withQuery(s.OIDCLoginURL, q), nil
+}
+
+// ApproveAuthorization issues an authorization code to a client for the authorized user
+// and returns the client's redirect URI carrying the code.
+func (s *Service) ApproveAuthorization(ctx *gin.Context) (redirectURL string, err error) {
+	var req AuthorizeRequest
+	err = ctx.ShouldBind(&req)
+	if err != nil {
+		err = errors.WithStack(ErrInvalidAuthorizeRequest)
+		return
+	}
+	client, err := s.checkAuthorizeRequest(ctx, req)
+	if err != nil {
+		return
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	instanceID, err := roles.Instance(ctx)
+	if err != nil {
+		return
+	}
+	// users log in per instance, so the user has to be logged in to the client's instance
+	if instanceID != client.InstanceID {
+		err = errors.WithStack(ErrOAuthClientNotFound)
+		return
+	}
+
+	code, digest, err := authtokens.GenerateOpaqueToken()
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	err = s.DBAPI.CreateAuthorizationCode(ctx, &m.AuthorizationCode{
+		Digest:        digest,
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         req.Scope,
+		Nonce:         null.NewString(req.Nonce, len(req.Nonce) > 0),
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      now,
+		ExpiresAt:     now.Add(AuthorizationCodeExpiry),
+	})
+	if err != nil {
+		return
+	}
+
+	return withQuery(req.RedirectURI, url.Values{"code": {code}, "state": {req.State}}), nil
+}
+
+// checkAuthorizeRequest validates the client and redirect URI first, as errors must not be redirected to unregistered URIs.
+// Errors of the remaining request are returned as OAuthError to be redirected.
+func (s *Service) checkAuthorizeRequest(ctx *gin.Context, req AuthorizeRequest) (*m.OauthClient, error) {
+	client, err := s.DBAPI.GetOAuthClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	registered := false
+	for _, uri := range strings.Fields(client.RedirectUris) {
+		registered = registered || uri == req.RedirectURI
+	}
+	if !registered {
+		return nil, errors.Wrapf(ErrInvalidRedirectURI, "%s", req.RedirectURI)
+	}
+
+	if req.ResponseType != "code" {
+		return nil, oauthError("unsupported_response_type", "only the authorization code flow is supported")
+	}
+	if !hasScope(req.Scope, "openid") {
+		return nil, oauthError("invalid_scope", "openid scope is required")
+	}
+	// PKCE is required for all clients, not only public ones
+	if len(req.CodeChallenge) == 0 || req.CodeChallengeMethod != "S256" {
+		return nil, oauthError("invalid_request", "S256 code challenge is required")
+	}
+	return client, nil
+}
+
+// Token redeems an authorization code or refresh token of a client for a fresh set of tokens,
+// or issues an access token to a service account by its client credentials.
+func (s *Service) Token(ctx *gin.Context) (res oidc.TokenResponse, err error) {
+	var req TokenRequest
+	err = ctx.ShouldBindWith(&req, binding.Form)
+	if err != nil {
+		err = oauthError("invalid_request", "")
+		return
+	}
+	client, err := s.authenticateClient(ctx, req)
+	if err != nil {
+		return
+	}
+
+	switch req.GrantType {
+	case "authorization_code":
+		return s.redeemAuthorizationCode(ctx, client, req)
+	case "refresh_token":
+		// the token has to be issued to the authenticated client, see RFC 6749 section 6
+		res.AccessToken, res.RefreshToken, err = s.refresh(ctx, req.RefreshToken, client)
+		if err != nil {
+			err = errors.Wrap(&OAuthError{Code: "invalid_grant"}, err.Error())
+			return
+		}
+		res.TokenType = "Bearer"
+		res.ExpiresIn = int64(authtokens.AccessTokenExpiry.Seconds())
+		return
+	case "client_credentials":
+		return s.issueServiceToken(client, req.Scope)
+	default:
+		err = oauthError("unsupported_grant_type", req.GrantType)
+		return
+	}
+}
+
+func (s *Service) redeemAuthorizationCode(ctx *gin.Context, client *m.OauthClient, req TokenRequest) (res oidc.TokenResponse, err error) {
+	code, err := s.DBAPI.ConsumeAuthorizationCode(ctx, authtokens.Digest(req.Code))
+	if err != nil {
+		if errors.Is(err, ErrAuthorizationCodeInvalid) {
+			err = errors.Wrap(&OAuthError{Code: "invalid_grant"}, err.Error())
+		}
+		return
+	}
+	if code.ClientID != client.ID || code.RedirectURI != req.RedirectURI {
+		err = oauthError("invalid_grant", "code was issued to another client or redirect URI")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(oidc.CodeChallenge(req.CodeVerifier)), []byte(code.CodeChallenge)) != 1 {
+		err = oauthError("invalid_grant", "code verifier mismatch")
+		return
+	}
+
+	user, err := s.DBAPI.GetUser(ctx, code.UserID)
+	if err != nil {
+		return
+	}
+	res.AccessToken, res.RefreshToken, _, err = s.startClientSession(ctx, user.ID, client.InstanceID, code.AuthTime, client)
+	if err != nil {
+		if errors.Is(err, ErrTokenDuplicate) {
+			err = errors.Wrap(&OAuthError{Code: "invalid_grant"}, err.Error())
+		}
+		return
+	}
+
+	claims := oidc.IDTokenClaims{
+		Nonce:           code.Nonce.String,
+		AuthorizedParty: client.ID,
+		AuthTime:        jwt.NewNumericDate(code.AuthTime),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   s.OIDCIssuer,
+			Subject:  user.ID,
+			Audience: jwt.ClaimStrings{client.ID},
+		},
+	}
+	if hasScope(code.Scope, "email") {
+		claims.Email = user.Email
+		claims.EmailVerified = oidc.Bool(user.ActivatedAt.Valid)
+	}
+	if hasScope(code.Scope, "profile") {
+		claims.Name = user.Name.String
+	}
+	res.IDToken, err = s.TokenAPI.GenerateIDToken(claims)
+	if err != nil {
+		return
+	}
+
+	res.TokenType = "Bearer"
+	res.ExpiresIn = int64(authtokens.AccessTokenExpiry.Seconds())
+	res.Scope = code.Scope
+	return
+}
+
+// authenticateClient checks the client credentials given by basic authentication or in the form.
+// Public clients have no secret and authenticate by their client ID only.
+func (s *Service) authenticateClient(ctx *gin.Context, req TokenRequest) (*m.OauthClient, error) {
+	clientID, clientSecret := req.ClientID, req.ClientSecret
+	if id, secret, ok := ctx.Request.BasicAuth(); ok {
+		// credentials are form encoded before basic authentication, see RFC 6749 section 2.3.1
+		clientID, _ = url.QueryUnescape(id)
+		clientSecret, _ = url.QueryUnescape(secret)
+	}
+
+	client, err := s.DBAPI.GetOAuthClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, ErrOAuthClientNotFound) {
+			err = errors.Wrap(&OAuthError{Code: "invalid_client"}, err.Error())
+		}
+		return nil, err
+	}
+	if client.Secret.Valid {
+		if subtle.ConstantTimeCompare(authtokens.Digest(clientSecret), client.Secret.Bytes) != 1 {
+			return nil, oauthError("invalid_client", "")
+		}
+	} else if len(clientSecret) > 0 {
+		return nil, oauthError("invalid_client", "public client must not authenticate with a secret")
+	}
+	return client, nil
+}
+
+// Userinfo returns the standard claims of the authorized user.
+func (s *Service) Userinfo(ctx *gin.Context) (res UserinfoResponse, err error) {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, userID)
+	if err != nil {
+		return
+	}
+	return UserinfoResponse{
+		Subject:       user.ID,
+		Email:         user.Email,
+		EmailVerified: user.ActivatedAt.Valid,
+		Name:          user.Name.String,
+	}, nil
+}
+
+func hasScope(scope, s string) bool {
+	for _, f := range strings.Fields(scope) {
+		if f == s {
+			return true
+		}
+	}
+	return false
+}
+
+// withQuery adds query parameters to a URL, keeping its existing query.
+func withQuery(rawURL string, q url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for k, v := range q {
+		if len(v) > 0 && len(v[0]) > 0 {
+			query[k] = v
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+var (
+	ErrOAuthClientNotFound      = errors.New("oauth client not found")
+	ErrInvalidRedirectURI       = errors.New("redirect URI not registered for client")
+	ErrInvalidAuthorizeRequest  = errors.New("invalid authorization request")
+	ErrAuthorizationCodeInvalid = errors.New("authorization code invalid or expired")
+	ErrMissingLoginURL          = errors.New("login page for authorization requests not configured")
+)
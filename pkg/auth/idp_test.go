@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	libtokens "github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_authorizationCodeFlow(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	tokenEnv := tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+		Issuer:            "auth",
+		Audience:          "test",
+	}
+	tokenAPI, err := tokens.Setup(tokenEnv)
+	require.CmpNoError(err)
+
+	instance := &m.Instance{ID: xid.New().String(), URL: "smartnuance.com"}
+	client := &m.OauthClient{
+		ID:           xid.New().String(),
+		InstanceID:   instance.ID,
+		Name:         "app",
+		RedirectUris: "http://localhost:3000/callback",
+	}
+	client.R = client.R.NewStruct()
+	client.R.Instance = instance
+	user := &m.User{ID: xid.New().String(), Name: null.StringFrom("Simon"), Email: "simon@smartnuance.com", ActivatedAt: null.TimeFrom(time.Now())}
+	profile := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: instance.ID, Role: null.StringFrom("teacher")}
+
+	service := &Service{
+		Env: Env{
+			TokenEnv:     tokenEnv,
+			OIDCLoginURL: "http://localhost:3000/login",
+		},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+		Denylist: libtokens.NewCachedDenylist(mock, time.Hour),
+	}
+	server := httptest.NewServer(router(service))
+	defer server.Close()
+	service.OIDCIssuer = server.URL
+
+	mock.EXPECT().
+		GetOAuthClient(gomock.Any(), gomock.Eq(client.ID)).
+		Return(client, nil).
+		AnyTimes()
+
+	provider, err := oidc.Discover(context.Background(), server.Client(), server.URL, oidc.Config{
+		ClientID:    client.ID,
+		RedirectURL: "http://localhost:3000/callback",
+		Scopes:      []string{"email", "profile"},
+	})
+	require.CmpNoError(err)
+
+	// when starting the authorization
+	httpClient := server.Client()
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := httpClient.Get(provider.AuthCodeURL("state", "nonce", "verifier"))
+	require.CmpNoError(err)
+	resp.Body.Close()
+
+	// then the user is redirected to the login page
+	require.Cmp(resp.StatusCode, http.StatusFound)
+	loginURL, err := resp.Location()
+	require.CmpNoError(err)
+	assert.Cmp(loginURL.Host, "localhost:3000")
+	assert.Cmp(loginURL.Query().Get("instance"), instance.URL)
+
+	// when the login page approves the request for the logged in user
+	var code *m.AuthorizationCode
+	mock.EXPECT().
+		CreateAuthorizationCode(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, c *m.AuthorizationCode) error {
+			code = c
+			return nil
+		})
+	accessToken, _, err := tokenAPI.GenerateAccessToken(user.ID, instance.ID, "teacher", time.Now())
+	require.CmpNoError(err)
+	params := map[string]string{}
+	for k := range loginURL.Query() {
+		params[k] = loginURL.Query().Get(k)
+	}
+	body, err := json.Marshal(params)
+	require.CmpNoError(err)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/authorize", strings.NewReader(string(body)))
+	require.CmpNoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err = server.Client().Do(req)
+	require.CmpNoError(err)
+	var approval struct {
+		RedirectURL string `json:"redirectURL"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&approval)
+	resp.Body.Close()
+	require.CmpNoError(err)
+
+	// then the client receives a code
+	callback, err := http.NewRequest(http.MethodGet, approval.RedirectURL, nil)
+	require.CmpNoError(err)
+	assert.Cmp(callback.URL.Query().Get("state"), "state")
+	assert.Cmp(code.Digest, tokens.Digest(callback.URL.Query().Get("code")))
+
+	// when redeeming the code
+	mock.EXPECT().
+		ConsumeAuthorizationCode(gomock.Any(), gomock.Eq(code.Digest)).
+		Return(code, nil)
+	mock.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Return(user, nil)
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(instance.ID)).
+		Return(profile, nil)
+	mock.EXPECT().
+		SaveToken(gomock.Any(), gomock.Eq(profile), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(client.ID), gomock.Any(), gomock.Any()).
+		Return(nil)
+	rawIDToken, err := provider.Exchange(context.Background(), callback.URL.Query().Get("code"), "verifier")
+	require.CmpNoError(err)
+	claims, err := provider.Verify(context.Background(), rawIDToken, "nonce")
+
+	// then
+	require.CmpNoError(err)
+	assert.Cmp(claims.Subject, user.ID)
+	assert.Cmp(claims.Email, user.Email)
+	assert.True(bool(claims.EmailVerified))
+	assert.Cmp(claims.Name, "Simon")
+}
+
+func (s *MySuite) Test_authorizeRejectsUnregisteredRedirect(assert, require *td.T) {
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := &Service{DBAPI: mock, Env: Env{OIDCLoginURL: "http://localhost:3000/login"}}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	client := &m.OauthClient{ID: xid.New().String(), RedirectUris: "http://localhost:3000/callback"}
+	mock.EXPECT().
+		GetOAuthClient(gomock.Any(), gomock.Eq(client.ID)).
+		Return(client, nil).
+		Times(2)
+
+	// unregistered redirect URI is not redirected to
+	_, err := service.checkAuthorizeRequest(ctx, AuthorizeRequest{
+		ClientID:    client.ID,
+		RedirectURI: "https://evil.example.com/callback",
+	})
+	assert.True(errors.Is(err, ErrInvalidRedirectURI))
+
+	// missing PKCE is redirected to the client
+	_, err = service.checkAuthorizeRequest(ctx, AuthorizeRequest{
+		ClientID:     client.ID,
+		RedirectURI:  "http://localhost:3000/callback",
+		ResponseType: "code",
+		Scope:        "openid",
+	})
+	var oauthErr *OAuthError
+	require.True(errors.As(err, &oauthErr))
+	assert.Cmp(oauthErr.Code, "invalid_request")
+}
+
+func (s *MySuite) Test_refreshTokenBoundToClient(assert, require *td.T) {
+	// given a refresh token issued to a client
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+	service := &Service{
+		Env:      Env{TokenEnv: tokens.TokenEnv{Issuer: "auth", Audience: "test"}},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+
+	instanceID := xid.New().String()
+	owner := &m.OauthClient{ID: xid.New().String(), InstanceID: instanceID}
+	other := &m.OauthClient{ID: xid.New().String(), InstanceID: instanceID}
+	profile := &m.Profile{ID: xid.New().String(), UserID: xid.New().String(), InstanceID: instanceID}
+	refreshToken, _, err := tokenAPI.GenerateRefreshToken(profile.UserID, instanceID)
+	require.CmpNoError(err)
+	mock.EXPECT().
+		GetOAuthClient(gomock.Any(), gomock.Eq(other.ID)).
+		Return(other, nil)
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(profile.UserID), gomock.Eq(instanceID)).
+		Return(profile, nil).
+		Times(2)
+	mock.EXPECT().
+		GetToken(gomock.Any(), gomock.Eq(profile.UserID), gomock.Eq(profile.ID), gomock.Eq(tokens.Digest(refreshToken))).
+		Return(&m.Token{ID: 1, UserID: profile.UserID, ProfileID: profile.ID, ClientID: null.StringFrom(owner.ID)}, nil).
+		Times(2)
+
+	// when another client redeems it, then it is rejected without rotating it
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/token", strings.NewReader("grant_type=refresh_token&client_id="+other.ID+"&refresh_token="+refreshToken))
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = service.Token(ctx)
+	var oauthErr *OAuthError
+	require.True(errors.As(err, &oauthErr))
+	assert.Cmp(oauthErr.Code, "invalid_grant")
+
+	// when refreshed as first-party session, then it is rejected as well
+	ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{"refreshToken":"`+refreshToken+`"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	_, _, err = service.Refresh(ctx)
+	assert.True(errors.Is(err, ErrTokenClientMismatch))
+}
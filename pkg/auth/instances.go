@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/lib/paging"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+// InstanceBody describes the attributes of an instance, absent attributes are left untouched on updates
+type InstanceBody struct {
+	Name *string `json:"name"`
+	URL  *string `json:"url"`
+}
+
+// InstanceResponse describes an instance
+type InstanceResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// InstanceList is a page of instances
+type InstanceList struct {
+	Items  []InstanceResponse `json:"items"`
+	Paging *paging.Paging     `json:"paging"`
+}
+
+// Instances lists a page of the instances that are not deleted.
+func (s *Service) Instances(ctx *gin.Context) (list InstanceList, err error) {
+	if !roles.CanActIn(ctx, roles.RoleSuperAdmin) {
+		err = errors.WithStack(roles.ErrUnauthorized)
+		return
+	}
+	page := paging.FromQuery(ctx)
+	instances, err := s.DBAPI.ListInstances(ctx, page)
+	if err != nil {
+		return
+	}
+
+	list.Items = make([]InstanceResponse, 0, len(instances))
+	for _, i := range instances {
+		list.Items = append(list.Items, instanceResponse(i))
+	}
+	var first, last string
+	if n := len(list.Items); n > 0 {
+		first, last = list.Items[0].ID, list.Items[n-1].ID
+	}
+	list.Paging = paging.Loaded(page, first, last, len(list.Items))
+	return
+}
+
+// CreateInstance creates an instance with a name and a URL no other instance has.
+func (s *Service) CreateInstance(ctx *gin.Context) (res InstanceResponse, err error) {
+	var body InstanceBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	if !roles.CanActIn(ctx, roles.RoleSuperAdmin) {
+		err = errors.WithStack(roles.ErrUnauthorized)
+		return
+	}
+	if body.Name == nil || len(strings.TrimSpace(*body.Name)) == 0 {
+		err = errors.WithStack(ErrInvalidInstanceName)
+		return
+	}
+	if body.URL == nil {
+		err = errors.WithStack(ErrInvalidInstanceURL)
+		return
+	}
+	url, err := s.availableInstanceURL(ctx, *body.URL, "")
+	if err != nil {
+		return
+	}
+
+	instance, err := s.DBAPI.CreateInstance(ctx, strings.TrimSpace(*body.Name), url)
+	if err != nil {
+		return
+	}
+	log.Debug().Str("instance", instance.ID).Str("url", instance.URL).Msg("instance created")
+	return instanceResponse(instance), nil
+}
+
+// UpdateInstance renames an instance or changes its URL.
+// Users log in with the URL, so a changed URL has to be communicated to them.
+func (s *Service) UpdateInstance(ctx *gin.Context) (res InstanceResponse, err error) {
+	var body InstanceBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	if !roles.CanActIn(ctx, roles.RoleSuperAdmin) {
+		err = errors.WithStack(roles.ErrUnauthorized)
+		return
+	}
+	instance, err := s.DBAPI.GetInstanceByID(ctx, ctx.Param("id"))
+	if err != nil {
+		return
+	}
+
+	if body.Name != nil {
+		if len(strings.TrimSpace(*body.Name)) == 0 {
+			err = errors.WithStack(ErrInvalidInstanceName)
+			return
+		}
+		instance.Name = strings.TrimSpace(*body.Name)
+	}
+	if body.URL != nil {
+		instance.URL, err = s.availableInstanceURL(ctx, *body.URL, instance.ID)
+		if err != nil {
+			return
+		}
+	}
+
+	err = s.DBAPI.UpdateInstance(ctx, instance)
+	if err != nil {
+		return
+	}
+	return instanceResponse(instance), nil
+}
+
+// DeleteInstance soft-deletes an instance and revokes the tokens and API keys of all its profiles.
+func (s *Service) DeleteInstance(ctx *gin.Context) error {
+	if !roles.CanActIn(ctx, roles.RoleSuperAdmin) {
+		return errors.WithStack(roles.ErrUnauthorized)
+	}
+	instanceID := ctx.Param("id")
+	n, err := s.DBAPI.DeleteInstance(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	s.syncDenylist(ctx)
+	log.Debug().Str("instance", instanceID).Int64("revoked", n).Msg("instance deleted")
+	return nil
+}
+
+// availableInstanceURL normalizes the URL of an instance and checks that no other instance than instanceID has it.
+func (s *Service) availableInstanceURL(ctx *gin.Context, url, instanceID string) (string, error) {
+	url = strings.ToLower(strings.TrimSpace(url))
+	if len(url) == 0 || strings.ContainsAny(url, " \t\r\n") {
+		return "", errors.WithStack(ErrInvalidInstanceURL)
+	}
+	other, err := s.DBAPI.GetInstance(ctx, url)
+	if errors.Is(err, ErrInstanceDoesNotExist) {
+		return url, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if other.ID != instanceID {
+		return "", errors.WithStack(ErrInstanceURLTaken)
+	}
+	return url, nil
+}
+
+func instanceResponse(i *m.Instance) InstanceResponse {
+	return InstanceResponse{
+		ID:        i.ID,
+		Name:      i.Name,
+		URL:       i.URL,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
+
+var (
+	ErrInvalidInstanceName = errors.New("invalid instance name")
+	ErrInvalidInstanceURL  = errors.New("invalid instance URL")
+	ErrInstanceURLTaken    = errors.New("instance URL is taken")
+)
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+func (s *MySuite) Test_createInstanceRequiresUniqueURL(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+
+	create := func(role roles.Role, body string) (InstanceResponse, error) {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/instances", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set(roles.RoleKey, role)
+		return service.CreateInstance(ctx)
+	}
+
+	// instance admins can not create instances
+	_, err := create(roles.RoleInstanceAdmin, `{"name":"Dance school","url":"dance.example.com"}`)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// URLs are unique
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq("smartnuance.com")).
+		Return(&m.Instance{ID: xid.New().String(), URL: "smartnuance.com"}, nil)
+	_, err = create(roles.RoleSuperAdmin, `{"name":"smartnuance","url":" SmartNuance.com "}`)
+	assert.True(errors.Is(err, ErrInstanceURLTaken))
+
+	// when the URL is available, then the instance is created
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq("dance.example.com")).
+		Return(nil, errors.WithStack(ErrInstanceDoesNotExist))
+	instance := &m.Instance{ID: xid.New().String(), Name: "Dance school", URL: "dance.example.com"}
+	mock.EXPECT().
+		CreateInstance(gomock.Any(), gomock.Eq("Dance school"), gomock.Eq("dance.example.com")).
+		Return(instance, nil)
+	res, err := create(roles.RoleSuperAdmin, `{"name":"Dance school","url":"dance.example.com"}`)
+	require.CmpNoError(err)
+	assert.Cmp(res.ID, instance.ID)
+}
+
+func (s *MySuite) Test_updateInstanceKeepsOwnURL(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+	instance := &m.Instance{ID: xid.New().String(), Name: "smartnuance", URL: "smartnuance.com"}
+
+	mock.EXPECT().
+		GetInstanceByID(gomock.Any(), gomock.Eq(instance.ID)).
+		Return(instance, nil)
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq("smartnuance.com")).
+		Return(instance, nil)
+	mock.EXPECT().
+		UpdateInstance(gomock.Any(), gomock.Eq(instance)).
+		Return(nil)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPatch, "/instances/"+instance.ID, strings.NewReader(`{"name":"Smartnuance","url":"smartnuance.com"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "id", Value: instance.ID}}
+	ctx.Set(roles.RoleKey, roles.RoleSuperAdmin)
+
+	// when
+	res, err := service.UpdateInstance(ctx)
+
+	// then
+	require.CmpNoError(err)
+	assert.Cmp(res.Name, "Smartnuance")
+	assert.Cmp(res.URL, "smartnuance.com")
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/volatiletech/null/v8"
+)
+
+// InvitationBody describes whom to invite to the authorized instance in which role
+type InvitationBody struct {
+	Email string     `json:"email"`
+	Role  roles.Role `json:"role"`
+}
+
+// InvitationResponse describes a pending invitation
+type InvitationResponse struct {
+	ID        string     `json:"id"`
+	Email     string     `json:"email"`
+	Role      roles.Role `json:"role"`
+	InvitedBy string     `json:"invitedBy,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+}
+
+// Invitations lists the pending invitations of the authorized instance.
+func (s *Service) Invitations(ctx *gin.Context) ([]InvitationResponse, error) {
+	instanceID, err := invitationInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := s.DBAPI.ListInvitations(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]InvitationResponse, 0, len(invitations))
+	for _, i := range invitations {
+		res = append(res, invitationResponse(i))
+	}
+	return res, nil
+}
+
+// Invite sends an invitation to join the authorized instance to an email.
+// The role is limited to roles the authorized user can act in.
+// Inviting an email again replaces its pending invitation.
+func (s *Service) Invite(ctx *gin.Context) (res InvitationResponse, err error) {
+	var body InvitationBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	instanceID, err := invitationInstance(ctx)
+	if err != nil {
+		return
+	}
+	err = checkEmail(body.Email)
+	if err != nil {
+		return
+	}
+	if !validRole(body.Role) {
+		err = errors.WithStack(roles.ErrInvalidRole)
+		return
+	}
+	if !roles.CanActIn(ctx, body.Role) {
+		err = errors.WithStack(roles.ErrUnauthorized)
+		return
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	instance, err := s.DBAPI.GetInstanceByID(ctx, instanceID)
+	if err != nil {
+		return
+	}
+
+	token, digest, err := tokens.GenerateOpaqueToken()
+	if err != nil {
+		return
+	}
+	invitation := &m.Invitation{
+		InstanceID: instanceID,
+		Email:      body.Email,
+		Role:       string(body.Role),
+		Digest:     digest,
+		InvitedBy:  null.StringFrom(userID),
+		ExpiresAt:  time.Now().Add(s.InvitationExpiry),
+	}
+	err = s.DBAPI.CreateInvitation(ctx, invitation)
+	if err != nil {
+		return
+	}
+
+	err = s.sendInvitation(ctx, instance, body.Email, token)
+	if err != nil {
+		return
+	}
+	return invitationResponse(invitation), nil
+}
+
+// DeleteInvitation revokes a pending invitation of the authorized instance.
+func (s *Service) DeleteInvitation(ctx *gin.Context) error {
+	instanceID, err := invitationInstance(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := s.DBAPI.DeleteInvitation(ctx, instanceID, ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrInvitationNotFound)
+	}
+	return nil
+}
+
+// AcceptInvitationBody describes the invitation token received by mail.
+// Name and password are only needed if no user with the invited email exists yet.
+type AcceptInvitationBody struct {
+	Token    string `json:"token"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// AcceptInvitation gives the invited user a profile with the invited role in the instance of the invitation.
+// A user with the invited email is created if there is none yet, then the password has to satisfy the instance's policy.
+// Existing users keep their credentials and log in as usual. Members keep a role including the invited one,
+// otherwise they get the invited role and their sessions end.
+func (s *Service) AcceptInvitation(ctx *gin.Context) (userID string, role roles.Role, err error) {
+	var body AcceptInvitationBody
+	err = ctx.ShouldBind(&body)
+	if err != nil || len(body.Token) == 0 {
+		err = errors.WithStack(ErrMissingInvitationToken)
+		return
+	}
+	digest := tokens.Digest(body.Token)
+	invitation, err := s.DBAPI.GetInvitation(ctx, digest)
+	if err != nil {
+		return
+	}
+
+	var hashedPassword []byte
+	_, err = s.DBAPI.FindUserByEmail(ctx, invitation.Email)
+	if errors.Is(err, ErrUserDoesNotExist) {
+		hashedPassword, err = s.invitedUserPassword(ctx, invitation.InstanceID, body.Password)
+	}
+	if err != nil {
+		return
+	}
+
+	user, profile, n, err := s.DBAPI.AcceptInvitation(ctx, digest, body.Name, hashedPassword)
+	if err != nil {
+		return
+	}
+	if n > 0 {
+		s.syncDenylist(ctx)
+	}
+	log.Debug().Str("user", user.ID).Str("instance", profile.InstanceID).Str("role", profile.Role.String).Int64("revoked", n).Msg("invitation accepted")
+	return user.ID, roles.Role(profile.Role.String), nil
+}
+
+// invitedUserPassword checks the password of a user to create against the policy of the instance and hashes it.
+func (s *Service) invitedUserPassword(ctx context.Context, instanceID, pw string) ([]byte, error) {
+	if len(pw) == 0 {
+		return nil, errors.WithStack(ErrInvalidPassword)
+	}
+	instance, err := s.DBAPI.GetInstanceByID(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkPassword(instancePolicy(instance), pw)
+	if err != nil {
+		return nil, err
+	}
+	return s.hashPassword(pw)
+}
+
+func (s *Service) sendInvitation(ctx context.Context, instance *m.Instance, email, token string) error {
+	// accepting may need a name and password, so the link opens the frontend's form that submits them
+	link := s.FrontendURL + "/invitations/accept?token=" + url.QueryEscape(token)
+	return s.Mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Invitation to " + instance.Name,
+		Body:    fmt.Sprintf("You are invited to join %s. Accept the invitation by opening the following link within %s:\n\n%s\n", instance.Name, s.InvitationExpiry, link),
+	})
+}
+
+// invitationInstance returns the authorized instance if the user can manage its invitations.
+func invitationInstance(ctx *gin.Context) (string, error) {
+	if !roles.CanActIn(ctx, roles.RoleInstanceAdmin) {
+		return "", errors.WithStack(roles.ErrUnauthorized)
+	}
+	return roles.Instance(ctx)
+}
+
+func invitationResponse(i *m.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:        i.ID,
+		Email:     i.Email,
+		Role:      roles.Role(i.Role),
+		InvitedBy: i.InvitedBy.String,
+		CreatedAt: i.CreatedAt,
+		ExpiresAt: i.ExpiresAt,
+	}
+}
+
+var (
+	ErrMissingInvitationToken = errors.New("missing invitation token")
+	ErrInvitationInvalid      = errors.New("invitation token invalid or expired")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+)
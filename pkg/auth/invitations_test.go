@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_inviteBoundedByRole(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	mailDir := require.TempDir()
+	service := Service{Env: Env{PublicURL: "http://localhost:8801", FrontendURL: "http://localhost:3000", InvitationExpiry: time.Hour}, DBAPI: mock, Mailer: mail.FileSender{Dir: mailDir}}
+	instance := &m.Instance{ID: xid.New().String(), Name: "smartnuance"}
+	adminID := xid.New().String()
+
+	invite := func(role roles.Role, body string) (InvitationResponse, error) {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/invitations", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set(roles.UserKey, adminID)
+		ctx.Set(roles.RoleKey, role)
+		ctx.Set(roles.InstanceKey, instance.ID)
+		return service.Invite(ctx)
+	}
+
+	// teachers can not invite
+	_, err := invite(roles.RoleTeacher, `{"email":"yanis@example.com","role":"teacher"}`)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// instance admins can not invite super admins
+	_, err = invite(roles.RoleInstanceAdmin, `{"email":"yanis@example.com","role":"super admin"}`)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// instance admins can invite teachers
+	mock.EXPECT().
+		GetInstanceByID(gomock.Any(), gomock.Eq(instance.ID)).
+		Return(instance, nil)
+	mock.EXPECT().
+		CreateInvitation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, i *m.Invitation) error {
+			assert.Cmp(i, td.Struct(&m.Invitation{InstanceID: instance.ID, Email: "yanis@example.com", Role: "teacher", InvitedBy: null.StringFrom(adminID)},
+				td.StructFields{"Digest": td.Len(32), "ExpiresAt": td.Gt(time.Now())}))
+			return nil
+		})
+	res, err := invite(roles.RoleInstanceAdmin, `{"email":"yanis@example.com","role":"teacher"}`)
+	require.CmpNoError(err)
+	assert.Cmp(res.Role, roles.RoleTeacher)
+
+	// and the invitee receives the token by mail
+	mails, err := filepath.Glob(filepath.Join(mailDir, "*.eml"))
+	require.CmpNoError(err)
+	require.Len(mails, 1)
+	content, err := ioutil.ReadFile(mails[0])
+	require.CmpNoError(err)
+	assert.Contains(string(content), "To: yanis@example.com")
+	assert.Contains(string(content), "http://localhost:3000/invitations/accept?token=")
+}
+
+func (s *MySuite) Test_acceptInvitation(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+
+	token, digest, err := tokens.GenerateOpaqueToken()
+	require.CmpNoError(err)
+	instance := &m.Instance{ID: xid.New().String(), PasswordMinLength: 8, PasswordMinClasses: 1}
+	invitation := &m.Invitation{ID: xid.New().String(), InstanceID: instance.ID, Email: "yanis@example.com", Role: "teacher", Digest: digest}
+	user := &m.User{ID: xid.New().String(), Email: invitation.Email}
+	profile := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: instance.ID, Role: null.StringFrom("teacher")}
+
+	accept := func(body string) (string, roles.Role, error) {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/invitations/accept", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return service.AcceptInvitation(ctx)
+	}
+	mock.EXPECT().
+		GetInvitation(gomock.Any(), gomock.Eq(digest)).
+		Return(invitation, nil).
+		AnyTimes()
+
+	// when the invitee has no user yet, then a password satisfying the instance's policy is required
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq(invitation.Email)).
+		Return(nil, errors.WithStack(ErrUserDoesNotExist)).
+		Times(2)
+	mock.EXPECT().
+		GetInstanceByID(gomock.Any(), gomock.Eq(instance.ID)).
+		Return(instance, nil).
+		Times(2)
+	_, _, err = accept(`{"token":"` + token + `","name":"Yanis","password":"short"}`)
+	var policyErr *PasswordPolicyError
+	assert.True(errors.As(err, &policyErr))
+
+	mock.EXPECT().
+		AcceptInvitation(gomock.Any(), gomock.Eq(digest), gomock.Eq("Yanis"), gomock.Not(gomock.Len(0))).
+		Return(user, profile, int64(0), nil)
+	userID, role, err := accept(`{"token":"` + token + `","name":"Yanis","password":"long enough"}`)
+	require.CmpNoError(err)
+	assert.Cmp(userID, user.ID)
+	assert.Cmp(role, roles.RoleTeacher)
+
+	// when the invitee already has a user, then the credentials are left unchanged
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq(invitation.Email)).
+		Return(user, nil)
+	mock.EXPECT().
+		AcceptInvitation(gomock.Any(), gomock.Eq(digest), gomock.Eq(""), gomock.Nil()).
+		Return(user, profile, int64(0), nil)
+	userID, _, err = accept(`{"token":"` + token + `"}`)
+	require.CmpNoError(err)
+	assert.Cmp(userID, user.ID)
+}
+
+func (s *MySuite) Test_acceptInvitationKeepsHigherRole(assert, require *td.T) {
+	// members invited with a role their role includes keep it
+	assert.True(includesRole(roles.RoleInstanceAdmin, roles.RoleTeacher))
+	assert.True(includesRole(roles.RoleTeacher, roles.RoleTeacher))
+
+	// other members get the invited role
+	assert.False(includesRole(roles.RoleTeacher, roles.RoleInstanceAdmin))
+	assert.False(includesRole(roles.NoRole, roles.RoleTeacher))
+}
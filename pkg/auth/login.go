@@ -6,45 +6,137 @@ import (
 	"github.com/friendsofgo/errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	authtokens "github.com/smartnuance/saas-kit/pkg/auth/tokens"
 	"github.com/smartnuance/saas-kit/pkg/lib/roles"
 	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
-	"golang.org/x/crypto/bcrypt"
 )
 
-// CredentialsBody describes the login credentials
+// CredentialsBody describes the login credentials, the instance is optional
 type CredentialsBody struct {
 	InstanceURL string `json:"instance"`
 	Email       string `json:"email"`
 	Password    string `json:"password"`
 }
 
-func (s *Service) Login(ctx *gin.Context) (accessToken, refreshToken string, role roles.Role, err error) {
+// UserInstanceResponse describes an instance the user has a profile in and the user's role there
+type UserInstanceResponse struct {
+	ID   string     `json:"id"`
+	Name string     `json:"name"`
+	URL  string     `json:"url"`
+	Role roles.Role `json:"role"`
+	// Current is set for the instance the returned tokens are scoped to
+	Current bool `json:"current"`
+}
+
+// Login checks the credentials and returns a fresh set of tokens.
+// Without an instance, the tokens are scoped to the instance the user joined first
+// and the instances the user has a profile in are returned, to switch to another with SwitchInstance.
+// Repeated failed logins lock out the account and the client IP temporarily.
+// If a second factor is required, only a challenge to complete the login with LoginTwoFactor is returned.
+func (s *Service) Login(ctx *gin.Context) (accessToken, refreshToken string, role roles.Role, instances []UserInstanceResponse, challenge *ChallengeResponse, err error) {
 	var body CredentialsBody
 	err = ctx.ShouldBind(&body)
 	if err != nil {
 		err = errors.WithStack(ErrMissingCredentials)
 		return
 	}
+	// locked out logins fail like wrong credentials without checking them
+	err = s.checkLoginThrottle(ctx, body.Email)
+	if err != nil {
+		return
+	}
 	var user *m.User
 	user, err = s.loginWithCredentials(ctx, body.Email, body.Password)
 	if err != nil {
+		s.recordLogin(ctx, body.Email, err)
 		return
 	}
 
 	var instance *m.Instance
-	instance, err = s.DBAPI.GetInstance(ctx, body.InstanceURL)
+	if len(body.InstanceURL) > 0 {
+		instance, err = s.DBAPI.GetInstance(ctx, body.InstanceURL)
+	} else {
+		instance, instances, err = s.defaultInstance(ctx, user)
+	}
 	if err != nil {
 		return
 	}
 
+	if instance.RequireVerification && !user.ActivatedAt.Valid {
+		err = errors.WithStack(ErrUserNotActivated)
+		return
+	}
+
+	challenge, err = s.twoFactorChallenge(ctx, user, instance)
+	if challenge != nil {
+		challenge.Instances = instances
+	}
+	if err != nil || challenge != nil {
+		return
+	}
+
+	accessToken, refreshToken, role, err = s.startSession(ctx, user.ID, instance.ID)
+	if err == nil {
+		s.recordLogin(ctx, body.Email, nil)
+	}
+	return
+}
+
+// defaultInstance lists the instances the user has a profile in and picks the one to log in to:
+// the instance the user joined first, skipping instances that require a verification the user lacks.
+func (s *Service) defaultInstance(ctx *gin.Context, user *m.User) (instance *m.Instance, instances []UserInstanceResponse, err error) {
+	profiles, err := s.DBAPI.ListProfiles(ctx, user.ID)
+	if err != nil {
+		return
+	}
+
+	var first *m.Instance
+	instances = make([]UserInstanceResponse, 0, len(profiles))
+	for _, p := range profiles {
+		if p.R == nil || p.R.Instance == nil {
+			continue
+		}
+		i := p.R.Instance
+		if first == nil {
+			first = i
+		}
+		res := UserInstanceResponse{ID: i.ID, Name: i.Name, URL: i.URL, Role: roles.Role(p.Role.String)}
+		if instance == nil && (!i.RequireVerification || user.ActivatedAt.Valid) {
+			instance = i
+			res.Current = true
+		}
+		instances = append(instances, res)
+	}
+	if len(instances) == 0 {
+		err = errors.WithStack(ErrProfileDoesNotExist)
+		return
+	}
+	if instance == nil {
+		// all instances require a verification the user lacks, which fails the login
+		instance = first
+		instances[0].Current = true
+	}
+	return
+}
+
+// startSession issues a fresh pair of tokens for the user's profile in the given instance.
+func (s *Service) startSession(ctx *gin.Context, userID, instanceID string) (accessToken, refreshToken string, role roles.Role, err error) {
+	return s.startClientSession(ctx, userID, instanceID, time.Now(), nil)
+}
+
+// startClientSession starts a session of a user who authenticated with credentials at authTime.
+// Its refresh token is bound to an OAuth client, or a first-party session if client is nil.
+func (s *Service) startClientSession(ctx *gin.Context, userID, instanceID string, authTime time.Time, client *m.OauthClient) (accessToken, refreshToken string, role roles.Role, err error) {
 	var expiresAt time.Time
-	refreshToken, expiresAt, err = s.TokenAPI.GenerateRefreshToken(user.ID, instance.ID)
+	refreshToken, expiresAt, err = s.TokenAPI.GenerateRefreshToken(userID, instanceID)
 	if err != nil {
 		return
 	}
 
-	profile, err := s.DBAPI.GetProfile(ctx, user.ID, instance.ID)
+	profile, err := s.DBAPI.GetProfile(ctx, userID, instanceID)
 	if err != nil {
 		err = errors.WithStack(ErrProfileDoesNotExist)
 		return
@@ -55,12 +147,17 @@ func (s *Service) Login(ctx *gin.Context) (accessToken, refreshToken string, rol
 	} else {
 		role = roles.NoRole
 	}
-	accessToken, err = s.TokenAPI.GenerateAccessToken(user.ID, instance.ID, role)
+	var accessJTI string
+	accessToken, accessJTI, err = s.TokenAPI.GenerateAccessToken(userID, instanceID, role, authTime)
 	if err != nil {
 		return
 	}
 
-	err = s.DBAPI.SaveToken(ctx, profile, refreshToken, expiresAt)
+	var clientID string
+	if client != nil {
+		clientID = client.ID
+	}
+	err = s.DBAPI.SaveToken(ctx, profile, authtokens.Digest(refreshToken), expiresAt, authTime, accessJTI, clientID, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
 		return
 	}
@@ -68,59 +165,205 @@ func (s *Service) Login(ctx *gin.Context) (accessToken, refreshToken string, rol
 	return
 }
 
-func (s *Service) loginWithCredentials(ctx *gin.Context, email string, password string) (*m.User, error) {
+func (s *Service) loginWithCredentials(ctx *gin.Context, email string, pw string) (*m.User, error) {
 	user, err := s.DBAPI.FindUserByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
-	err = bcrypt.CompareHashAndPassword(user.Password, []byte(password))
+	err = password.Compare(user.Password, pw)
 	if err != nil {
 		return nil, errors.WithStack(ErrInvalidCredentials)
 	}
+	s.rehashPassword(ctx, user, pw)
 	return user, nil
 }
 
+// rehashPassword upgrades a hash of an outdated algorithm or parameters, which is only possible while the password is known.
+// Failing to do so does not fail the login.
+func (s *Service) rehashPassword(ctx *gin.Context, user *m.User, pw string) {
+	hasher := s.passwordHasher()
+	if !hasher.NeedsRehash(user.Password) {
+		return
+	}
+	hash, err := hasher.Hash(pw)
+	if err == nil {
+		err = s.DBAPI.RehashPassword(ctx, user.ID, user.Password, hash)
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("")
+		return
+	}
+	user.Password = hash
+	log.Debug().Str("user", user.ID).Str("algorithm", hasher.Algorithm).Msg("password rehashed")
+}
+
 // RefreshTokenBody describes the refresh body
 type RefreshTokenBody struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
-func (s *Service) Refresh(ctx *gin.Context) (string, error) {
+// Refresh exchanges a refresh token for a new access token and a new refresh token.
+// The presented refresh token is consumed. Presenting a consumed token again indicates it was stolen,
+// so the whole token family descending from the same login is revoked.
+func (s *Service) Refresh(ctx *gin.Context) (accessToken, refreshToken string, err error) {
 	var body RefreshTokenBody
-	err := ctx.ShouldBind(&body)
+	err = ctx.ShouldBind(&body)
 	if err != nil {
-		return "", errors.WithStack(ErrMissingRefreshToken)
+		err = errors.WithStack(ErrMissingRefreshToken)
+		return
 	}
+	return s.refresh(ctx, body.RefreshToken, nil)
+}
 
-	var claims tokens.RefreshTokenClaims
-	err = tokens.CheckRefreshToken(body.RefreshToken, &claims, s.TokenAPI.ValidationKey, s.Issuer, s.Audience)
+// refresh rotates a refresh token presented by the OAuth client it was issued to, or by a first-party client if client is nil.
+func (s *Service) refresh(ctx *gin.Context, presentedToken string, client *m.OauthClient) (accessToken, refreshToken string, err error) {
+	claims, profile, token, err := s.checkPresentedToken(ctx, presentedToken, client)
 	if err != nil {
-		return "", errors.WithStack(errors.Wrap(err, ErrTokenInvalid.Error()))
+		return
 	}
-
 	userID := claims.Subject
-	profile, err := s.DBAPI.GetProfile(ctx, userID, claims.Instance)
+
+	var role roles.Role
+	if profile.Role.Valid {
+		role = roles.Role(profile.Role.String)
+	} else {
+		role = roles.NoRole
+	}
+
+	var accessJTI string
+	// refreshing is no authentication, so the time of the login is kept
+	accessToken, accessJTI, err = s.TokenAPI.GenerateAccessToken(userID, claims.Instance, role, token.LoggedInAt)
 	if err != nil {
-		return "", errors.WithStack(ErrProfileDoesNotExist)
+		return
+	}
+
+	var expiresAt time.Time
+	refreshToken, expiresAt, err = s.TokenAPI.GenerateRefreshToken(userID, claims.Instance)
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.RotateToken(ctx, token, authtokens.Digest(refreshToken), expiresAt, accessJTI)
+	if err != nil {
+		// the issued tokens are not stored, so they must not be handed out
+		accessToken, refreshToken = "", ""
+		if errors.Is(err, ErrTokenReused) {
+			err = s.revokeTokenFamily(ctx, token)
+		}
+		return
+	}
+	return
+}
+
+// checkPresentedToken checks a refresh token and loads it with the profile it was issued for.
+// A consumed token indicates it was stolen, so its family is revoked.
+// Tokens issued to an OAuth client are only accepted from that client, first-party tokens only if client is nil.
+func (s *Service) checkPresentedToken(ctx *gin.Context, presentedToken string, client *m.OauthClient) (claims tokens.RefreshTokenClaims, profile *m.Profile, token *m.Token, err error) {
+	err = tokens.CheckRefreshToken(ctx, presentedToken, &claims, s.TokenAPI.ValidationKeys, s.Issuer, s.Audience)
+	if err != nil {
+		err = errors.WithStack(errors.Wrap(err, ErrTokenInvalid.Error()))
+		return
+	}
+
+	profile, err = s.DBAPI.GetProfile(ctx, claims.Subject, claims.Instance)
+	if err != nil {
+		err = errors.WithStack(ErrProfileDoesNotExist)
+		return
 	}
 
 	// check if revoked in the meanwhile
-	ok, err := s.DBAPI.HasToken(ctx, userID, profile.ID, body.RefreshToken)
+	token, err = s.DBAPI.GetToken(ctx, claims.Subject, profile.ID, authtokens.Digest(presentedToken))
 	if err != nil {
-		return "", errors.WithStack(err)
+		return
+	}
+	if token.ConsumedAt.Valid {
+		err = s.revokeTokenFamily(ctx, token)
+		return
 	}
-	if !ok {
-		return "", errors.WithStack(ErrTokenRevoked)
+	if client == nil {
+		if token.ClientID.Valid {
+			err = errors.WithStack(ErrTokenClientMismatch)
+		}
+		return
 	}
+	if token.ClientID.String != client.ID || claims.Instance != client.InstanceID {
+		err = errors.WithStack(ErrTokenClientMismatch)
+	}
+	return
+}
 
-	var role roles.Role
-	if profile.Role.Valid {
-		role = roles.Role(profile.Role.String)
-	} else {
-		role = roles.NoRole
+// SwitchInstanceBody describes the refresh token of a session and the instance to switch to
+type SwitchInstanceBody struct {
+	RefreshToken string `json:"refreshToken"`
+	InstanceURL  string `json:"instance"`
+}
+
+// SwitchInstance exchanges a refresh token for a fresh set of tokens for the user's profile in another instance,
+// without asking for the password again. The session of the presented token ends.
+// The other instance's requirements apply like on a login: if it requires a second factor,
+// only a challenge to complete the switch with LoginTwoFactor is returned and the presented session goes on.
+func (s *Service) SwitchInstance(ctx *gin.Context) (accessToken, refreshToken string, role roles.Role, challenge *ChallengeResponse, err error) {
+	var body SwitchInstanceBody
+	err = ctx.ShouldBind(&body)
+	if err != nil || len(body.RefreshToken) == 0 {
+		err = errors.WithStack(ErrMissingRefreshToken)
+		return
 	}
+	_, _, token, err := s.checkPresentedToken(ctx, body.RefreshToken, nil)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, token.UserID)
+	if err != nil {
+		return
+	}
+	instance, err := s.DBAPI.GetInstance(ctx, body.InstanceURL)
+	if err != nil {
+		return
+	}
+	_, err = s.DBAPI.GetProfile(ctx, user.ID, instance.ID)
+	if err != nil {
+		err = errors.WithStack(ErrProfileDoesNotExist)
+		return
+	}
+
+	if instance.RequireVerification && !user.ActivatedAt.Valid {
+		err = errors.WithStack(ErrUserNotActivated)
+		return
+	}
+	challenge, err = s.twoFactorChallenge(ctx, user, instance)
+	if err != nil || challenge != nil {
+		return
+	}
+
+	n, err := s.DBAPI.DeleteTokenFamily(ctx, token.Family)
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		// exchanged or revoked concurrently
+		err = errors.WithStack(ErrTokenNotFound)
+		return
+	}
+	s.syncDenylist(ctx)
 
-	return s.TokenAPI.GenerateAccessToken(userID, claims.Instance, role)
+	// switching is no authentication, so the time of the login is kept
+	accessToken, refreshToken, role, err = s.startClientSession(ctx, user.ID, instance.ID, token.LoggedInAt, nil)
+	if err != nil {
+		return
+	}
+	log.Debug().Str("user", user.ID).Str("from", token.ProfileID).Str("instance", instance.ID).Msg("instance switched")
+	return
+}
+
+// revokeTokenFamily revokes all refresh tokens descending from the same login as a reused token.
+func (s *Service) revokeTokenFamily(ctx *gin.Context, token *m.Token) error {
+	n, err := s.DBAPI.DeleteTokenFamily(ctx, token.Family)
+	if err != nil {
+		return err
+	}
+	s.syncDenylist(ctx)
+	log.Warn().Str("user", token.UserID).Str("profile", token.ProfileID).Int64("revoked", n).Msg("refresh token reused")
+	return errors.WithStack(ErrTokenReused)
 }
 
 var (
@@ -129,6 +372,9 @@ var (
 	ErrMissingRefreshToken  = errors.New("missing refresh token in JSON body")
 	ErrTokenInvalid         = errors.New("token invalid")
 	ErrTokenNotFound        = errors.New("token not found")
+	ErrTokenReused          = errors.New("refresh token was already used, all tokens of its family are revoked")
+	ErrTokenClientMismatch  = errors.New("refresh token was issued to another client")
+	ErrTokenDuplicate       = errors.New("refresh token was issued already")
 	ErrUserDoesNotExist     = errors.New("user does not exist")
 	ErrInstanceDoesNotExist = errors.New("instance does not exist")
 	ErrProfileDoesNotExist  = errors.New("profile does not exist")
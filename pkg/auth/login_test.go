@@ -0,0 +1,543 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/auth/totp"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	libtokens "github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/volatiletech/null/v8"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *MySuite) Test_refreshReused(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	tokenEnv := tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+		Issuer:            "auth",
+		Audience:          "test",
+	}
+	tokenAPI, err := tokens.Setup(tokenEnv)
+	require.CmpNoError(err)
+
+	userID := xid.New().String()
+	instanceID := xid.New().String()
+	refreshToken, expiresAt, err := tokenAPI.GenerateRefreshToken(userID, instanceID)
+	require.CmpNoError(err)
+
+	profile := &m.Profile{
+		ID:         xid.New().String(),
+		UserID:     userID,
+		InstanceID: instanceID,
+		Role:       null.StringFrom("teacher"),
+	}
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(userID), gomock.Eq(instanceID)).
+		Return(profile, nil)
+
+	family := xid.New().String()
+	mock.EXPECT().
+		GetToken(gomock.Any(), gomock.Eq(userID), gomock.Eq(profile.ID), gomock.Eq(tokens.Digest(refreshToken))).
+		Return(&m.Token{
+			ID:         1,
+			UserID:     userID,
+			ProfileID:  profile.ID,
+			Digest:     null.BytesFrom(tokens.Digest(refreshToken)),
+			ExpiresAt:  expiresAt,
+			Family:     family,
+			ConsumedAt: null.TimeFrom(time.Now()),
+		}, nil)
+
+	mock.EXPECT().
+		DeleteTokenFamily(gomock.Any(), gomock.Eq(family)).
+		Return(int64(2), nil)
+
+	service := Service{
+		Env: Env{
+			TokenEnv: tokenEnv,
+		},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{"refreshToken":"`+refreshToken+`"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	// when
+	accessToken, newRefreshToken, err := service.Refresh(ctx)
+
+	// then
+	assert.True(errors.Is(err, ErrTokenReused))
+	assert.Empty(accessToken)
+	assert.Empty(newRefreshToken)
+}
+
+func (s *MySuite) Test_refreshRotates(assert, require *td.T) {
+	// given a session with an unconsumed refresh token
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+
+	userID := xid.New().String()
+	instanceID := xid.New().String()
+	refreshToken, expiresAt, err := tokenAPI.GenerateRefreshToken(userID, instanceID)
+	require.CmpNoError(err)
+
+	profile := &m.Profile{ID: xid.New().String(), UserID: userID, InstanceID: instanceID, Role: null.StringFrom("teacher")}
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(userID), gomock.Eq(instanceID)).
+		Return(profile, nil).
+		AnyTimes()
+
+	// the token store consumes a token only once, like the conditional update of the database
+	var mu sync.Mutex
+	parent := &m.Token{ID: 1, UserID: userID, ProfileID: profile.ID, Digest: null.BytesFrom(tokens.Digest(refreshToken)), ExpiresAt: expiresAt, Family: xid.New().String()}
+	stored := m.TokenSlice{parent}
+	mock.EXPECT().
+		GetToken(gomock.Any(), gomock.Eq(userID), gomock.Eq(profile.ID), gomock.Eq(tokens.Digest(refreshToken))).
+		DoAndReturn(func(_ context.Context, _, _ string, _ []byte) (*m.Token, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			t := *parent
+			return &t, nil
+		}).
+		AnyTimes()
+	mock.EXPECT().
+		RotateToken(gomock.Any(), gomock.Any(), gomock.Len(32), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, presented *m.Token, digest []byte, expiresAt time.Time, accessJTI string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if presented.ID != parent.ID || parent.ConsumedAt.Valid {
+				return errors.WithStack(ErrTokenReused)
+			}
+			parent.ConsumedAt = null.TimeFrom(time.Now())
+			stored = append(stored, &m.Token{
+				ID:        int64(len(stored) + 1),
+				UserID:    presented.UserID,
+				ProfileID: presented.ProfileID,
+				Digest:    null.BytesFrom(digest),
+				ExpiresAt: expiresAt,
+				Family:    presented.Family,
+				ParentID:  null.Int64From(presented.ID),
+				AccessJti: null.StringFrom(accessJTI),
+			})
+			return nil
+		}).
+		AnyTimes()
+
+	// then a rotation of the consumed token revokes its family
+	mock.EXPECT().
+		DeleteTokenFamily(gomock.Any(), gomock.Eq(parent.Family)).
+		Return(int64(2), nil).
+		AnyTimes()
+
+	service := Service{
+		Env:      Env{TokenEnv: tokens.TokenEnv{Issuer: "auth", Audience: "test"}},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+	refresh := func() (string, string, error) {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{"refreshToken":"`+refreshToken+`"}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return service.Refresh(ctx)
+	}
+
+	// when the token is rotated concurrently
+	type result struct {
+		accessToken, refreshToken string
+		err                       error
+	}
+	results := make(chan result, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accessToken, refreshToken, err := refresh()
+			results <- result{accessToken, refreshToken, err}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// then only one rotation succeeds and the other one hands out no tokens
+	var rotated []result
+	for r := range results {
+		if r.err != nil {
+			assert.True(errors.Is(r.err, ErrTokenReused))
+			assert.Empty(r.accessToken)
+			assert.Empty(r.refreshToken)
+			continue
+		}
+		rotated = append(rotated, r)
+	}
+	require.Len(rotated, 1)
+
+	// and the presented token is consumed and the new one joins its family
+	assert.True(parent.ConsumedAt.Valid)
+	require.Len(stored, 2)
+	child := stored[1]
+	assert.Cmp(child.Family, parent.Family)
+	assert.Cmp(child.ParentID, null.Int64From(parent.ID))
+	assert.Cmp(child.Digest, null.BytesFrom(tokens.Digest(rotated[0].refreshToken)))
+
+	var claims libtokens.AccessTokenClaims
+	require.CmpNoError(libtokens.CheckAccessToken(context.Background(), rotated[0].accessToken, &claims, tokenAPI.ValidationKeys, "auth", "test"))
+	assert.Cmp(child.AccessJti, null.StringFrom(claims.ID))
+	assert.Cmp(claims.Role, "teacher")
+}
+
+func (s *MySuite) Test_refreshRightAfterLogin(assert, require *td.T) {
+	// given a token store where digests are unique, like in the database
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+
+	userID := xid.New().String()
+	instanceID := xid.New().String()
+	profile := &m.Profile{ID: xid.New().String(), UserID: userID, InstanceID: instanceID, Role: null.StringFrom("teacher")}
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(userID), gomock.Eq(instanceID)).
+		Return(profile, nil).
+		AnyTimes()
+
+	stored := map[string]*m.Token{}
+	store := func(t *m.Token) error {
+		if _, ok := stored[string(t.Digest.Bytes)]; ok {
+			return errors.New("duplicate digest")
+		}
+		t.ID = int64(len(stored) + 1)
+		stored[string(t.Digest.Bytes)] = t
+		return nil
+	}
+	mock.EXPECT().
+		SaveToken(gomock.Any(), gomock.Eq(profile), gomock.Len(32), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, profile *m.Profile, digest []byte, expiresAt, loggedInAt time.Time, accessJTI, _, _, _ string) error {
+			return store(&m.Token{UserID: profile.UserID, ProfileID: profile.ID, Digest: null.BytesFrom(digest), ExpiresAt: expiresAt, LoggedInAt: loggedInAt, Family: xid.New().String(), AccessJti: null.StringFrom(accessJTI)})
+		})
+	mock.EXPECT().
+		GetToken(gomock.Any(), gomock.Eq(userID), gomock.Eq(profile.ID), gomock.Len(32)).
+		DoAndReturn(func(_ context.Context, _, _ string, digest []byte) (*m.Token, error) {
+			t, ok := stored[string(digest)]
+			if !ok {
+				return nil, errors.WithStack(ErrTokenRevoked)
+			}
+			copied := *t
+			return &copied, nil
+		}).
+		AnyTimes()
+	mock.EXPECT().
+		RotateToken(gomock.Any(), gomock.Any(), gomock.Len(32), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, parent *m.Token, digest []byte, expiresAt time.Time, accessJTI string) error {
+			stored[string(parent.Digest.Bytes)].ConsumedAt = null.TimeFrom(time.Now())
+			return store(&m.Token{UserID: parent.UserID, ProfileID: parent.ProfileID, Digest: null.BytesFrom(digest), ExpiresAt: expiresAt, LoggedInAt: parent.LoggedInAt, Family: parent.Family, ParentID: null.Int64From(parent.ID), AccessJti: null.StringFrom(accessJTI)})
+		}).
+		AnyTimes()
+
+	service := Service{
+		Env:      Env{TokenEnv: tokens.TokenEnv{Issuer: "auth", Audience: "test"}},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/login", nil)
+	_, loginToken, _, err := service.startSession(ctx, userID, instanceID)
+	require.CmpNoError(err)
+	refresh := func(refreshToken string) (string, string, error) {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{"refreshToken":"`+refreshToken+`"}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return service.Refresh(ctx)
+	}
+
+	// when the session is refreshed within the second of the login
+	_, refreshToken, err := refresh(loginToken)
+
+	// then the rotated token differs from the presented one
+	require.CmpNoError(err)
+	assert.Not(refreshToken, loginToken)
+
+	// and is accepted right away
+	_, nextToken, err := refresh(refreshToken)
+	assert.CmpNoError(err)
+	assert.Not(nextToken, refreshToken)
+	assert.Len(stored, 3)
+}
+
+func (s *MySuite) Test_loginTwoFactor(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	tokenEnv := tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+		Issuer:            "auth",
+		Audience:          "test",
+	}
+	tokenAPI, err := tokens.Setup(tokenEnv)
+	require.CmpNoError(err)
+
+	userID := xid.New().String()
+	instanceID := xid.New().String()
+	challengeToken, err := tokenAPI.GenerateChallengeToken(userID, instanceID)
+	require.CmpNoError(err)
+
+	secret, err := totp.GenerateSecret()
+	require.CmpNoError(err)
+	step := totp.Step(time.Now())
+	code, err := totp.Code(secret, step)
+	require.CmpNoError(err)
+
+	mock.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(userID)).
+		Return(&m.User{ID: userID, Email: "simon@smartnuance.com"}, nil)
+	mock.EXPECT().
+		GetTOTPSecret(gomock.Any(), gomock.Eq(userID)).
+		Return(&m.TotpSecret{
+			UserID:      userID,
+			Secret:      secret,
+			ConfirmedAt: null.TimeFrom(time.Now().Add(-time.Hour)),
+		}, nil)
+
+	mock.EXPECT().
+		UseTOTPStep(gomock.Any(), gomock.Eq(userID), gomock.Eq(step)).
+		Return(nil)
+
+	profile := &m.Profile{
+		ID:         xid.New().String(),
+		UserID:     userID,
+		InstanceID: instanceID,
+		Role:       null.StringFrom("instance admin"),
+	}
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(userID), gomock.Eq(instanceID)).
+		Return(profile, nil)
+
+	mock.EXPECT().
+		SaveToken(gomock.Any(), gomock.Eq(profile), gomock.Len(32), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	service := Service{
+		Env: Env{
+			TokenEnv: tokenEnv,
+		},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/login/2fa", strings.NewReader(`{"challengeToken":"`+challengeToken+`","code":"`+code+`"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	// when
+	accessToken, refreshToken, role, recoveryCodes, err := service.LoginTwoFactor(ctx)
+
+	// then
+	assert.CmpNoError(err)
+	assert.NotEmpty(accessToken)
+	assert.NotEmpty(refreshToken)
+	assert.Cmp(role, roles.RoleInstanceAdmin)
+	assert.Nil(recoveryCodes)
+}
+
+func (s *MySuite) Test_loginRehashesBcrypt(assert, require *td.T) {
+	// given a user with a password hashed by bcrypt
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.CmpNoError(err)
+	user := &m.User{ID: xid.New().String(), Email: "simon@smartnuance.com", Password: hash}
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Return(user, nil)
+
+	// then the hash is upgraded to argon2id
+	var rehashed []byte
+	mock.EXPECT().
+		RehashPassword(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(hash), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _, newHash []byte) error {
+			rehashed = newHash
+			return nil
+		})
+
+	service := Service{DBAPI: mock}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	// when
+	_, err = service.loginWithCredentials(ctx, user.Email, "password")
+
+	// then
+	require.CmpNoError(err)
+	assert.HasPrefix(string(rehashed), "$argon2id$")
+	assert.CmpNoError(password.Compare(rehashed, "password"))
+	assert.False(password.DefaultHasher.NeedsRehash(rehashed))
+}
+
+func (s *MySuite) Test_loginWithoutInstance(assert, require *td.T) {
+	// given a user that is not verified yet with profiles in two instances, the first one requiring verification
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	tokenAPI, err := tokens.Setup(tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+	})
+	require.CmpNoError(err)
+
+	hasher := password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}
+	hash, err := hasher.Hash("password")
+	require.CmpNoError(err)
+	user := &m.User{ID: xid.New().String(), Email: "simon@smartnuance.com", Password: hash}
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Return(user, nil)
+
+	profile := func(instance *m.Instance, role roles.Role) *m.Profile {
+		p := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: instance.ID, Role: null.StringFrom(string(role))}
+		p.R = p.R.NewStruct()
+		p.R.Instance = instance
+		return p
+	}
+	verifying := &m.Instance{ID: xid.New().String(), Name: "Verifying", URL: "verifying.example.com", RequireVerification: true}
+	open := &m.Instance{ID: xid.New().String(), Name: "Open", URL: "open.example.com"}
+	openProfile := profile(open, roles.RoleTeacher)
+	mock.EXPECT().
+		ListProfiles(gomock.Any(), gomock.Eq(user.ID)).
+		Return(m.ProfileSlice{profile(verifying, roles.RoleInstanceAdmin), openProfile}, nil)
+
+	// then the session starts in the first instance the user can log in to
+	mock.EXPECT().
+		GetTOTPSecret(gomock.Any(), gomock.Eq(user.ID)).
+		Return(nil, errors.WithStack(ErrTwoFactorNotEnrolled))
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(open.ID)).
+		Return(openProfile, nil)
+	mock.EXPECT().
+		SaveToken(gomock.Any(), gomock.Eq(openProfile), gomock.Len(32), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	service := Service{
+		Env:      Env{PasswordHasher: hasher},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"email":"simon@smartnuance.com","password":"password"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	// when
+	accessToken, refreshToken, role, instances, challenge, err := service.Login(ctx)
+
+	// then
+	require.CmpNoError(err)
+	assert.NotEmpty(accessToken)
+	assert.NotEmpty(refreshToken)
+	assert.Nil(challenge)
+	assert.Cmp(role, roles.RoleTeacher)
+	assert.Cmp(instances, []UserInstanceResponse{
+		{ID: verifying.ID, Name: "Verifying", URL: "verifying.example.com", Role: roles.RoleInstanceAdmin},
+		{ID: open.ID, Name: "Open", URL: "open.example.com", Role: roles.RoleTeacher, Current: true},
+	})
+}
+
+func (s *MySuite) Test_switchInstance(assert, require *td.T) {
+	// given a session in one instance
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	tokenEnv := tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+		Issuer:            "auth",
+		Audience:          "test",
+	}
+	tokenAPI, err := tokens.Setup(tokenEnv)
+	require.CmpNoError(err)
+
+	user := &m.User{ID: xid.New().String(), Email: "simon@smartnuance.com"}
+	from := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: xid.New().String(), Role: null.StringFrom("teacher")}
+	refreshToken, expiresAt, err := tokenAPI.GenerateRefreshToken(user.ID, from.InstanceID)
+	require.CmpNoError(err)
+	token := &m.Token{ID: 1, UserID: user.ID, ProfileID: from.ID, Digest: null.BytesFrom(tokens.Digest(refreshToken)), ExpiresAt: expiresAt, Family: xid.New().String()}
+
+	other := &m.Instance{ID: xid.New().String(), URL: "other.example.com"}
+	to := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: other.ID, Role: null.StringFrom("event organizer")}
+
+	service := Service{
+		Env:      Env{TokenEnv: tokenEnv},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+	switchTo := func(instanceURL string) (string, string, roles.Role, *ChallengeResponse, error) {
+		mock.EXPECT().
+			GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(from.InstanceID)).
+			Return(from, nil)
+		mock.EXPECT().
+			GetToken(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(from.ID), gomock.Eq(tokens.Digest(refreshToken))).
+			Return(token, nil)
+		mock.EXPECT().
+			GetUser(gomock.Any(), gomock.Eq(user.ID)).
+			Return(user, nil)
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/refresh/instance", strings.NewReader(`{"refreshToken":"`+refreshToken+`","instance":"`+instanceURL+`"}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		return service.SwitchInstance(ctx)
+	}
+
+	// users can not switch to instances they have no profile in
+	foreign := &m.Instance{ID: xid.New().String(), URL: "foreign.example.com"}
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq(foreign.URL)).
+		Return(foreign, nil)
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(foreign.ID)).
+		Return(nil, errors.WithStack(ErrProfileDoesNotExist))
+	_, _, _, _, err = switchTo(foreign.URL)
+	assert.True(errors.Is(err, ErrProfileDoesNotExist))
+
+	// when switching to another instance of the user, then the presented session ends and a new one starts
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq(other.URL)).
+		Return(other, nil)
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(other.ID)).
+		Return(to, nil).
+		Times(2)
+	mock.EXPECT().
+		GetTOTPSecret(gomock.Any(), gomock.Eq(user.ID)).
+		Return(nil, errors.WithStack(ErrTwoFactorNotEnrolled))
+	mock.EXPECT().
+		DeleteTokenFamily(gomock.Any(), gomock.Eq(token.Family)).
+		Return(int64(1), nil)
+	mock.EXPECT().
+		SaveToken(gomock.Any(), gomock.Eq(to), gomock.Len(32), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+	accessToken, newRefreshToken, role, challenge, err := switchTo(other.URL)
+	require.CmpNoError(err)
+	assert.Nil(challenge)
+	assert.Cmp(role, roles.RoleEventOrganizer)
+	assert.NotEmpty(newRefreshToken)
+
+	var claims libtokens.AccessTokenClaims
+	require.CmpNoError(libtokens.CheckAccessToken(context.Background(), accessToken, &claims, tokenAPI.ValidationKeys, "auth", "test"))
+	assert.Cmp(claims.Instance, other.ID)
+}
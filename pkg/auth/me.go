@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+// MeResponse describes the authorized user
+type MeResponse struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	Email    string     `json:"email"`
+	Verified bool       `json:"verified"`
+	Instance string     `json:"instance"`
+	Role     roles.Role `json:"role"`
+}
+
+// Me returns the authorized user.
+func (s *Service) Me(ctx *gin.Context) (me MeResponse, err error) {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	me = MeResponse{
+		ID:       user.ID,
+		Name:     user.Name.String,
+		Email:    user.Email,
+		Verified: user.ActivatedAt.Valid,
+	}
+	me.Instance, err = roles.Instance(ctx)
+	if err != nil {
+		return
+	}
+	me.Role, err = roles.FromContext(ctx)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// UpdateMeBody describes the user attributes to change, absent attributes are left untouched
+type UpdateMeBody struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+// UpdateMe changes the authorized user's attributes.
+// A changed email only takes effect after it was verified.
+func (s *Service) UpdateMe(ctx *gin.Context) (me MeResponse, err error) {
+	var body UpdateMeBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	if body.Name != nil {
+		err = s.DBAPI.UpdateUserName(ctx, user.ID, *body.Name)
+		if err != nil {
+			return
+		}
+	}
+
+	if body.Email != nil && *body.Email != user.Email {
+		err = s.changeEmail(ctx, user, *body.Email)
+		if err != nil {
+			return
+		}
+	}
+
+	return s.Me(ctx)
+}
+
+func (s *Service) changeEmail(ctx *gin.Context, user *m.User, email string) (err error) {
+	err = checkEmail(email)
+	if err != nil {
+		return
+	}
+	_, err = s.DBAPI.FindUserByEmail(ctx, email)
+	if err == nil {
+		return errors.WithStack(ErrEmailTaken)
+	}
+	if !errors.Is(err, ErrUserDoesNotExist) {
+		return
+	}
+
+	var tx *sql.Tx
+	tx, err = s.DBAPI.BeginTx(ctx)
+	if err != nil {
+		return
+	}
+	token, err := s.createVerification(ctx, tx, user.ID, email)
+	if err != nil {
+		s.DBAPI.Rollback(tx)
+		return
+	}
+	err = s.DBAPI.Commit(tx)
+	if err != nil {
+		return
+	}
+
+	return s.sendVerification(ctx, email, token)
+}
+
+// ChangePasswordBody describes the current and the desired new password
+type ChangePasswordBody struct {
+	CurrentPassword string `json:"currentPassword"`
+	Password        string `json:"password"`
+}
+
+// ChangePassword replaces the authorized user's password.
+// All sessions of the user are revoked and a fresh session is returned for the current instance.
+func (s *Service) ChangePassword(ctx *gin.Context) (accessToken, refreshToken string, role roles.Role, err error) {
+	var body ChangePasswordBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	if len(body.Password) == 0 {
+		err = errors.WithStack(ErrInvalidPassword)
+		return
+	}
+
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	instanceID, err := roles.Instance(ctx)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, userID)
+	if err != nil {
+		return
+	}
+	err = password.Compare(user.Password, body.CurrentPassword)
+	if err != nil {
+		err = errors.WithStack(ErrInvalidCredentials)
+		return
+	}
+	// the password is shared by all profiles of the user, so it has to satisfy the policies of all their instances
+	policy, err := s.userPolicy(ctx, user)
+	if err != nil {
+		return
+	}
+	err = s.checkPassword(policy, body.Password)
+	if err != nil {
+		return
+	}
+
+	hashedPassword, err := s.hashPassword(body.Password)
+	if err != nil {
+		return
+	}
+	n, err := s.DBAPI.ChangePassword(ctx, user.ID, hashedPassword)
+	if err != nil {
+		return
+	}
+	s.syncDenylist(ctx)
+	log.Debug().Str("user", user.ID).Int64("revoked", n).Msg("password changed")
+
+	return s.startSession(ctx, user.ID, instanceID)
+}
+
+var (
+	ErrEmailTaken = errors.New("email is already used by another user")
+)
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	libtokens "github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/volatiletech/null/v8"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// meContext is the context of a request authorized for the user in the instance.
+func meContext(method, path, body, userID, instanceID string, role roles.Role) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(method, path, strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Set(roles.UserKey, userID)
+	ctx.Set(roles.InstanceKey, instanceID)
+	ctx.Set(roles.RoleKey, role)
+	return ctx
+}
+
+func (s *MySuite) Test_updateMe(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	instanceID := xid.New().String()
+	user := &m.User{ID: xid.New().String(), Name: null.StringFrom("Jane"), Email: "jane@example.com", ActivatedAt: null.TimeFrom(time.Now())}
+	mock.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Return(user, nil).
+		AnyTimes()
+
+	mailDir := require.TempDir()
+	service := Service{
+		Env:    Env{PublicURL: "http://localhost:8801"},
+		DBAPI:  mock,
+		Mailer: mail.FileSender{Dir: mailDir},
+	}
+	update := func(body string) (MeResponse, error) {
+		return service.UpdateMe(meContext(http.MethodPatch, "/me", body, user.ID, instanceID, roles.RoleTeacher))
+	}
+
+	// emails of other users are rejected
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq("taken@example.com")).
+		Return(&m.User{ID: xid.New().String(), Email: "taken@example.com"}, nil)
+	_, err := update(`{"email":"taken@example.com"}`)
+	assert.True(errors.Is(err, ErrEmailTaken))
+
+	// invalid emails are rejected
+	_, err = update(`{"email":"jane@example.com\r\nBcc: victim@example.com"}`)
+	assert.True(errors.Is(err, ErrInvalidEmail))
+
+	// when changing the name and the email
+	mock.EXPECT().
+		UpdateUserName(gomock.Any(), gomock.Eq(user.ID), gomock.Eq("Jane Doe")).
+		Return(nil)
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq("doe@example.com")).
+		Return(nil, errors.WithStack(ErrUserDoesNotExist))
+	mock.EXPECT().
+		BeginTx(gomock.Any()).
+		Return(nil, nil)
+	mock.EXPECT().
+		CreateVerification(gomock.Any(), gomock.Any(), gomock.Eq(user.ID), gomock.Eq("doe@example.com"), gomock.Len(32), gomock.Any()).
+		Return(nil)
+	mock.EXPECT().
+		Commit(gomock.Any()).
+		Return(nil)
+	me, err := update(`{"name":"Jane Doe","email":"doe@example.com"}`)
+
+	// then the email stays unchanged until the new email is verified
+	require.CmpNoError(err)
+	assert.Cmp(me.Email, "jane@example.com")
+	assert.True(me.Verified)
+
+	mails, err := filepath.Glob(filepath.Join(mailDir, "*.eml"))
+	require.CmpNoError(err)
+	require.Len(mails, 1)
+	content, err := ioutil.ReadFile(mails[0])
+	require.CmpNoError(err)
+	assert.Contains(string(content), "To: doe@example.com")
+	assert.Contains(string(content), "http://localhost:8801/verify?token=")
+}
+
+func (s *MySuite) Test_changePassword(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+
+	hasher := password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}
+	hash, err := hasher.Hash("current secret")
+	require.CmpNoError(err)
+	user := &m.User{ID: xid.New().String(), Email: "jane@example.com", Password: hash}
+	// the user has profiles in instances with different policies
+	instance := &m.Instance{ID: xid.New().String(), PasswordMinLength: 8, PasswordMaxLength: 72, PasswordMinClasses: 1}
+	strictInstance := &m.Instance{ID: xid.New().String(), PasswordMinLength: 10, PasswordMaxLength: 72, PasswordMinClasses: 2}
+	profile := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: instance.ID, Role: null.StringFrom("teacher")}
+	strictProfile := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: strictInstance.ID, Role: null.StringFrom("teacher")}
+	for p, i := range map[*m.Profile]*m.Instance{profile: instance, strictProfile: strictInstance} {
+		p.R = p.R.NewStruct()
+		p.R.Instance = i
+	}
+	mock.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Return(user, nil).
+		Times(3)
+	mock.EXPECT().
+		ListProfiles(gomock.Any(), gomock.Eq(user.ID)).
+		Return(m.ProfileSlice{profile, strictProfile}, nil).
+		Times(2)
+
+	service := Service{
+		Env:      Env{PasswordHasher: hasher},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+	}
+	change := func(body string) (string, string, roles.Role, error) {
+		return service.ChangePassword(meContext(http.MethodPut, "/me/password", body, user.ID, instance.ID, roles.RoleTeacher))
+	}
+
+	// a wrong current password changes nothing
+	_, _, _, err = change(`{"currentPassword":"wrong","password":"new secret"}`)
+	assert.True(errors.Is(err, ErrInvalidCredentials))
+
+	// a password allowed in the current instance, but not in another instance of the user, is rejected
+	_, _, _, err = change(`{"currentPassword":"current secret","password":"newsecret"}`)
+	var policyErr *PasswordPolicyError
+	assert.True(errors.As(err, &policyErr))
+
+	// when the current password is confirmed, then all other sessions are revoked together with the password change before a fresh session starts
+	gomock.InOrder(
+		mock.EXPECT().
+			ChangePassword(gomock.Any(), gomock.Eq(user.ID), gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ string, newHash []byte) (int64, error) {
+				assert.CmpNoError(password.Compare(newHash, "new secret 2"))
+				return 3, nil
+			}),
+		mock.EXPECT().
+			GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(instance.ID)).
+			Return(profile, nil),
+		mock.EXPECT().
+			SaveToken(gomock.Any(), gomock.Eq(profile), gomock.Len(32), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+	accessToken, refreshToken, role, err := change(`{"currentPassword":"current secret","password":"new secret 2"}`)
+	require.CmpNoError(err)
+	assert.NotEmpty(refreshToken)
+	assert.Cmp(role, roles.RoleTeacher)
+
+	var claims libtokens.AccessTokenClaims
+	require.CmpNoError(libtokens.CheckAccessToken(context.Background(), accessToken, &claims, tokenAPI.ValidationKeys, "auth", "test"))
+	assert.Cmp(claims.Subject, user.ID)
+	assert.Cmp(claims.Instance, instance.ID)
+}
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/lib/paging"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+// MemberResponse describes the profile of a user in an instance
+type MemberResponse struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"userID"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Role      roles.Role `json:"role"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// MemberList is a page of members
+type MemberList struct {
+	Items  []MemberResponse `json:"items"`
+	Paging *paging.Paging   `json:"paging"`
+}
+
+// MemberRoleBody describes the role to give a member
+type MemberRoleBody struct {
+	Role roles.Role `json:"role"`
+}
+
+// Members lists a page of the members of the authorized instance.
+func (s *Service) Members(ctx *gin.Context) (list MemberList, err error) {
+	instanceID, err := memberInstance(ctx)
+	if err != nil {
+		return
+	}
+	page := paging.FromQuery(ctx)
+	profiles, err := s.DBAPI.ListMembers(ctx, instanceID, page)
+	if err != nil {
+		return
+	}
+
+	list.Items = make([]MemberResponse, 0, len(profiles))
+	for _, p := range profiles {
+		list.Items = append(list.Items, memberResponse(p))
+	}
+	var first, last string
+	if n := len(list.Items); n > 0 {
+		first, last = list.Items[0].ID, list.Items[n-1].ID
+	}
+	list.Paging = paging.Loaded(page, first, last, len(list.Items))
+	return
+}
+
+// ChangeMemberRole gives a member of the authorized instance another role.
+// Both the current and the new role have to be roles the authorized user can act in, super admin is never granted.
+// The member's sessions end, so the new role takes effect with the next login.
+func (s *Service) ChangeMemberRole(ctx *gin.Context) (res MemberResponse, err error) {
+	var body MemberRoleBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	if !validRole(body.Role) {
+		err = errors.WithStack(roles.ErrInvalidRole)
+		return
+	}
+	profile, err := s.administrableMember(ctx)
+	if err != nil {
+		return
+	}
+	if !canGrant(ctx, body.Role) {
+		err = errors.WithStack(roles.ErrUnauthorized)
+		return
+	}
+
+	err = s.DBAPI.UpdateProfileRole(ctx, profile.ID, body.Role)
+	if err != nil {
+		return
+	}
+	n, err := s.DBAPI.DeleteToken(ctx, profile.ID)
+	if err != nil {
+		return
+	}
+	s.syncDenylist(ctx)
+	log.Debug().Str("profile", profile.ID).Str("role", string(body.Role)).Int64("revoked", n).Msg("member role changed")
+
+	profile.Role.SetValid(string(body.Role))
+	return memberResponse(profile), nil
+}
+
+// RemoveMember soft-deletes the profile of a member of the authorized instance and revokes its tokens and API keys.
+// The user keeps profiles in other instances.
+func (s *Service) RemoveMember(ctx *gin.Context) error {
+	profile, err := s.administrableMember(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := s.DBAPI.DeleteProfile(ctx, profile)
+	if err != nil {
+		return err
+	}
+	s.syncDenylist(ctx)
+	log.Debug().Str("profile", profile.ID).Int64("revoked", n).Msg("member removed")
+	return nil
+}
+
+// administrableMember loads the member of the request path if the authorized user can administrate it.
+// Users can not administrate themselves, so an instance does not lose its last admin by accident.
+func (s *Service) administrableMember(ctx *gin.Context) (*m.Profile, error) {
+	instanceID, err := memberInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.DBAPI.GetMember(ctx, instanceID, ctx.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+	if profile.UserID == userID {
+		return nil, errors.WithStack(ErrOwnProfile)
+	}
+	if !canGrant(ctx, roles.Role(profile.Role.String)) {
+		return nil, errors.WithStack(roles.ErrUnauthorized)
+	}
+	return profile, nil
+}
+
+// canGrant checks if the authorized user inherits role, which must not be super admin.
+func canGrant(ctx *gin.Context, role roles.Role) bool {
+	return role != roles.RoleSuperAdmin && roles.CanActIn(ctx, role)
+}
+
+// memberInstance returns the authorized instance if the user can administrate its members.
+func memberInstance(ctx *gin.Context) (string, error) {
+	if !roles.CanActIn(ctx, roles.RoleInstanceAdmin) {
+		return "", errors.WithStack(roles.ErrUnauthorized)
+	}
+	return roles.Instance(ctx)
+}
+
+func memberResponse(p *m.Profile) MemberResponse {
+	res := MemberResponse{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		Role:      roles.Role(p.Role.String),
+		CreatedAt: p.CreatedAt,
+	}
+	if p.R != nil && p.R.User != nil {
+		res.Name = p.R.User.Name.String
+		res.Email = p.R.User.Email
+	}
+	return res
+}
+
+var (
+	ErrOwnProfile = errors.New("users can not administrate their own profile")
+)
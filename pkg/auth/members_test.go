@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_changeMemberRole(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+
+	adminID := xid.New().String()
+	instanceID := xid.New().String()
+	member := func(userID string, role roles.Role) *m.Profile {
+		p := &m.Profile{ID: xid.New().String(), UserID: userID, InstanceID: instanceID, Role: null.StringFrom(string(role))}
+		mock.EXPECT().
+			GetMember(gomock.Any(), gomock.Eq(instanceID), gomock.Eq(p.ID)).
+			Return(p, nil)
+		return p
+	}
+	change := func(profileID string, body string) (MemberResponse, error) {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPatch, "/members/"+profileID, strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Params = gin.Params{{Key: "id", Value: profileID}}
+		ctx.Set(roles.UserKey, adminID)
+		ctx.Set(roles.RoleKey, roles.RoleInstanceAdmin)
+		ctx.Set(roles.InstanceKey, instanceID)
+		return service.ChangeMemberRole(ctx)
+	}
+
+	// admins can not escalate to super admin
+	teacher := member(xid.New().String(), roles.RoleTeacher)
+	_, err := change(teacher.ID, `{"role":"super admin"}`)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// admins can not demote members with roles they do not inherit
+	superAdmin := member(xid.New().String(), roles.RoleSuperAdmin)
+	_, err = change(superAdmin.ID, `{"role":"teacher"}`)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// admins can not change their own role
+	own := member(adminID, roles.RoleInstanceAdmin)
+	_, err = change(own.ID, `{"role":"teacher"}`)
+	assert.True(errors.Is(err, ErrOwnProfile))
+
+	// when promoting a teacher, then the teacher's sessions end
+	teacher = member(teacher.UserID, roles.RoleTeacher)
+	mock.EXPECT().
+		UpdateProfileRole(gomock.Any(), gomock.Eq(teacher.ID), gomock.Eq(roles.RoleEventOrganizer)).
+		Return(nil)
+	mock.EXPECT().
+		DeleteToken(gomock.Any(), gomock.Eq(teacher.ID)).
+		Return(int64(1), nil)
+	res, err := change(teacher.ID, `{"role":"event organizer"}`)
+	require.CmpNoError(err)
+	assert.Cmp(res.Role, roles.RoleEventOrganizer)
+}
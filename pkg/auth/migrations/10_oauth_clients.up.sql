@@ -0,0 +1,29 @@
+--Applications logging users in with the auth service as OpenID Connect provider.
+CREATE TABLE IF NOT EXISTS oauth_clients(
+  --id is the client_id
+  id char(20) PRIMARY KEY,
+  instance_id char(20) NOT NULL,
+  name text NOT NULL,
+  --digest of the client secret; public clients (native and browser apps) have none and rely on PKCE
+  secret bytea,
+  --space separated redirect URIs, matched exactly
+  redirect_uris text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_instance FOREIGN KEY(instance_id) REFERENCES instances(id) ON DELETE CASCADE
+);
+--Authorization codes issued to clients, to be redeemed once at the token endpoint.
+CREATE TABLE IF NOT EXISTS authorization_codes(
+  digest bytea PRIMARY KEY,
+  client_id char(20) NOT NULL,
+  user_id char(20) NOT NULL,
+  redirect_uri text NOT NULL,
+  scope text NOT NULL,
+  nonce text,
+  code_challenge text NOT NULL,
+  --time the user authenticated, as auth_time claim of ID tokens
+  auth_time timestamp with time zone NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  expires_at timestamp with time zone NOT NULL,
+  CONSTRAINT fk_client FOREIGN KEY(client_id) REFERENCES oauth_clients(id) ON DELETE CASCADE,
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+--Service accounts are clients with a role, authenticating with the client credentials grant.
+ALTER TABLE oauth_clients ADD COLUMN IF NOT EXISTS role text;
+--space separated scopes service accounts can request
+ALTER TABLE oauth_clients ADD COLUMN IF NOT EXISTS scopes text NOT NULL DEFAULT '';
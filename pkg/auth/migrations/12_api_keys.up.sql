@@ -0,0 +1,18 @@
+--Long-lived API keys users create for scripts, acting in a role of the user's profile in an instance.
+CREATE TABLE IF NOT EXISTS api_keys(
+  --id is part of the key, to look up the key before comparing its secret
+  id char(20) PRIMARY KEY,
+  user_id char(20) NOT NULL,
+  instance_id char(20) NOT NULL,
+  name text NOT NULL,
+  --digest of the key
+  digest bytea NOT NULL,
+  --role the key acts in, limited to roles of the user's profile
+  role text NOT NULL,
+  --space separated scopes the key is limited to; empty for no limitation beyond the role
+  scopes text NOT NULL DEFAULT '',
+  expires_at timestamp with time zone,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_instance FOREIGN KEY(instance_id) REFERENCES instances(id) ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+--Failed logins per account (email) and per client IP, to lock out brute-force attacks.
+CREATE TABLE IF NOT EXISTS login_attempts(
+  --"account:" followed by the email or "ip:" followed by the client IP
+  key text PRIMARY KEY,
+  --failed logins since the attempts were last forgotten
+  failures integer NOT NULL,
+  locked_until timestamp with time zone,
+  --time the failures are forgotten, if no further login fails
+  expires_at timestamp with time zone NOT NULL
+);
@@ -0,0 +1,3 @@
+ALTER TABLE instances DROP COLUMN IF EXISTS password_check_breached;
+ALTER TABLE instances DROP COLUMN IF EXISTS password_min_classes;
+ALTER TABLE instances DROP COLUMN IF EXISTS password_min_length;
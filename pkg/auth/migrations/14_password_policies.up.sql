@@ -0,0 +1,6 @@
+--Password policy per instance, applied when users sign up or change their password.
+ALTER TABLE instances ADD COLUMN password_min_length integer NOT NULL DEFAULT 8;
+--minimum number of character classes (lower case, upper case, digits, others) a password has to contain
+ALTER TABLE instances ADD COLUMN password_min_classes integer NOT NULL DEFAULT 1;
+--reject passwords on the breached password list, if configured
+ALTER TABLE instances ADD COLUMN password_check_breached boolean NOT NULL DEFAULT true;
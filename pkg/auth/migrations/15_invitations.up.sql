@@ -0,0 +1,18 @@
+--Invitations of instance admins for an email to join their instance with a role.
+CREATE TABLE IF NOT EXISTS invitations(
+  id char(20) PRIMARY KEY,
+  instance_id char(20) NOT NULL,
+  email text NOT NULL,
+  --role of the profile created when the invitation is accepted
+  role text NOT NULL,
+  --digest of the single-use token sent to the email
+  digest bytea NOT NULL UNIQUE,
+  --user who created the invitation
+  invited_by char(20),
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  expires_at timestamp with time zone NOT NULL,
+  CONSTRAINT fk_instance FOREIGN KEY(instance_id) REFERENCES instances(id) ON DELETE CASCADE,
+  CONSTRAINT fk_invited_by FOREIGN KEY(invited_by) REFERENCES users(id) ON DELETE SET NULL,
+  --inviting an email again replaces the pending invitation
+  CONSTRAINT invitation_email UNIQUE(instance_id, email)
+);
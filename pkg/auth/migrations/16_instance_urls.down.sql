@@ -0,0 +1 @@
+DROP INDEX IF EXISTS instance_url_unique_idx;
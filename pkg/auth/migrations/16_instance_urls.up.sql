@@ -0,0 +1,2 @@
+--URLs identify instances at login, so they have to be unique among instances that are not deleted.
+CREATE UNIQUE INDEX instance_url_unique_idx ON instances(url) WHERE deleted_at IS NULL;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS instance_deleted_idx;
+DROP INDEX IF EXISTS profile_deleted_idx;
+DROP INDEX IF EXISTS user_deleted_idx;
+DROP INDEX IF EXISTS user_email_unique_idx;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE(email);
+ALTER TABLE password_resets DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id);
+ALTER TABLE verifications DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id);
+ALTER TABLE tokens DROP CONSTRAINT fk_profile, ADD CONSTRAINT fk_profile FOREIGN KEY(profile_id) REFERENCES profiles(id);
+ALTER TABLE tokens DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id);
+ALTER TABLE profiles DROP CONSTRAINT fk_instance, ADD CONSTRAINT fk_instance FOREIGN KEY(instance_id) REFERENCES instances(id);
+ALTER TABLE profiles DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id);
@@ -0,0 +1,13 @@
+--Soft-deleted users, profiles and instances are purged after a retention window, taking their dependent rows with them.
+ALTER TABLE profiles DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE profiles DROP CONSTRAINT fk_instance, ADD CONSTRAINT fk_instance FOREIGN KEY(instance_id) REFERENCES instances(id) ON DELETE CASCADE;
+ALTER TABLE tokens DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE tokens DROP CONSTRAINT fk_profile, ADD CONSTRAINT fk_profile FOREIGN KEY(profile_id) REFERENCES profiles(id) ON DELETE CASCADE;
+ALTER TABLE verifications DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE password_resets DROP CONSTRAINT fk_user, ADD CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
+--emails of soft-deleted users can sign up again
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+CREATE UNIQUE INDEX user_email_unique_idx ON users(email) WHERE deleted_at IS NULL;
+CREATE INDEX user_deleted_idx ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX profile_deleted_idx ON profiles(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX instance_deleted_idx ON instances(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+--Erased users are kept anonymized instead of deleted, so data of other services can keep referring to them.
+ALTER TABLE users ADD COLUMN erased_at timestamp with time zone;
@@ -0,0 +1,3 @@
+--OAuth client a refresh token was issued to, so only that client can redeem it; tokens of first-party logins have none.
+ALTER TABLE tokens ADD COLUMN client_id char(20);
+ALTER TABLE tokens ADD CONSTRAINT fk_client FOREIGN KEY(client_id) REFERENCES oauth_clients(id) ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS verifications CASCADE;
+ALTER TABLE instances DROP COLUMN IF EXISTS require_verification;
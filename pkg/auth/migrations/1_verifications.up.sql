@@ -0,0 +1,10 @@
+ALTER TABLE instances ADD COLUMN require_verification boolean NOT NULL DEFAULT false;
+CREATE TABLE IF NOT EXISTS verifications(
+  id bigserial PRIMARY KEY,
+  user_id char(20) NOT NULL,
+  email text NOT NULL,
+  token bytea NOT NULL UNIQUE,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  expires_at timestamp with time zone NOT NULL,
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id)
+);
@@ -0,0 +1,2 @@
+--maximum length of passwords in bytes per instance, at most 72 as bcrypt ignores further bytes
+ALTER TABLE instances ADD COLUMN password_max_length integer NOT NULL DEFAULT 72;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS password_resets(
+  id bigserial PRIMARY KEY,
+  user_id char(20) NOT NULL,
+  token bytea NOT NULL UNIQUE,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  expires_at timestamp with time zone NOT NULL,
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS token_family_idx;
+ALTER TABLE tokens DROP COLUMN IF EXISTS consumed_at;
+ALTER TABLE tokens DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE tokens DROP COLUMN IF EXISTS family;
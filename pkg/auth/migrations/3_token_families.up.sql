@@ -0,0 +1,9 @@
+--Refresh tokens are rotated on each refresh, tokens descending from the same login form a family.
+ALTER TABLE tokens ADD COLUMN family char(20);
+UPDATE tokens SET family = lpad(id::text, 20, '0');
+ALTER TABLE tokens ALTER COLUMN family SET NOT NULL;
+ALTER TABLE tokens ADD COLUMN parent_id bigint;
+ALTER TABLE tokens ADD CONSTRAINT fk_parent FOREIGN KEY(parent_id) REFERENCES tokens(id) ON DELETE SET NULL;
+--A consumed token was already exchanged, presenting it again indicates theft.
+ALTER TABLE tokens ADD COLUMN consumed_at timestamp with time zone;
+CREATE INDEX token_family_idx ON tokens(family);
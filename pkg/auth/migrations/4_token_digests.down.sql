@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS token_idx;
+--Tokens only stored as digest cannot be restored.
+DELETE FROM tokens WHERE token IS NULL;
+ALTER TABLE tokens ALTER COLUMN token SET NOT NULL;
+ALTER TABLE tokens DROP COLUMN IF EXISTS digest;
+CREATE INDEX token_idx ON tokens(user_id, profile_id, token);
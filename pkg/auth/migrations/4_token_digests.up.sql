@@ -0,0 +1,6 @@
+--Refresh tokens are stored as SHA-256 digest only, so a database leak does not expose usable sessions.
+ALTER TABLE tokens ADD COLUMN digest bytea UNIQUE;
+--Plaintext tokens of existing rows are kept until converted by the hashtokens command.
+ALTER TABLE tokens ALTER COLUMN token DROP NOT NULL;
+DROP INDEX IF EXISTS token_idx;
+CREATE INDEX token_idx ON tokens(user_id, profile_id, digest);
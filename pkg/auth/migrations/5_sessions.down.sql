@@ -0,0 +1,3 @@
+ALTER TABLE tokens DROP COLUMN IF EXISTS logged_in_at;
+ALTER TABLE tokens DROP COLUMN IF EXISTS ip;
+ALTER TABLE tokens DROP COLUMN IF EXISTS user_agent;
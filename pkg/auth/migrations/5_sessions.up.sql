@@ -0,0 +1,6 @@
+--A session is a token family, the client of its login is shown when listing sessions.
+ALTER TABLE tokens ADD COLUMN user_agent text;
+ALTER TABLE tokens ADD COLUMN ip text;
+--Rotated tokens inherit the login time of their family.
+ALTER TABLE tokens ADD COLUMN logged_in_at timestamp with time zone NOT NULL DEFAULT NOW();
+UPDATE tokens SET logged_in_at = created_at;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS denied_tokens CASCADE;
+ALTER TABLE tokens DROP COLUMN IF EXISTS access_jti;
@@ -0,0 +1,7 @@
+--The access token issued together with a refresh token, it is denied when the refresh token is revoked.
+ALTER TABLE tokens ADD COLUMN access_jti text;
+--Revoked access tokens that have not yet expired.
+CREATE TABLE IF NOT EXISTS denied_tokens(
+  jti text PRIMARY KEY,
+  expires_at timestamp with time zone NOT NULL
+);
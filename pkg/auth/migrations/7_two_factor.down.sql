@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS recovery_codes CASCADE;
+DROP TABLE IF EXISTS totp_secrets CASCADE;
+ALTER TABLE instances DROP COLUMN IF EXISTS require_two_factor;
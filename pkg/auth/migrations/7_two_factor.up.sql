@@ -0,0 +1,21 @@
+--Instances can require two-factor authentication for roles at or above instance admin.
+ALTER TABLE instances ADD COLUMN require_two_factor boolean NOT NULL DEFAULT false;
+--The TOTP secret of a user, enrollment is pending until confirmed with a first code.
+CREATE TABLE IF NOT EXISTS totp_secrets(
+  user_id char(20) PRIMARY KEY,
+  secret text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  confirmed_at timestamp with time zone,
+  --The last accepted time step, codes of this or earlier steps are rejected to prevent replays.
+  last_step bigint NOT NULL DEFAULT 0,
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+--Single-use recovery codes, stored as SHA-256 digest.
+CREATE TABLE IF NOT EXISTS recovery_codes(
+  id bigserial PRIMARY KEY,
+  user_id char(20) NOT NULL,
+  code bytea NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX recovery_code_idx ON recovery_codes(user_id, code);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS passkey_challenges CASCADE;
+DROP TABLE IF EXISTS passkeys CASCADE;
@@ -0,0 +1,22 @@
+--WebAuthn credentials (passkeys) to log in without password.
+CREATE TABLE IF NOT EXISTS passkeys(
+  id bytea PRIMARY KEY,
+  user_id char(20) NOT NULL,
+  --COSE encoded public key
+  public_key bytea NOT NULL,
+  sign_count bigint NOT NULL DEFAULT 0,
+  name text,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  last_used_at timestamp with time zone,
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX passkey_user_idx ON passkeys(user_id);
+--Pending registration (with user) and login (without user) ceremonies.
+CREATE TABLE IF NOT EXISTS passkey_challenges(
+  id bigserial PRIMARY KEY,
+  user_id char(20),
+  challenge bytea NOT NULL UNIQUE,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  expires_at timestamp with time zone NOT NULL,
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
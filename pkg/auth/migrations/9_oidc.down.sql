@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS oidc_states CASCADE;
+DROP TABLE IF EXISTS identities CASCADE;
+DROP TABLE IF EXISTS identity_providers CASCADE;
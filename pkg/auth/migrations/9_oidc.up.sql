@@ -0,0 +1,41 @@
+--External OpenID Connect providers users of an instance can log in with.
+CREATE TABLE IF NOT EXISTS identity_providers(
+  id char(20) PRIMARY KEY,
+  instance_id char(20) NOT NULL,
+  --slug identifies the provider within the instance, e.g. google
+  slug text NOT NULL,
+  name text NOT NULL,
+  issuer text NOT NULL,
+  client_id text NOT NULL,
+  client_secret text NOT NULL,
+  --space separated scopes requested in addition to openid
+  scopes text NOT NULL DEFAULT 'email profile',
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_instance FOREIGN KEY(instance_id) REFERENCES instances(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX identity_provider_slug_idx ON identity_providers(instance_id, slug);
+--External identities linked to users.
+CREATE TABLE IF NOT EXISTS identities(
+  id bigserial PRIMARY KEY,
+  provider_id char(20) NOT NULL,
+  --subject is the user ID at the provider
+  subject text NOT NULL,
+  user_id char(20) NOT NULL,
+  email text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_provider FOREIGN KEY(provider_id) REFERENCES identity_providers(id) ON DELETE CASCADE,
+  CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX identity_subject_idx ON identities(provider_id, subject);
+CREATE INDEX identity_user_idx ON identities(user_id);
+--Pending logins redirected to a provider.
+CREATE TABLE IF NOT EXISTS oidc_states(
+  state text PRIMARY KEY,
+  provider_id char(20) NOT NULL,
+  nonce text NOT NULL,
+  --PKCE code verifier
+  verifier text NOT NULL,
+  created_at timestamp with time zone NOT NULL DEFAULT NOW(),
+  expires_at timestamp with time zone NOT NULL,
+  CONSTRAINT fk_provider FOREIGN KEY(provider_id) REFERENCES identity_providers(id) ON DELETE CASCADE
+);
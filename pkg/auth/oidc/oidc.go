@@ -0,0 +1,274 @@
+// Package oidc implements the client side of an OpenID Connect login with the authorization code flow and PKCE,
+// see https://openid.net/specs/openid-connect-core-1_0.html and RFC 7636.
+// Provider metadata is discovered from the issuer, ID tokens must be signed with RS256.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+// Timeout is the time a user has to complete a login at the provider.
+const Timeout = 10 * time.Minute
+
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// Config is the client registration at a provider.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the registered URL the provider redirects back to
+	RedirectURL string
+	// Scopes are requested in addition to openid
+	Scopes []string
+}
+
+// Discovery is the subset of provider metadata used by the authorization code flow.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint,omitempty"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is a discovered provider with a client registration.
+type Provider struct {
+	Discovery
+	Config
+	Client *http.Client
+
+	keys *tokens.RemoteKeySet
+}
+
+// Discover fetches the metadata of the provider identified by issuer.
+func Discover(ctx context.Context, client *http.Client, issuer string, config Config) (*Provider, error) {
+	var discovery Discovery
+	err := getJSON(ctx, client, strings.TrimSuffix(issuer, "/")+DiscoveryPath, &discovery)
+	if err != nil {
+		return nil, errors.Wrap(err, "discovery failed")
+	}
+	// the issuer must match exactly to prevent a provider from impersonating another one
+	if discovery.Issuer != issuer {
+		return nil, errors.Wrapf(ErrInvalidDiscovery, "issuer %s", discovery.Issuer)
+	}
+	if len(discovery.AuthorizationEndpoint) == 0 || len(discovery.TokenEndpoint) == 0 || len(discovery.JWKSURI) == 0 {
+		return nil, errors.Wrap(ErrInvalidDiscovery, "missing endpoints")
+	}
+
+	keys := tokens.NewRemoteKeySet(tokens.ValidationEnv{JWKSURL: discovery.JWKSURI})
+	keys.Client = client
+	return &Provider{Discovery: discovery, Config: config, Client: client, keys: keys}, nil
+}
+
+// GenerateRandom creates a random value usable as state, nonce or code verifier.
+func GenerateRandom() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Wrap(err, "generating random value failed")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge derives the S256 code challenge from a code verifier.
+func CodeChallenge(verifier string) string {
+	d := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(d[:])
+}
+
+// AuthCodeURL builds the URL to redirect the user to for logging in at the provider.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.ClientID},
+		"redirect_uri":          {p.RedirectURL},
+		"scope":                 {strings.Join(append([]string{"openid"}, p.Scopes...), " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {CodeChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.AuthorizationEndpoint + sep + q.Encode()
+}
+
+// TokenResponse is the response of the token endpoint.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	IDToken      string `json:"id_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// Exchange redeems an authorization code for the raw ID token.
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "code exchange failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", errors.Wrapf(ErrExchangeFailed, "status %d: %s", resp.StatusCode, body)
+	}
+
+	var res TokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return "", errors.Wrap(ErrExchangeFailed, err.Error())
+	}
+	if len(res.IDToken) == 0 {
+		return "", errors.Wrap(ErrExchangeFailed, "missing id token")
+	}
+	return res.IDToken, nil
+}
+
+// Bool is a boolean claim some providers encode as string.
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*b = s == "true"
+		return nil
+	}
+	var v bool
+	err := json.Unmarshal(data, &v)
+	*b = Bool(v)
+	return err
+}
+
+// IDTokenClaims are the claims of an ID token identifying the user.
+type IDTokenClaims struct {
+	Nonce           string           `json:"nonce,omitempty"`
+	AuthorizedParty string           `json:"azp,omitempty"`
+	AuthTime        *jwt.NumericDate `json:"auth_time,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	EmailVerified   Bool             `json:"email_verified"`
+	Name            string           `json:"name,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Verify validates signature, issuer, audience, expiry and nonce of a raw ID token.
+func (p *Provider) Verify(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	var claims IDTokenClaims
+	token, err := jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("invalid token signing method: %s", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err.Error())
+	}
+	if !token.Valid || claims.ExpiresAt == nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, "invalid claims")
+	}
+	if !claims.VerifyIssuer(p.Issuer, true) {
+		return nil, errors.Wrap(ErrInvalidIDToken, "issuer mismatch")
+	}
+	if !claims.VerifyAudience(p.ClientID, true) {
+		return nil, errors.Wrap(ErrInvalidIDToken, "audience mismatch")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.ClientID {
+		return nil, errors.Wrap(ErrInvalidIDToken, "authorized party mismatch")
+	}
+	if len(claims.Subject) == 0 {
+		return nil, errors.Wrap(ErrInvalidIDToken, "missing subject")
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.Wrap(ErrInvalidIDToken, "nonce mismatch")
+	}
+	return &claims, nil
+}
+
+// Registry caches discovered providers by issuer.
+type Registry struct {
+	Client *http.Client
+
+	mu        sync.Mutex
+	providers map[string]*Provider
+}
+
+func NewRegistry(client *http.Client) *Registry {
+	return &Registry{Client: client, providers: map[string]*Provider{}}
+}
+
+// Provider returns the provider of issuer with the given client registration, discovering it on first use.
+func (r *Registry) Provider(ctx context.Context, issuer string, config Config) (*Provider, error) {
+	r.mu.Lock()
+	cached, ok := r.providers[issuer]
+	r.mu.Unlock()
+	if ok {
+		// instances share a discovered provider, but not their client registration
+		p := *cached
+		p.Config = config
+		return &p, nil
+	}
+
+	p, err := Discover(ctx, r.Client, issuer, config)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.providers[issuer] = p
+	r.mu.Unlock()
+	return p, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+var (
+	ErrInvalidDiscovery = errors.New("invalid provider metadata")
+	ErrExchangeFailed   = errors.New("authorization code exchange failed")
+	ErrInvalidIDToken   = errors.New("invalid id token")
+)
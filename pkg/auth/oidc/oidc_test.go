@@ -0,0 +1,110 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc/oidctest"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+var config = oidc.Config{
+	ClientID:     "saas-kit",
+	ClientSecret: "secret",
+	RedirectURL:  "http://localhost:8801/login/oidc/callback",
+	Scopes:       []string{"email", "profile"},
+}
+
+func (s *MySuite) Test_login(assert, require *td.T) {
+	// given
+	idp, err := oidctest.New(config.ClientID, config.ClientSecret)
+	require.CmpNoError(err)
+	defer idp.Close()
+	idp.User = oidctest.User{Subject: "1234", Email: "simon@smartnuance.com", EmailVerified: true, Name: "Simon"}
+
+	provider, err := oidc.NewRegistry(idp.Client()).Provider(context.Background(), idp.Issuer(), config)
+	require.CmpNoError(err)
+
+	state, err := oidc.GenerateRandom()
+	require.CmpNoError(err)
+	nonce, err := oidc.GenerateRandom()
+	require.CmpNoError(err)
+	verifier, err := oidc.GenerateRandom()
+	require.CmpNoError(err)
+
+	// when
+	callback, err := idp.Authorize(provider.AuthCodeURL(state, nonce, verifier))
+	require.CmpNoError(err)
+	assert.Cmp(callback.Get("state"), state)
+	rawIDToken, err := provider.Exchange(context.Background(), callback.Get("code"), verifier)
+	require.CmpNoError(err)
+	claims, err := provider.Verify(context.Background(), rawIDToken, nonce)
+
+	// then
+	require.CmpNoError(err)
+	assert.Cmp(claims.Subject, "1234")
+	assert.Cmp(claims.Email, "simon@smartnuance.com")
+	assert.True(bool(claims.EmailVerified))
+	assert.Cmp(claims.Name, "Simon")
+
+	// when replaying the code
+	_, err = provider.Exchange(context.Background(), callback.Get("code"), verifier)
+
+	// then
+	assert.True(errors.Is(err, oidc.ErrExchangeFailed))
+}
+
+func (s *MySuite) Test_rejectInvalidLogins(assert, require *td.T) {
+	idp, err := oidctest.New(config.ClientID, config.ClientSecret)
+	require.CmpNoError(err)
+	defer idp.Close()
+	idp.User = oidctest.User{Subject: "1234"}
+	provider, err := oidc.Discover(context.Background(), idp.Client(), idp.Issuer(), config)
+	require.CmpNoError(err)
+
+	// wrong code verifier
+	callback, err := idp.Authorize(provider.AuthCodeURL("state", "nonce", "verifier"))
+	require.CmpNoError(err)
+	_, err = provider.Exchange(context.Background(), callback.Get("code"), "other")
+	assert.True(errors.Is(err, oidc.ErrExchangeFailed))
+
+	// wrong nonce
+	callback, err = idp.Authorize(provider.AuthCodeURL("state", "nonce", "verifier"))
+	require.CmpNoError(err)
+	rawIDToken, err := provider.Exchange(context.Background(), callback.Get("code"), "verifier")
+	require.CmpNoError(err)
+	_, err = provider.Verify(context.Background(), rawIDToken, "other")
+	assert.True(errors.Is(err, oidc.ErrInvalidIDToken))
+
+	// token of another client
+	other := *provider
+	other.ClientID = "other"
+	_, err = other.Verify(context.Background(), rawIDToken, "nonce")
+	assert.True(errors.Is(err, oidc.ErrInvalidIDToken))
+
+	// token of another provider
+	foreign, err := oidctest.New(config.ClientID, config.ClientSecret)
+	require.CmpNoError(err)
+	defer foreign.Close()
+	rawIDToken, err = foreign.IDToken(idp.User, "nonce")
+	require.CmpNoError(err)
+	_, err = provider.Verify(context.Background(), rawIDToken, "nonce")
+	assert.True(errors.Is(err, oidc.ErrInvalidIDToken))
+
+	// wrong client secret
+	other = *provider
+	other.ClientSecret = "other"
+	callback, err = idp.Authorize(provider.AuthCodeURL("state", "nonce", "verifier"))
+	require.CmpNoError(err)
+	_, err = other.Exchange(context.Background(), callback.Get("code"), "verifier")
+	assert.True(errors.Is(err, oidc.ErrExchangeFailed))
+}
@@ -0,0 +1,200 @@
+// Package oidctest provides a stand-in OpenID Connect provider on httptest to test logins offline.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+// User is the identity of the user logging in at the provider.
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider authenticates the configured User without interaction, so the authorization endpoint redirects back right away.
+type Provider struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+	User         User
+
+	key      *rsa.PrivateKey
+	mu       sync.Mutex
+	requests map[string]authRequest
+}
+
+type authRequest struct {
+	redirectURI string
+	nonce       string
+	challenge   string
+	user        User
+}
+
+func New(clientID, clientSecret string) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	p := &Provider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		key:          key,
+		requests:     map[string]authRequest{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidc.DiscoveryPath, p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc(tokens.JWKSPath, p.jwks)
+	p.Server = httptest.NewServer(mux)
+	return p, nil
+}
+
+// Issuer is the issuer URL to discover the provider with.
+func (p *Provider) Issuer() string {
+	return p.URL
+}
+
+// Authorize follows the authorization URL like a browser and returns the query the provider redirects back with.
+func (p *Provider) Authorize(authURL string) (url.Values, error) {
+	client := p.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		return nil, errors.Errorf("authorization failed with status %d", resp.StatusCode)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		return nil, err
+	}
+	return location.Query(), nil
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Discovery{
+		Issuer:                p.URL,
+		AuthorizationEndpoint: p.URL + "/authorize",
+		TokenEndpoint:         p.URL + "/token",
+		JWKSURI:               p.URL + tokens.JWKSPath,
+	})
+}
+
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || q.Get("client_id") != p.ClientID || q.Get("response_type") != "code" ||
+		q.Get("code_challenge_method") != "S256" || len(q.Get("code_challenge")) == 0 {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code, err := oidc.GenerateRandom()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p.mu.Lock()
+	p.requests[code] = authRequest{
+		redirectURI: redirectURI.String(),
+		nonce:       q.Get("nonce"),
+		challenge:   q.Get("code_challenge"),
+		user:        p.User,
+	}
+	p.mu.Unlock()
+
+	callback := redirectURI.Query()
+	callback.Set("code", code)
+	callback.Set("state", q.Get("state"))
+	redirectURI.RawQuery = callback.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != p.ClientID || clientSecret != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostFormValue("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	// codes are single use
+	code := r.PostFormValue("code")
+	p.mu.Lock()
+	req, ok := p.requests[code]
+	delete(p.requests, code)
+	p.mu.Unlock()
+	if !ok || req.redirectURI != r.PostFormValue("redirect_uri") ||
+		oidc.CodeChallenge(r.PostFormValue("code_verifier")) != req.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken, err := p.IDToken(req.user, req.nonce)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, oidc.TokenResponse{
+		AccessToken: code,
+		TokenType:   "Bearer",
+		IDToken:     idToken,
+		ExpiresIn:   3600,
+	})
+}
+
+// IDToken signs an ID token for the user.
+func (p *Provider) IDToken(user User, nonce string) (string, error) {
+	now := time.Now()
+	claims := oidc.IDTokenClaims{
+		Nonce:         nonce,
+		Email:         user.Email,
+		EmailVerified: oidc.Bool(user.EmailVerified),
+		Name:          user.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    p.URL,
+			Subject:   user.Subject,
+			Audience:  jwt.ClaimStrings{p.ClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = tokens.KeyID(&p.key.PublicKey)
+	return token.SignedString(p.key)
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, tokens.JWKS{
+		Keys: []tokens.JWK{tokens.NewJWK(tokens.KeyID(&p.key.PublicKey), &p.key.PublicKey)},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+// OIDCLoginBody selects the provider to log in with
+type OIDCLoginBody struct {
+	InstanceURL string `json:"instance"`
+	Provider    string `json:"provider"`
+}
+
+// OIDCCallbackQuery describes the query a provider redirects back with
+type OIDCCallbackQuery struct {
+	State string `form:"state"`
+	Code  string `form:"code"`
+	Error string `form:"error"`
+}
+
+// IdentityProviderResponse describes a provider users of an instance can log in with
+type IdentityProviderResponse struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// IdentityProviders lists the providers of an instance for its login page.
+func (s *Service) IdentityProviders(ctx *gin.Context) ([]IdentityProviderResponse, error) {
+	instance, err := s.DBAPI.GetInstance(ctx, ctx.Query("instance"))
+	if err != nil {
+		return nil, err
+	}
+	providers, err := s.DBAPI.ListIdentityProviders(ctx, instance.ID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]IdentityProviderResponse, 0, len(providers))
+	for _, p := range providers {
+		res = append(res, IdentityProviderResponse{Slug: p.Slug, Name: p.Name})
+	}
+	return res, nil
+}
+
+// OIDCLogin starts a login at a provider of the instance and returns the URL to redirect the user to.
+func (s *Service) OIDCLogin(ctx *gin.Context) (authorizationURL string, err error) {
+	var body OIDCLoginBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	instance, err := s.DBAPI.GetInstance(ctx, body.InstanceURL)
+	if err != nil {
+		return
+	}
+	idp, err := s.DBAPI.GetIdentityProvider(ctx, instance.ID, body.Provider)
+	if err != nil {
+		return
+	}
+	provider, err := s.oidcProvider(ctx, idp)
+	if err != nil {
+		return
+	}
+
+	state := &m.OidcState{
+		ProviderID: idp.ID,
+		ExpiresAt:  time.Now().Add(oidc.Timeout),
+	}
+	for _, v := range []*string{&state.State, &state.Nonce, &state.Verifier} {
+		*v, err = oidc.GenerateRandom()
+		if err != nil {
+			return
+		}
+	}
+	err = s.DBAPI.CreateOIDCState(ctx, state)
+	if err != nil {
+		return
+	}
+
+	return provider.AuthCodeURL(state.State, state.Nonce, state.Verifier), nil
+}
+
+// OIDCCallback completes a login at a provider and returns the same fresh set of tokens or challenge as Login.
+// The external identity is linked to the user with the same verified email on first login.
+func (s *Service) OIDCCallback(ctx *gin.Context) (accessToken, refreshToken string, role roles.Role, challenge *ChallengeResponse, err error) {
+	var query OIDCCallbackQuery
+	err = ctx.ShouldBindQuery(&query)
+	if err != nil {
+		return
+	}
+	if len(query.Error) > 0 {
+		err = errors.Wrap(ErrOIDCLoginDenied, query.Error)
+		return
+	}
+
+	state, err := s.DBAPI.ConsumeOIDCState(ctx, query.State)
+	if err != nil {
+		return
+	}
+	idp := state.R.Provider
+	provider, err := s.oidcProvider(ctx, idp)
+	if err != nil {
+		return
+	}
+	rawIDToken, err := provider.Exchange(ctx, query.Code, state.Verifier)
+	if err != nil {
+		return
+	}
+	claims, err := provider.Verify(ctx, rawIDToken, state.Nonce)
+	if err != nil {
+		return
+	}
+
+	user, err := s.linkIdentity(ctx, idp, claims)
+	if err != nil {
+		return
+	}
+
+	instance := idp.R.Instance
+	if instance.RequireVerification && !user.ActivatedAt.Valid {
+		err = errors.WithStack(ErrUserNotActivated)
+		return
+	}
+
+	challenge, err = s.twoFactorChallenge(ctx, user, instance)
+	if err != nil || challenge != nil {
+		return
+	}
+
+	accessToken, refreshToken, role, err = s.startSession(ctx, user.ID, instance.ID)
+	return
+}
+
+// linkIdentity returns the user an external identity is linked to, linking it by verified email on first login.
+func (s *Service) linkIdentity(ctx *gin.Context, idp *m.IdentityProvider, claims *oidc.IDTokenClaims) (*m.User, error) {
+	identity, err := s.DBAPI.GetIdentity(ctx, idp.ID, claims.Subject)
+	if err == nil {
+		return s.DBAPI.GetUser(ctx, identity.UserID)
+	}
+	if !errors.Is(err, ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	// an unverified email could be anyone's, so linking it would allow taking over accounts
+	if len(claims.Email) == 0 || !claims.EmailVerified {
+		return nil, errors.WithStack(ErrEmailNotVerified)
+	}
+	return s.DBAPI.LinkIdentity(ctx, &m.Identity{
+		ProviderID: idp.ID,
+		Subject:    claims.Subject,
+		Email:      claims.Email,
+	}, idp.InstanceID, claims.Name)
+}
+
+func (s *Service) oidcProvider(ctx *gin.Context, idp *m.IdentityProvider) (*oidc.Provider, error) {
+	return s.OIDC.Provider(ctx, idp.Issuer, oidc.Config{
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  s.OIDCRedirectURL,
+		Scopes:       strings.Fields(idp.Scopes),
+	})
+}
+
+var (
+	ErrIdentityProviderNotFound = errors.New("identity provider not found")
+	ErrIdentityNotFound         = errors.New("identity not found")
+	ErrOIDCStateInvalid         = errors.New("login state invalid or expired")
+	ErrOIDCLoginDenied          = errors.New("login denied by identity provider")
+	ErrEmailNotVerified         = errors.New("email not verified by identity provider")
+)
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc/oidctest"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_oidcLoginLinksByEmail(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	idp, err := oidctest.New("saas-kit", "secret")
+	require.CmpNoError(err)
+	defer idp.Close()
+	idp.User = oidctest.User{Subject: "1234", Email: "simon@smartnuance.com", EmailVerified: true, Name: "Simon"}
+
+	tokenEnv := tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+		Issuer:            "auth",
+		Audience:          "test",
+	}
+	tokenAPI, err := tokens.Setup(tokenEnv)
+	require.CmpNoError(err)
+
+	instance := &m.Instance{ID: xid.New().String(), URL: "smartnuance.com"}
+	provider := &m.IdentityProvider{
+		ID:           xid.New().String(),
+		InstanceID:   instance.ID,
+		Slug:         "test",
+		Issuer:       idp.Issuer(),
+		ClientID:     "saas-kit",
+		ClientSecret: "secret",
+		Scopes:       "email profile",
+	}
+	provider.R = provider.R.NewStruct()
+	provider.R.Instance = instance
+	user := &m.User{ID: xid.New().String(), Email: "simon@smartnuance.com", ActivatedAt: null.TimeFrom(time.Now())}
+	profile := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: instance.ID, Role: null.StringFrom("teacher")}
+
+	var state *m.OidcState
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq(instance.URL)).
+		Return(instance, nil)
+	mock.EXPECT().
+		GetIdentityProvider(gomock.Any(), gomock.Eq(instance.ID), gomock.Eq("test")).
+		Return(provider, nil)
+	mock.EXPECT().
+		CreateOIDCState(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, s *m.OidcState) error {
+			state = s
+			return nil
+		})
+
+	service := Service{
+		Env: Env{
+			TokenEnv:        tokenEnv,
+			OIDCRedirectURL: "http://localhost:8801/login/oidc/callback",
+		},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+		OIDC:     oidc.NewRegistry(idp.Client()),
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/login/oidc", strings.NewReader(`{"instance":"smartnuance.com","provider":"test"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	// when starting the login
+	authorizationURL, err := service.OIDCLogin(ctx)
+
+	// then
+	require.CmpNoError(err)
+	callback, err := idp.Authorize(authorizationURL)
+	require.CmpNoError(err)
+	assert.Cmp(callback.Get("state"), state.State)
+
+	// given
+	state.R = state.R.NewStruct()
+	state.R.Provider = provider
+	mock.EXPECT().
+		ConsumeOIDCState(gomock.Any(), gomock.Eq(state.State)).
+		Return(state, nil)
+	mock.EXPECT().
+		GetIdentity(gomock.Any(), gomock.Eq(provider.ID), gomock.Eq("1234")).
+		Return(nil, ErrIdentityNotFound)
+	mock.EXPECT().
+		LinkIdentity(gomock.Any(), gomock.Eq(&m.Identity{ProviderID: provider.ID, Subject: "1234", Email: user.Email}), gomock.Eq(instance.ID), gomock.Eq("Simon")).
+		Return(user, nil)
+	mock.EXPECT().
+		GetTOTPSecret(gomock.Any(), gomock.Eq(user.ID)).
+		Return(nil, ErrTwoFactorNotEnrolled)
+	mock.EXPECT().
+		GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(instance.ID)).
+		Return(profile, nil)
+	mock.EXPECT().
+		SaveToken(gomock.Any(), gomock.Eq(profile), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	ctx, _ = gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/login/oidc/callback?"+callback.Encode(), nil)
+
+	// when completing the login
+	accessToken, refreshToken, role, challenge, err := service.OIDCCallback(ctx)
+
+	// then
+	require.CmpNoError(err)
+	assert.Nil(challenge)
+	assert.NotEmpty(accessToken)
+	assert.NotEmpty(refreshToken)
+	assert.Cmp(role, roles.Role("teacher"))
+}
+
+func (s *MySuite) Test_oidcLoginRequiresVerifiedEmail(assert, require *td.T) {
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	provider := &m.IdentityProvider{ID: xid.New().String()}
+	mock.EXPECT().
+		GetIdentity(gomock.Any(), gomock.Eq(provider.ID), gomock.Eq("1234")).
+		Return(nil, ErrIdentityNotFound)
+
+	claims := &oidc.IDTokenClaims{Email: "simon@smartnuance.com", EmailVerified: false}
+	claims.Subject = "1234"
+	_, err := service.linkIdentity(ctx, provider, claims)
+	assert.True(errors.Is(err, ErrEmailNotVerified))
+}
@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/webauthn"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/volatiletech/null/v8"
+)
+
+// RegisterPasskeyBody describes the authenticator's response to a registration
+type RegisterPasskeyBody struct {
+	Name     string                       `json:"name"`
+	Response webauthn.AttestationResponse `json:"response"`
+}
+
+// LoginPasskeyBody describes the authenticator's response to a login
+type LoginPasskeyBody struct {
+	InstanceURL string                     `json:"instance"`
+	RawID       webauthn.Base64URL         `json:"rawId"`
+	Response    webauthn.AssertionResponse `json:"response"`
+}
+
+// PasskeyResponse describes a registered passkey
+type PasskeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+// PasskeyRegistrationOptions starts the registration of a passkey for the authorized user.
+func (s *Service) PasskeyRegistrationOptions(ctx *gin.Context) (options webauthn.CreationOptions, err error) {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, userID)
+	if err != nil {
+		return
+	}
+	passkeys, err := s.DBAPI.ListPasskeys(ctx, userID)
+	if err != nil {
+		return
+	}
+	exclude := make([][]byte, 0, len(passkeys))
+	for _, p := range passkeys {
+		exclude = append(exclude, p.ID)
+	}
+
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.CreatePasskeyChallenge(ctx, userID, challenge, time.Now().Add(webauthn.Timeout))
+	if err != nil {
+		return
+	}
+
+	displayName := user.Email
+	if user.Name.Valid {
+		displayName = user.Name.String
+	}
+	options = s.RelyingParty.CreationOptions(challenge, user.ID, user.Email, displayName, exclude)
+	return
+}
+
+// RegisterPasskey completes the registration of a passkey for the authorized user.
+func (s *Service) RegisterPasskey(ctx *gin.Context) (passkey PasskeyResponse, err error) {
+	var body RegisterPasskeyBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		err = errors.WithStack(ErrMissingPasskeyResponse)
+		return
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+
+	challenge, err := webauthn.ClientChallenge(body.Response.ClientDataJSON)
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.ConsumePasskeyChallenge(ctx, challenge, userID)
+	if err != nil {
+		return
+	}
+	cred, err := s.RelyingParty.VerifyRegistration(body.Response, challenge)
+	if err != nil {
+		return
+	}
+
+	p := &m.Passkey{
+		ID:        cred.ID,
+		UserID:    userID,
+		PublicKey: cred.PublicKey,
+		SignCount: int64(cred.SignCount),
+		Name:      null.NewString(body.Name, len(body.Name) > 0),
+	}
+	err = s.DBAPI.CreatePasskey(ctx, p)
+	if err != nil {
+		return
+	}
+	passkey = passkeyResponse(p)
+	return
+}
+
+// Passkeys lists the passkeys of the authorized user.
+func (s *Service) Passkeys(ctx *gin.Context) ([]PasskeyResponse, error) {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := s.DBAPI.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]PasskeyResponse, 0, len(passkeys))
+	for _, p := range passkeys {
+		res = append(res, passkeyResponse(p))
+	}
+	return res, nil
+}
+
+// DeletePasskey removes a passkey of the authorized user.
+func (s *Service) DeletePasskey(ctx *gin.Context) error {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := base64.RawURLEncoding.DecodeString(ctx.Param("id"))
+	if err != nil {
+		return errors.WithStack(ErrPasskeyNotFound)
+	}
+	n, err := s.DBAPI.DeletePasskey(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrPasskeyNotFound)
+	}
+	return nil
+}
+
+// PasskeyLoginOptions starts a login with any passkey.
+func (s *Service) PasskeyLoginOptions(ctx *gin.Context) (options webauthn.RequestOptions, err error) {
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.CreatePasskeyChallenge(ctx, "", challenge, time.Now().Add(webauthn.Timeout))
+	if err != nil {
+		return
+	}
+	options = s.RelyingParty.RequestOptions(challenge)
+	return
+}
+
+// LoginPasskey completes a login with a passkey and returns the same fresh set of tokens as Login.
+// Passkeys require user verification by the authenticator, so no second factor is asked for.
+func (s *Service) LoginPasskey(ctx *gin.Context) (accessToken, refreshToken string, role roles.Role, err error) {
+	var body LoginPasskeyBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		err = errors.WithStack(ErrMissingPasskeyResponse)
+		return
+	}
+
+	challenge, err := webauthn.ClientChallenge(body.Response.ClientDataJSON)
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.ConsumePasskeyChallenge(ctx, challenge, "")
+	if err != nil {
+		return
+	}
+
+	passkey, err := s.DBAPI.GetPasskey(ctx, body.RawID)
+	if err != nil {
+		return
+	}
+	if len(body.Response.UserHandle) > 0 && string(body.Response.UserHandle) != passkey.UserID {
+		err = errors.WithStack(ErrPasskeyNotFound)
+		return
+	}
+	signCount, err := s.RelyingParty.VerifyAssertion(body.Response, challenge, webauthn.Credential{
+		ID:        passkey.ID,
+		PublicKey: passkey.PublicKey,
+		SignCount: uint32(passkey.SignCount),
+	})
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.UpdatePasskeySignCount(ctx, passkey.ID, signCount)
+	if err != nil {
+		return
+	}
+
+	user, err := s.DBAPI.GetUser(ctx, passkey.UserID)
+	if err != nil {
+		return
+	}
+	instance, err := s.DBAPI.GetInstance(ctx, body.InstanceURL)
+	if err != nil {
+		return
+	}
+	if instance.RequireVerification && !user.ActivatedAt.Valid {
+		err = errors.WithStack(ErrUserNotActivated)
+		return
+	}
+
+	return s.startSession(ctx, user.ID, instance.ID)
+}
+
+func passkeyResponse(p *m.Passkey) PasskeyResponse {
+	return PasskeyResponse{
+		ID:         base64.RawURLEncoding.EncodeToString(p.ID),
+		Name:       p.Name.String,
+		CreatedAt:  p.CreatedAt,
+		LastUsedAt: p.LastUsedAt.Ptr(),
+	}
+}
+
+var (
+	ErrMissingPasskeyResponse  = errors.New("missing authenticator response")
+	ErrPasskeyChallengeInvalid = errors.New("passkey challenge invalid or expired")
+	ErrPasskeyNotFound         = errors.New("passkey not found")
+)
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
+)
+
+// ForgotPasswordBody describes the user who forgot the password
+type ForgotPasswordBody struct {
+	Email string `json:"email"`
+}
+
+// forgotPasswordTimeout limits the background work of a forgotten password.
+const forgotPasswordTimeout = time.Minute
+
+// ForgotPassword sends a mail with a single-use token to reset the user's password.
+// The mail is prepared and sent in the background, so the response time does not tell whether the email has an account.
+func (s *Service) ForgotPassword(ctx *gin.Context) error {
+	var body ForgotPasswordBody
+	err := ctx.ShouldBind(&body)
+	if err != nil {
+		return err
+	}
+
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		// the request context ends with the response
+		ctx, cancel := context.WithTimeout(context.Background(), forgotPasswordTimeout)
+		defer cancel()
+		err := s.sendPasswordReset(ctx, body.Email)
+		if errors.Is(err, ErrUserDoesNotExist) {
+			log.Debug().Str("email", body.Email).Msg("password reset of unknown email")
+		} else if err != nil {
+			log.Error().Stack().Err(err).Msg("sending password reset failed")
+		}
+	}()
+	return nil
+}
+
+func (s *Service) sendPasswordReset(ctx context.Context, email string) (err error) {
+	user, err := s.DBAPI.FindUserByEmail(ctx, email)
+	if err != nil {
+		return
+	}
+
+	token, digest, err := tokens.GenerateOpaqueToken()
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.CreatePasswordReset(ctx, user.ID, digest, time.Now().Add(s.PasswordResetExpiry))
+	if err != nil {
+		return
+	}
+
+	// the reset needs a new password, so the link opens the frontend's form that submits it
+	link := s.FrontendURL + "/password/reset?token=" + url.QueryEscape(token)
+	return s.Mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Someone requested to reset your password. If this was you, open the following link within %s:\n\n%s\n\nOtherwise you can ignore this mail.\n", s.PasswordResetExpiry, link),
+	})
+}
+
+// ResetPasswordBody describes the reset token received by mail and the desired new password
+type ResetPasswordBody struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ResetPassword sets a new password and revokes all of the user's refresh tokens.
+// The reset is not bound to an instance, so the new password has to satisfy the policies of all instances the user has a profile in.
+func (s *Service) ResetPassword(ctx *gin.Context) (err error) {
+	var body ResetPasswordBody
+	err = ctx.ShouldBind(&body)
+	if err != nil || len(body.Token) == 0 {
+		return errors.WithStack(ErrMissingResetToken)
+	}
+	if len(body.Password) == 0 {
+		return errors.WithStack(ErrInvalidPassword)
+	}
+	user, err := s.DBAPI.GetPasswordResetUser(ctx, tokens.Digest(body.Token))
+	if err != nil {
+		return
+	}
+	policy, err := s.userPolicy(ctx, user)
+	if err != nil {
+		return
+	}
+	err = s.checkPassword(policy, body.Password)
+	if err != nil {
+		return
+	}
+
+	hashedPassword, err := s.hashPassword(body.Password)
+	if err != nil {
+		return
+	}
+
+	// sessions possibly started by someone knowing the old password end together with it
+	user, n, err := s.DBAPI.ResetPassword(ctx, tokens.Digest(body.Token), hashedPassword)
+	if err != nil {
+		return
+	}
+	s.syncDenylist(ctx)
+	log.Debug().Str("user", user.ID).Int64("revoked", n).Msg("password reset")
+	return
+}
+
+// PasswordPolicyError lists the rules of the password policy a password violates.
+type PasswordPolicyError struct {
+	Rules []password.Rule `json:"rules"`
+}
+
+func (e *PasswordPolicyError) Error() string {
+	rules := make([]string, 0, len(e.Rules))
+	for _, r := range e.Rules {
+		rules = append(rules, string(r))
+	}
+	return "password violates policy: " + strings.Join(rules, ", ")
+}
+
+// PasswordPolicy returns the password policy of an instance, for clients to check passwords before submitting them.
+func (s *Service) PasswordPolicy(ctx *gin.Context) (policy password.Policy, err error) {
+	instance, err := s.DBAPI.GetInstance(ctx, ctx.Query("instance"))
+	if err != nil {
+		return
+	}
+	policy = instancePolicy(instance)
+	// without a breached password list, breached passwords are not checked
+	policy.CheckBreached = policy.CheckBreached && s.BreachedPasswords != nil
+	return
+}
+
+func instancePolicy(instance *m.Instance) password.Policy {
+	return password.Policy{
+		MinLength:     instance.PasswordMinLength,
+		MaxLength:     instance.PasswordMaxLength,
+		MinClasses:    instance.PasswordMinClasses,
+		CheckBreached: instance.PasswordCheckBreached,
+	}
+}
+
+// userPolicy is the strictest policy of the instances the user has a profile in, or the default policy without profiles.
+func (s *Service) userPolicy(ctx *gin.Context, user *m.User) (password.Policy, error) {
+	profiles, err := s.DBAPI.ListProfiles(ctx, user.ID)
+	if err != nil {
+		return password.Policy{}, err
+	}
+	policies := make([]password.Policy, 0, len(profiles))
+	for _, p := range profiles {
+		if p.R != nil && p.R.Instance != nil {
+			policies = append(policies, instancePolicy(p.R.Instance))
+		}
+	}
+	return password.Strictest(policies...), nil
+}
+
+// checkPassword fails with a PasswordPolicyError if the password violates the policy.
+func (s *Service) checkPassword(policy password.Policy, pw string) error {
+	violated, err := policy.Check(pw, s.BreachedPasswords)
+	if err != nil {
+		return err
+	}
+	if len(violated) > 0 {
+		return errors.WithStack(&PasswordPolicyError{Rules: violated})
+	}
+	return nil
+}
+
+var (
+	ErrMissingResetToken = errors.New("missing password reset token")
+	ErrResetInvalid      = errors.New("password reset token invalid or expired")
+)
@@ -0,0 +1,133 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+)
+
+// PrefixLength is the length of the hash prefix a breached password list is queried with.
+// Only the prefix leaves the caller, so a remote list learns nothing about the password (k-anonymity).
+const PrefixLength = 5
+
+// BreachedList returns the hash suffixes of breached passwords whose upper case hex SHA-1 hash starts with prefix.
+type BreachedList interface {
+	Range(prefix string) ([]string, error)
+}
+
+// Breached checks if the password is on the list.
+func Breached(list BreachedList, password string) (bool, error) {
+	d := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(d[:]))
+	suffixes, err := list.Range(hash[:PrefixLength])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if suffix == hash[PrefixLength:] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// FileList is a breached password list in a local file with one upper case hex SHA-1 hash per line, sorted by hash.
+// Lines may carry a count separated by colon, like the downloads of https://haveibeenpwned.com/Passwords.
+// The file is binary searched, so it is not loaded into memory.
+type FileList struct {
+	Path string
+}
+
+// NewFileList checks that the file at path can be read.
+func NewFileList(path string) (*FileList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening breached password list failed")
+	}
+	f.Close()
+	return &FileList{Path: path}, nil
+}
+
+// Range returns the hash suffixes of all lines starting with prefix.
+func (l *FileList) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+	f, err := os.Open(l.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// find the first line not sorting before prefix:
+	// it is either a line starting in [lo, hi) or the first line starting at or after hi
+	lo, hi := int64(0), info.Size()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, line, err := lineAfter(f, mid, info.Size())
+		if err != nil {
+			return nil, err
+		}
+		if start >= info.Size() || hashOf(line) >= prefix {
+			hi = mid
+		} else {
+			lo = start + int64(len(line))
+		}
+	}
+
+	start, _, err := lineAfter(f, lo, info.Size())
+	if err != nil {
+		return nil, err
+	}
+	suffixes := []string{}
+	r := bufio.NewReader(io.NewSectionReader(f, start, info.Size()-start))
+	for {
+		line, err := r.ReadString('\n')
+		hash := hashOf(line)
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes = append(suffixes, hash[len(prefix):])
+		if err != nil {
+			break
+		}
+	}
+	return suffixes, nil
+}
+
+// lineAfter returns the first line starting at or after off, including its line break.
+func lineAfter(f *os.File, off, size int64) (start int64, line string, err error) {
+	start = off
+	if off > 0 {
+		// skip the rest of the line containing the byte before off
+		start = off - 1
+	}
+	r := bufio.NewReader(io.NewSectionReader(f, start, size-start))
+	if off > 0 {
+		skipped, err := r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return 0, "", err
+		}
+		start += int64(len(skipped))
+	}
+	line, err = r.ReadString('\n')
+	if err == io.EOF {
+		err = nil
+	}
+	return
+}
+
+func hashOf(line string) string {
+	hash := strings.TrimRight(line, "\r\n")
+	if i := strings.IndexByte(hash, ':'); i >= 0 {
+		hash = hash[:i]
+	}
+	return strings.ToUpper(hash)
+}
@@ -0,0 +1,149 @@
+package password
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/friendsofgo/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithms to hash passwords with.
+const (
+	Argon2id = "argon2id"
+	Bcrypt   = "bcrypt"
+)
+
+// Argon2Params are the parameters of argon2id, see RFC 9106.
+type Argon2Params struct {
+	// Memory is the memory used in KiB
+	Memory uint32
+	// Iterations is the number of passes over the memory
+	Iterations uint32
+	// Parallelism is the number of threads
+	Parallelism uint8
+}
+
+// DefaultArgon2Params follow the second recommended option of RFC 9106 with less parallelism.
+var DefaultArgon2Params = Argon2Params{Memory: 64 * 1024, Iterations: 3, Parallelism: 2}
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// Hasher hashes passwords with the configured algorithm and parameters.
+// Hashes are self-describing: bcrypt hashes in their modular crypt format ($2a$...),
+// argon2id hashes in the PHC string format ($argon2id$v=19$m=...,t=...,p=...$salt$key).
+// So the algorithm and parameters can evolve while stored hashes keep working.
+type Hasher struct {
+	// Algorithm is Argon2id or Bcrypt
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+// DefaultHasher hashes with argon2id.
+var DefaultHasher = Hasher{Algorithm: Argon2id, BcryptCost: bcrypt.DefaultCost, Argon2: DefaultArgon2Params}
+
+// Hash salts and hashes a password.
+func (h Hasher) Hash(password string) ([]byte, error) {
+	switch h.Algorithm {
+	case Argon2id:
+		salt := make([]byte, argon2SaltLength)
+		_, err := rand.Read(salt)
+		if err != nil {
+			return nil, errors.Wrap(err, "generating salt failed")
+		}
+		key := argon2.IDKey([]byte(password), salt, h.Argon2.Iterations, h.Argon2.Memory, h.Argon2.Parallelism, argon2KeyLength)
+		return []byte(fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", Argon2id, argon2.Version,
+			h.Argon2.Memory, h.Argon2.Iterations, h.Argon2.Parallelism,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))), nil
+	case Bcrypt:
+		return bcrypt.GenerateFromPassword([]byte(password), h.BcryptCost)
+	default:
+		return nil, errors.Wrapf(ErrUnknownAlgorithm, "%s", h.Algorithm)
+	}
+}
+
+// Compare checks a password against a hash of any supported algorithm.
+func Compare(hash []byte, password string) error {
+	if isArgon2id(hash) {
+		params, salt, key, err := parseArgon2id(hash)
+		if err != nil {
+			return err
+		}
+		other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(key, other) != 1 {
+			return errors.WithStack(ErrMismatch)
+		}
+		return nil
+	}
+	err := bcrypt.CompareHashAndPassword(hash, []byte(password))
+	if err != nil {
+		return errors.Wrap(ErrMismatch, err.Error())
+	}
+	return nil
+}
+
+// NeedsRehash checks if a hash was created with another algorithm or other parameters than configured.
+func (h Hasher) NeedsRehash(hash []byte) bool {
+	switch h.Algorithm {
+	case Argon2id:
+		if !isArgon2id(hash) {
+			return true
+		}
+		params, _, _, err := parseArgon2id(hash)
+		return err != nil || params != h.Argon2
+	case Bcrypt:
+		cost, err := bcrypt.Cost(hash)
+		return err != nil || cost != h.BcryptCost
+	default:
+		return false
+	}
+}
+
+func isArgon2id(hash []byte) bool {
+	return bytes.HasPrefix(hash, []byte("$"+Argon2id+"$"))
+}
+
+func parseArgon2id(hash []byte) (params Argon2Params, salt, key []byte, err error) {
+	var version int
+	var encodedSalt, encodedKey string
+	parts := bytes.Split(hash, []byte("$"))
+	if len(parts) != 6 {
+		err = errors.Wrap(ErrInvalidHash, "argon2id")
+		return
+	}
+	_, err = fmt.Sscanf(string(parts[2]), "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		err = errors.Wrapf(ErrInvalidHash, "argon2id version %s", parts[2])
+		return
+	}
+	_, err = fmt.Sscanf(string(parts[3]), "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		err = errors.Wrap(ErrInvalidHash, err.Error())
+		return
+	}
+	encodedSalt, encodedKey = string(parts[4]), string(parts[5])
+	salt, err = base64.RawStdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		err = errors.Wrap(ErrInvalidHash, err.Error())
+		return
+	}
+	key, err = base64.RawStdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		err = errors.Wrap(ErrInvalidHash, err.Error())
+	}
+	return
+}
+
+var (
+	ErrMismatch         = errors.New("password does not match hash")
+	ErrInvalidHash      = errors.New("invalid password hash")
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+)
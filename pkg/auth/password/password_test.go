@@ -0,0 +1,106 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+func (s *MySuite) Test_Check(assert, require *td.T) {
+	policy := Policy{MinLength: 8, MaxLength: MaxLength, MinClasses: 3}
+
+	violated, err := policy.Check("Correct-Horse", nil)
+	require.CmpNoError(err)
+	assert.Empty(violated)
+
+	violated, err = policy.Check("short", nil)
+	require.CmpNoError(err)
+	assert.Cmp(violated, []Rule{RuleMinLength, RuleCharacterClasses})
+
+	// the length limit is in bytes, as bcrypt ignores further bytes
+	violated, err = policy.Check(strings.Repeat("Äb1", 19), nil)
+	require.CmpNoError(err)
+	assert.Cmp(violated, []Rule{RuleMaxLength})
+}
+
+func (s *MySuite) Test_Strictest(assert, require *td.T) {
+	assert.Cmp(Strictest(), DefaultPolicy)
+	assert.Cmp(Strictest(
+		Policy{MinLength: 12, MaxLength: MaxLength, MinClasses: 1},
+		Policy{MinLength: 8, MaxLength: 32, MinClasses: 3, CheckBreached: true},
+		Policy{MinLength: 8},
+	), Policy{MinLength: 12, MaxLength: 32, MinClasses: 3, CheckBreached: true})
+}
+
+func (s *MySuite) Test_FileList(assert, require *td.T) {
+	// given a sorted list with counts, like downloaded from haveibeenpwned.com
+	breached := []string{"password", "123456", "qwerty", "letmein", "Correct-Horse"}
+	lines := []string{}
+	for _, p := range breached {
+		d := sha1.Sum([]byte(p))
+		lines = append(lines, strings.ToUpper(hex.EncodeToString(d[:]))+":42")
+	}
+	sort.Strings(lines)
+	path := filepath.Join(require.TempDir(), "pwned.txt")
+	require.CmpNoError(os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0o600))
+	list, err := NewFileList(path)
+	require.CmpNoError(err)
+
+	// then every listed password is found
+	for _, p := range breached {
+		found, err := Breached(list, p)
+		require.CmpNoError(err)
+		assert.True(found, p)
+	}
+	found, err := Breached(list, "Correct-Horse-Battery")
+	require.CmpNoError(err)
+	assert.False(found)
+
+	// and a policy rejects them
+	violated, err := DefaultPolicy.Check("Correct-Horse", list)
+	require.CmpNoError(err)
+	assert.Cmp(violated, []Rule{RuleBreached})
+}
+
+func (s *MySuite) Test_Hasher(assert, require *td.T) {
+	fast := Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+	argon := Hasher{Algorithm: Argon2id, Argon2: fast}
+	bcryptHasher := Hasher{Algorithm: Bcrypt, BcryptCost: bcrypt.MinCost}
+
+	// hashes of either algorithm are verified
+	for _, h := range []Hasher{argon, bcryptHasher} {
+		hash, err := h.Hash("Correct-Horse")
+		require.CmpNoError(err)
+		assert.CmpNoError(Compare(hash, "Correct-Horse"), h.Algorithm)
+		assert.True(errors.Is(Compare(hash, "Correct-Horse-Battery"), ErrMismatch), h.Algorithm)
+		assert.False(h.NeedsRehash(hash), h.Algorithm)
+	}
+
+	// hashes of another algorithm or other parameters need a rehash
+	hash, err := bcryptHasher.Hash("Correct-Horse")
+	require.CmpNoError(err)
+	assert.True(argon.NeedsRehash(hash))
+	assert.True(Hasher{Algorithm: Bcrypt, BcryptCost: bcrypt.MinCost + 1}.NeedsRehash(hash))
+	hash, err = argon.Hash("Correct-Horse")
+	require.CmpNoError(err)
+	assert.True(Hasher{Algorithm: Argon2id, Argon2: Argon2Params{Memory: 2048, Iterations: 1, Parallelism: 1}}.NeedsRehash(hash))
+	assert.True(bcryptHasher.NeedsRehash(hash))
+
+	// users without password never match
+	assert.True(errors.Is(Compare(nil, ""), ErrMismatch))
+}
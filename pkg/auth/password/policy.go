@@ -0,0 +1,103 @@
+// Package password hashes passwords and checks them against a policy and a list of breached passwords.
+package password
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxLength is the maximum length of a password in bytes, bcrypt ignores further bytes.
+const MaxLength = 72
+
+// Rule identifies a rule of a password policy.
+type Rule string
+
+const (
+	RuleMinLength        Rule = "min_length"
+	RuleMaxLength        Rule = "max_length"
+	RuleCharacterClasses Rule = "character_classes"
+	RuleBreached         Rule = "breached"
+)
+
+// Policy describes the rules passwords have to satisfy.
+type Policy struct {
+	// MinLength is the minimum number of characters
+	MinLength int `json:"minLength"`
+	// MaxLength is the maximum number of bytes, at most MaxLength
+	MaxLength int `json:"maxLength"`
+	// MinClasses is the minimum number of character classes (lower case, upper case, digits, others)
+	MinClasses int `json:"minClasses"`
+	// CheckBreached rejects passwords on the breached password list
+	CheckBreached bool `json:"checkBreached"`
+}
+
+// DefaultPolicy applies where no policy of an instance is known.
+var DefaultPolicy = Policy{MinLength: 8, MaxLength: MaxLength, MinClasses: 1, CheckBreached: true}
+
+// Strictest combines policies to one that a password only satisfies if it satisfies all of them.
+// Without policies, the DefaultPolicy applies.
+func Strictest(policies ...Policy) Policy {
+	if len(policies) == 0 {
+		return DefaultPolicy
+	}
+	strictest := Policy{MaxLength: MaxLength}
+	for _, p := range policies {
+		if p.MinLength > strictest.MinLength {
+			strictest.MinLength = p.MinLength
+		}
+		if p.MaxLength > 0 && p.MaxLength < strictest.MaxLength {
+			strictest.MaxLength = p.MaxLength
+		}
+		if p.MinClasses > strictest.MinClasses {
+			strictest.MinClasses = p.MinClasses
+		}
+		strictest.CheckBreached = strictest.CheckBreached || p.CheckBreached
+	}
+	return strictest
+}
+
+// Check returns the rules the password violates, in the order of the Rule constants.
+// The breached password list is only consulted if the policy asks for it and list is not nil.
+func (p Policy) Check(password string, list BreachedList) ([]Rule, error) {
+	violated := []Rule{}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violated = append(violated, RuleMinLength)
+	}
+	maxLength := p.MaxLength
+	if maxLength <= 0 || maxLength > MaxLength {
+		maxLength = MaxLength
+	}
+	if len(password) > maxLength {
+		violated = append(violated, RuleMaxLength)
+	}
+	if classes(password) < p.MinClasses {
+		violated = append(violated, RuleCharacterClasses)
+	}
+	if p.CheckBreached && list != nil {
+		breached, err := Breached(list, password)
+		if err != nil {
+			return nil, err
+		}
+		if breached {
+			violated = append(violated, RuleBreached)
+		}
+	}
+	return violated, nil
+}
+
+func classes(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
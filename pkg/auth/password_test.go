@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *MySuite) Test_forgotPassword(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	user := &m.User{ID: xid.New().String(), Email: "jane@example.com"}
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq("jane@example.com")).
+		Return(user, nil)
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq("unknown@example.com")).
+		Return(nil, errors.WithStack(ErrUserDoesNotExist))
+	var digest []byte
+	release := make(chan struct{})
+	mock.EXPECT().
+		CreatePasswordReset(gomock.Any(), gomock.Eq(user.ID), gomock.Len(32), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, token []byte, expiresAt time.Time) error {
+			<-release
+			digest = token
+			assert.Between(expiresAt, time.Now(), time.Now().Add(time.Hour), td.BoundsInIn)
+			return nil
+		})
+
+	mailDir := require.TempDir()
+	service := Service{
+		Env: Env{
+			PublicURL:           "http://localhost:8801",
+			FrontendURL:         "http://localhost:3000",
+			PasswordResetExpiry: time.Hour,
+		},
+		DBAPI:  mock,
+		Mailer: mail.FileSender{Dir: mailDir},
+	}
+	forgot := func(email string) int {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/password/forgot", strings.NewReader(`{"email":"`+email+`"}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ForgotPasswordHandler(ctx, &service)
+		return ctx.Writer.Status()
+	}
+
+	// when
+	known := forgot("jane@example.com")
+	unknown := forgot("unknown@example.com")
+
+	// then both emails get the same response without waiting for the mail, but only the user receives a mail
+	assert.Cmp(known, http.StatusAccepted)
+	assert.Cmp(unknown, known)
+	close(release)
+	service.background.Wait()
+
+	mails, err := filepath.Glob(filepath.Join(mailDir, "*.eml"))
+	require.CmpNoError(err)
+	require.Len(mails, 1)
+	content, err := ioutil.ReadFile(mails[0])
+	require.CmpNoError(err)
+	assert.Contains(string(content), "To: jane@example.com")
+
+	// and the mail links to the frontend with the token whose digest is stored
+	link := regexp.MustCompile(`http://localhost:3000/password/reset\?token=(\S+)`).FindStringSubmatch(string(content))
+	require.Len(link, 2)
+	token, err := url.QueryUnescape(link[1])
+	require.CmpNoError(err)
+	assert.Cmp(tokens.Digest(token), digest)
+}
+
+func (s *MySuite) Test_resetPassword(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	userID := xid.New().String()
+	valid, validDigest, err := tokens.GenerateOpaqueToken()
+	require.CmpNoError(err)
+	expired, expiredDigest, err := tokens.GenerateOpaqueToken()
+	require.CmpNoError(err)
+
+	// the database only knows the digests of the reset tokens
+	resets := map[string]time.Time{
+		string(validDigest):   time.Now().Add(time.Hour),
+		string(expiredDigest): time.Now().Add(-time.Minute),
+	}
+	mock.EXPECT().
+		ResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, digest, hash []byte) (*m.User, int64, error) {
+			expiresAt, ok := resets[string(digest)]
+			if !ok || expiresAt.Before(time.Now()) {
+				return nil, 0, errors.WithStack(ErrResetInvalid)
+			}
+			delete(resets, string(digest))
+			assert.CmpNoError(password.Compare(hash, "new secret"))
+			return &m.User{ID: userID}, 2, nil
+		}).
+		Times(1)
+
+	mock.EXPECT().
+		GetPasswordResetUser(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, digest []byte) (*m.User, error) {
+			expiresAt, ok := resets[string(digest)]
+			if !ok || expiresAt.Before(time.Now()) {
+				return nil, errors.WithStack(ErrResetInvalid)
+			}
+			return &m.User{ID: userID}, nil
+		}).
+		AnyTimes()
+
+	// the user has profiles in instances with different policies
+	profiles := m.ProfileSlice{}
+	for _, instance := range []*m.Instance{
+		{ID: xid.New().String(), PasswordMinLength: 8, PasswordMaxLength: 72, PasswordMinClasses: 1},
+		{ID: xid.New().String(), PasswordMinLength: 10, PasswordMaxLength: 16, PasswordMinClasses: 1},
+	} {
+		p := &m.Profile{UserID: userID, InstanceID: instance.ID}
+		p.R = p.R.NewStruct()
+		p.R.Instance = instance
+		profiles = append(profiles, p)
+	}
+	mock.EXPECT().
+		ListProfiles(gomock.Any(), gomock.Eq(userID)).
+		Return(profiles, nil).
+		AnyTimes()
+
+	service := Service{
+		Env:   Env{PasswordHasher: password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}},
+		DBAPI: mock,
+	}
+	reset := func(token, pw string) int {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(`{"token":"`+token+`","password":"`+pw+`"}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ResetPasswordHandler(ctx, &service)
+		return ctx.Writer.Status()
+	}
+
+	// when the password violates the policy of any of the user's instances, then it is rejected and the token stays valid
+	assert.Cmp(reset(valid, "secret"), http.StatusBadRequest)
+	assert.Cmp(reset(valid, "a very long new secret"), http.StatusBadRequest)
+
+	// when the token is used, then the password is reset
+	assert.Cmp(reset(valid, "new secret"), http.StatusOK)
+
+	// and the token can not be used again
+	assert.Cmp(reset(valid, "new secret"), http.StatusUnauthorized)
+
+	// and expired tokens are rejected
+	assert.Cmp(reset(expired, "new secret"), http.StatusUnauthorized)
+}
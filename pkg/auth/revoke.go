@@ -1,9 +1,12 @@
 package auth
 
 import (
+	"context"
+
 	"github.com/friendsofgo/errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 	"github.com/smartnuance/saas-kit/pkg/lib/roles"
 )
 
@@ -64,6 +67,7 @@ func (s *Service) Revoke(ctx *gin.Context) error {
 	if err != nil {
 		return err
 	}
+	s.syncDenylist(ctx)
 	return nil
 }
 
@@ -103,9 +107,22 @@ func (s *Service) RevokeAll(ctx *gin.Context) error {
 	if err != nil {
 		return err
 	}
+	s.syncDenylist(ctx)
 	return nil
 }
 
+// syncDenylist makes access tokens denied by a revocation take effect immediately.
+// Other instances of the auth service pick them up with their next periodic sync.
+func (s *Service) syncDenylist(ctx context.Context) {
+	if s.Denylist == nil {
+		return
+	}
+	err := s.Denylist.Sync(ctx)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("syncing denylist failed")
+	}
+}
+
 var (
 	ErrMissingRevokeEmail = errors.New("missing user id")
 	ErrTokenRevoked       = errors.New("refresh token was revoked and is no longer valid")
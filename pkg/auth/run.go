@@ -5,18 +5,29 @@ import (
 	"embed"
 	"flag"
 	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/RichardKnop/go-fixtures"
 	"github.com/friendsofgo/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
 	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/auth/webauthn"
 	"github.com/smartnuance/saas-kit/pkg/lib"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
 	"github.com/smartnuance/saas-kit/pkg/lib/service"
+	libtokens "github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/userdata"
+	"github.com/volatiletech/null/v8"
 )
 
 //go:embed migrations/*
@@ -36,8 +47,54 @@ type Env struct {
 	service.DBEnv
 	tokens.TokenEnv
 	service.HTTPEnv
+	mail.MailEnv
 	AllowOrigins []string
-	release      bool
+	// TrustedProxies are the IPs or CIDRs of reverse proxies whose X-Forwarded-For header is trusted for the client IP.
+	// Without, the client IP is the remote address, so clients can not spoof it to evade login throttling.
+	TrustedProxies []string
+	// PublicURL is the base URL links in mails point to
+	PublicURL string
+	// FrontendURL is the base URL of the web frontend, which links in mails to forms like the password reset point to
+	FrontendURL string
+	// VerificationExpiry is the duration an email verification token stays valid
+	VerificationExpiry time.Duration
+	// PasswordResetExpiry is the duration a password reset token stays valid
+	PasswordResetExpiry time.Duration
+	// InvitationExpiry is the duration an invitation to an instance stays valid
+	InvitationExpiry time.Duration
+	// DeletedRetention is the duration soft-deleted users, profiles and instances are kept before they are purged
+	DeletedRetention time.Duration
+	// EventUserDataURL is the event service's endpoint to export and erase user data; the event service is left out if empty
+	EventUserDataURL string
+	// TokenGCInterval is the interval expired refresh tokens are deleted in the background
+	TokenGCInterval time.Duration
+	// DenylistSyncInterval is the interval the in-memory denylist is synced with the database
+	DenylistSyncInterval time.Duration
+	// TOTPIssuer is the issuer shown in authenticator apps
+	TOTPIssuer string
+	// RelyingParty identifies the auth service to passkey authenticators
+	RelyingParty webauthn.RelyingParty
+	// OIDCRedirectURL is the URL identity providers redirect back to after a login
+	OIDCRedirectURL string
+	// OIDCIssuer is the issuer URL of the auth service as OpenID Connect provider
+	OIDCIssuer string
+	// OIDCLoginURL is the login page that authenticates users for authorization requests of clients
+	OIDCLoginURL string
+	// LoginAccountThreshold is the number of failed logins of an account before it is locked out
+	LoginAccountThreshold int
+	// LoginIPThreshold is the number of failed logins from a client IP before it is locked out
+	LoginIPThreshold int
+	// LoginLockout is the duration of the first lockout, doubled with every further failed login
+	LoginLockout time.Duration
+	// LoginMaxLockout caps the lockout duration
+	LoginMaxLockout time.Duration
+	// LoginAttemptWindow is the time failed logins are remembered after the last one
+	LoginAttemptWindow time.Duration
+	// BreachedPasswordsPath is the file of breached password hashes to reject; none are rejected if empty
+	BreachedPasswordsPath string
+	// PasswordHasher hashes new passwords; stored hashes of other algorithms or parameters are upgraded on login
+	PasswordHasher password.Hasher
+	release        bool
 }
 
 // Service offers the APIs of the authentication service.
@@ -47,8 +104,20 @@ type Service struct {
 	service.DBConn
 	DBAPI DBAPI
 	service.HTTPServer
-	TokenAPI     *tokens.TokenController
+	TokenAPI *tokens.TokenController
+	TokenGC  *TokenGC
+	Denylist *libtokens.CachedDenylist
+	Throttle *LoginThrottle
+	// BreachedPasswords is nil if no breached password list is configured
+	BreachedPasswords password.BreachedList
+	OIDC              *oidc.Registry
+	Mailer            mail.Sender
+	// UserData are the other services holding data about users, which are included in exports and erasures
+	UserData     []*userdata.Client
 	AllowOrigins map[string]struct{}
+
+	// background tracks work outliving its request, like sending password reset mails
+	background sync.WaitGroup
 }
 
 var migrateDownFlag bool
@@ -58,6 +127,23 @@ var userName string
 var userEmail string
 var userPassword string
 var userInstanceURL string
+var generateKeyFlag bool
+var promoteKeyID string
+var removeKeyID string
+var purgeTokensFlag bool
+var providerInstanceURL string
+var providerSlug string
+var providerName string
+var providerIssuer string
+var providerClientID string
+var providerClientSecret string
+var providerScopes string
+var clientInstanceURL string
+var clientName string
+var clientRedirectURIs string
+var clientPublicFlag bool
+var dataUserEmail string
+var exportPath string
 
 func Main() (authService Service, err error) {
 	// Common steps for all command options
@@ -66,6 +152,14 @@ func Main() (authService Service, err error) {
 	if err != nil {
 		return
 	}
+
+	// Key management has to work before a valid key set exists
+	if len(os.Args) >= 2 && os.Args[1] == "keys" {
+		lib.SetupLogger(ServiceName, Version, env.release)
+		err = keys(env.TokenEnv, os.Args[2:])
+		return
+	}
+
 	authService, err = env.Setup()
 	if err != nil {
 		return
@@ -82,6 +176,26 @@ func Main() (authService Service, err error) {
 	userCommand.StringVar(&userEmail, "email", "", "email of user to add")
 	userCommand.StringVar(&userPassword, "password", "", "password of user to add")
 	userCommand.StringVar(&userInstanceURL, "instance", "smartnuance.com", "instance URL for which to add user's default profile")
+	hashTokensCommand := flag.NewFlagSet("hashtokens", flag.ExitOnError)
+	hashTokensCommand.BoolVar(&purgeTokensFlag, "purge", false, "delete plaintext refresh tokens instead of converting them, which ends the affected sessions")
+	providerCommand := flag.NewFlagSet("addprovider", flag.ExitOnError)
+	providerCommand.StringVar(&providerInstanceURL, "instance", "smartnuance.com", "instance URL whose users can log in with the provider")
+	providerCommand.StringVar(&providerSlug, "slug", "", "identifier of the provider in login requests, e.g. google")
+	providerCommand.StringVar(&providerName, "name", "", "name of the provider shown on the login page")
+	providerCommand.StringVar(&providerIssuer, "issuer", "", "OpenID Connect issuer URL to discover the provider from")
+	providerCommand.StringVar(&providerClientID, "client-id", "", "client ID registered at the provider")
+	providerCommand.StringVar(&providerClientSecret, "client-secret", "", "client secret registered at the provider")
+	providerCommand.StringVar(&providerScopes, "scopes", "email profile", "space separated scopes requested in addition to openid")
+	clientCommand := flag.NewFlagSet("addclient", flag.ExitOnError)
+	clientCommand.StringVar(&clientInstanceURL, "instance", "smartnuance.com", "instance URL whose users can log in to the client")
+	clientCommand.StringVar(&clientName, "name", "", "name of the client application")
+	clientCommand.StringVar(&clientRedirectURIs, "redirect-uris", "", "comma separated redirect URIs of the client")
+	clientCommand.BoolVar(&clientPublicFlag, "public", false, "register a public client without secret, like native or browser apps")
+	exportCommand := flag.NewFlagSet("export", flag.ExitOnError)
+	exportCommand.StringVar(&dataUserEmail, "email", "", "email of user to export")
+	exportCommand.StringVar(&exportPath, "out", "export.zip", "path of the ZIP file to write the export to")
+	eraseCommand := flag.NewFlagSet("erase", flag.ExitOnError)
+	eraseCommand.StringVar(&dataUserEmail, "email", "", "email of user to erase in all services")
 	flag.Parse()
 
 	// Check if a subcommand has been provided
@@ -137,10 +251,152 @@ func Main() (authService Service, err error) {
 				return
 			}
 
-			_, err = authService.signup(ctx, instance.ID, SignupBody{Name: userName, Email: userEmail, Password: userPassword}, "super admin")
+			_, err = authService.signup(ctx, instance.ID, SignupBody{Name: userName, Email: userEmail, Password: userPassword}, "super admin", true)
+			if err != nil {
+				return
+			}
+		case "addprovider":
+			err = providerCommand.Parse(os.Args[2:])
+			if err != nil {
+				return
+			}
+
+			ctx := context.Background()
+			var instance *m.Instance
+			instance, err = authService.DBAPI.GetInstance(ctx, providerInstanceURL)
+			if err != nil {
+				return
+			}
+
+			err = authService.DBAPI.CreateIdentityProvider(ctx, &m.IdentityProvider{
+				InstanceID:   instance.ID,
+				Slug:         providerSlug,
+				Name:         providerName,
+				Issuer:       providerIssuer,
+				ClientID:     providerClientID,
+				ClientSecret: providerClientSecret,
+				Scopes:       providerScopes,
+			})
+			if err != nil {
+				return
+			}
+			log.Info().Str("slug", providerSlug).Str("instance", providerInstanceURL).Msg("added identity provider")
+		case "addclient":
+			err = clientCommand.Parse(os.Args[2:])
 			if err != nil {
 				return
 			}
+
+			ctx := context.Background()
+			var instance *m.Instance
+			instance, err = authService.DBAPI.GetInstance(ctx, clientInstanceURL)
+			if err != nil {
+				return
+			}
+
+			client := &m.OauthClient{
+				InstanceID:   instance.ID,
+				Name:         clientName,
+				RedirectUris: strings.Join(strings.Split(clientRedirectURIs, ","), " "),
+			}
+			var secret string
+			if !clientPublicFlag {
+				var digest []byte
+				secret, digest, err = tokens.GenerateOpaqueToken()
+				if err != nil {
+					return
+				}
+				client.Secret = null.BytesFrom(digest)
+			}
+			err = authService.DBAPI.CreateOAuthClient(ctx, client)
+			if err != nil {
+				return
+			}
+			// the secret is only stored as digest, so it is shown once
+			log.Info().Str("clientID", client.ID).Str("clientSecret", secret).Msg("added client")
+		case "gc":
+			var n int64
+			n, err = authService.TokenGC.Collect(context.Background())
+			if err != nil {
+				return
+			}
+			log.Info().Int64("count", n).Msg("deleted expired refresh tokens")
+		case "purge":
+			if authService.DeletedRetention == 0 {
+				// like the token GC, a retention of zero keeps soft-deleted rows
+				log.Warn().Msg("purging is disabled by DELETED_RETENTION=0")
+				return
+			}
+			var n int64
+			n, err = authService.DBAPI.PurgeDeleted(context.Background(), time.Now().Add(-authService.DeletedRetention))
+			if err != nil {
+				return
+			}
+			log.Info().Int64("count", n).Msg("purged soft-deleted users, profiles and instances")
+		case "export":
+			err = exportCommand.Parse(os.Args[2:])
+			if err != nil {
+				return
+			}
+
+			ctx := context.Background()
+			var user *m.User
+			user, err = authService.DBAPI.FindUserByEmail(ctx, dataUserEmail)
+			if err != nil {
+				return
+			}
+			var export UserExport
+			export, err = authService.ExportUser(ctx, user.ID)
+			if err != nil {
+				return
+			}
+			var f *os.File
+			f, err = os.Create(exportPath)
+			if err != nil {
+				return
+			}
+			err = export.WriteZip(f)
+			if err != nil {
+				f.Close()
+				return
+			}
+			err = f.Close()
+			if err != nil {
+				return
+			}
+			log.Info().Str("user", user.ID).Str("path", exportPath).Msg("exported user data")
+		case "erase":
+			err = eraseCommand.Parse(os.Args[2:])
+			if err != nil {
+				return
+			}
+
+			ctx := context.Background()
+			var user *m.User
+			user, err = authService.DBAPI.FindUserByEmail(ctx, dataUserEmail)
+			if err != nil {
+				return
+			}
+			err = authService.EraseUser(ctx, user.ID)
+			if err != nil {
+				return
+			}
+		case "hashtokens":
+			err = hashTokensCommand.Parse(os.Args[2:])
+			if err != nil {
+				return
+			}
+
+			var n int64
+			n, err = authService.DBAPI.HashTokens(context.Background(), purgeTokensFlag)
+			if err != nil {
+				return
+			}
+			if purgeTokensFlag {
+				log.Info().Int64("count", n).Msg("purged plaintext refresh tokens")
+			} else {
+				log.Info().Int64("count", n).Msg("converted plaintext refresh tokens to digests")
+			}
 		default:
 			err = errors.Errorf("invalid command: %s", os.Args[1])
 			return
@@ -158,6 +414,51 @@ func Main() (authService Service, err error) {
 	return
 }
 
+// keys manages the key set for token signing with rotation.
+func keys(env tokens.TokenEnv, args []string) (err error) {
+	keysCommand := flag.NewFlagSet("keys", flag.ExitOnError)
+	keysCommand.BoolVar(&generateKeyFlag, "generate", false, "generate a new key pair (not yet used for signing)")
+	keysCommand.StringVar(&promoteKeyID, "promote", "", "kid of key to use for signing from now on, retiring the active key")
+	keysCommand.StringVar(&removeKeyID, "remove", "", "kid of retired key to stop accepting tokens for")
+	err = keysCommand.Parse(args)
+	if err != nil {
+		return
+	}
+	if len(env.KeyDir) == 0 {
+		return errors.New("key management requires TOKEN_KEY_DIR to be set")
+	}
+
+	if generateKeyFlag {
+		var kid string
+		kid, err = tokens.GenerateKey(env.KeyDir)
+		if err != nil {
+			return
+		}
+		log.Info().Str("kid", kid).Msg("generated key")
+	} else if promoteKeyID != "" {
+		err = tokens.PromoteKey(env.KeyDir, promoteKeyID)
+		if err != nil {
+			return
+		}
+		log.Info().Str("kid", promoteKeyID).Msg("promoted key")
+	} else if removeKeyID != "" {
+		err = tokens.RemoveKey(env.KeyDir, removeKeyID)
+		if err != nil {
+			return
+		}
+		log.Info().Str("kid", removeKeyID).Msg("removed key")
+	}
+
+	kids, active, err := tokens.ListKeys(env.KeyDir)
+	if err != nil {
+		return
+	}
+	for _, kid := range kids {
+		log.Info().Str("kid", kid).Bool("active", kid == active).Msg("key")
+	}
+	return
+}
+
 func Load() (env Env, err error) {
 	envs, err := lib.EnvMux(ServiceName)
 	if err != nil {
@@ -169,7 +470,144 @@ func Load() (env Env, err error) {
 
 	env.DBEnv = service.LoadDBEnv(envs)
 	env.TokenEnv = tokens.Load(envs, ServiceName)
+	env.MailEnv = mail.Load(envs)
 	env.AllowOrigins = strings.Split(envs["ALLOW_ORIGINS"], ",")
+	if len(envs["TRUSTED_PROXIES"]) > 0 {
+		env.TrustedProxies = strings.Split(envs["TRUSTED_PROXIES"], ",")
+	}
+	env.PublicURL = envs["PUBLIC_URL"]
+	env.FrontendURL = envs["FRONTEND_URL"]
+	if len(env.FrontendURL) == 0 {
+		env.FrontendURL = env.PublicURL
+	}
+	env.TOTPIssuer = envs["TOTP_ISSUER"]
+	if len(env.TOTPIssuer) == 0 {
+		env.TOTPIssuer = "saas-kit"
+	}
+	env.RelyingParty, err = loadRelyingParty(envs, env.PublicURL, env.TOTPIssuer)
+	if err != nil {
+		return
+	}
+	env.OIDCRedirectURL = envs["OIDC_REDIRECT_URL"]
+	if len(env.OIDCRedirectURL) == 0 {
+		env.OIDCRedirectURL = strings.TrimSuffix(env.PublicURL, "/") + "/login/oidc/callback"
+	}
+	env.OIDCIssuer = envs["OIDC_ISSUER"]
+	if len(env.OIDCIssuer) == 0 {
+		env.OIDCIssuer = env.PublicURL
+	}
+	env.OIDCLoginURL = envs["OIDC_LOGIN_URL"]
+	env.EventUserDataURL = envs["EVENT_USERDATA_URL"]
+	if len(env.EventUserDataURL) == 0 && len(envs["EVENT_SERVICE_PORT"]) > 0 {
+		env.EventUserDataURL = "http://" + envs["EVENT_SERVICE_HOST"] + ":" + envs["EVENT_SERVICE_PORT"] + userdata.Path
+	}
+	env.BreachedPasswordsPath = envs["BREACHED_PASSWORDS_PATH"]
+	env.VerificationExpiry, err = lib.Duration(envs, "VERIFICATION_EXPIRY", 48*time.Hour)
+	if err != nil {
+		return
+	}
+	env.PasswordResetExpiry, err = lib.Duration(envs, "PASSWORD_RESET_EXPIRY", time.Hour)
+	if err != nil {
+		return
+	}
+	env.InvitationExpiry, err = lib.Duration(envs, "INVITATION_EXPIRY", 7*24*time.Hour)
+	if err != nil {
+		return
+	}
+	env.DeletedRetention, err = lib.Duration(envs, "DELETED_RETENTION", 30*24*time.Hour)
+	if err != nil {
+		return
+	}
+	env.TokenGCInterval, err = lib.Duration(envs, "TOKEN_GC_INTERVAL", time.Hour)
+	if err != nil {
+		return
+	}
+	env.DenylistSyncInterval, err = lib.Duration(envs, "DENYLIST_SYNC_INTERVAL", 10*time.Second)
+	if err != nil {
+		return
+	}
+	env.LoginAccountThreshold, err = lib.Int(envs, "LOGIN_ACCOUNT_THRESHOLD", 5)
+	if err != nil {
+		return
+	}
+	env.LoginIPThreshold, err = lib.Int(envs, "LOGIN_IP_THRESHOLD", 50)
+	if err != nil {
+		return
+	}
+	env.LoginLockout, err = lib.Duration(envs, "LOGIN_LOCKOUT", time.Minute)
+	if err != nil {
+		return
+	}
+	env.LoginMaxLockout, err = lib.Duration(envs, "LOGIN_MAX_LOCKOUT", time.Hour)
+	if err != nil {
+		return
+	}
+	env.LoginAttemptWindow, err = lib.Duration(envs, "LOGIN_ATTEMPT_WINDOW", time.Hour)
+	if err != nil {
+		return
+	}
+	env.PasswordHasher, err = loadPasswordHasher(envs)
+	if err != nil {
+		return
+	}
+	return
+}
+
+// loadPasswordHasher defaults to argon2id with the recommended parameters.
+func loadPasswordHasher(envs map[string]string) (h password.Hasher, err error) {
+	h = password.DefaultHasher
+	if len(envs["PASSWORD_HASH"]) > 0 {
+		h.Algorithm = envs["PASSWORD_HASH"]
+	}
+	if h.Algorithm != password.Argon2id && h.Algorithm != password.Bcrypt {
+		err = errors.Wrapf(password.ErrUnknownAlgorithm, "PASSWORD_HASH %s", h.Algorithm)
+		return
+	}
+	h.BcryptCost, err = lib.Int(envs, "BCRYPT_COST", h.BcryptCost)
+	if err != nil {
+		return
+	}
+	memory, err := lib.Int(envs, "ARGON2_MEMORY", int(h.Argon2.Memory))
+	if err != nil {
+		return
+	}
+	iterations, err := lib.Int(envs, "ARGON2_ITERATIONS", int(h.Argon2.Iterations))
+	if err != nil {
+		return
+	}
+	parallelism, err := lib.Int(envs, "ARGON2_PARALLELISM", int(h.Argon2.Parallelism))
+	if err != nil {
+		return
+	}
+	if memory <= 0 || iterations <= 0 || parallelism <= 0 || parallelism > 255 {
+		err = errors.New("invalid argon2 parameters")
+		return
+	}
+	h.Argon2 = password.Argon2Params{Memory: uint32(memory), Iterations: uint32(iterations), Parallelism: uint8(parallelism)}
+	return
+}
+
+// loadRelyingParty defaults to the domain and origin of the public URL.
+func loadRelyingParty(envs map[string]string, publicURL, name string) (rp webauthn.RelyingParty, err error) {
+	rp = webauthn.RelyingParty{
+		ID:     envs["WEBAUTHN_RP_ID"],
+		Name:   envs["WEBAUTHN_RP_NAME"],
+		Origin: envs["WEBAUTHN_ORIGIN"],
+	}
+	u, err := url.Parse(publicURL)
+	if err != nil {
+		err = errors.Wrap(err, "invalid PUBLIC_URL")
+		return
+	}
+	if len(rp.ID) == 0 {
+		rp.ID = u.Hostname()
+	}
+	if len(rp.Name) == 0 {
+		rp.Name = name
+	}
+	if len(rp.Origin) == 0 {
+		rp.Origin = u.Scheme + "://" + u.Host
+	}
 	return
 }
 
@@ -183,12 +621,32 @@ func (env Env) Setup() (s Service, err error) {
 		return
 	}
 	s.DBAPI = &dbAPI{DB: s.DB}
+	s.TokenGC = NewTokenGC(s.DBAPI, env.TokenGCInterval)
+	s.TokenGC.Retention = env.DeletedRetention
+	s.Denylist = libtokens.NewCachedDenylist(s.DBAPI, env.DenylistSyncInterval)
+	s.Throttle = NewLoginThrottle(s.DBAPI, env)
+	if len(env.BreachedPasswordsPath) > 0 {
+		s.BreachedPasswords, err = password.NewFileList(env.BreachedPasswordsPath)
+		if err != nil {
+			return
+		}
+	}
+	s.OIDC = oidc.NewRegistry(&http.Client{Timeout: 10 * time.Second})
 
 	s.TokenAPI, err = tokens.Setup(s.TokenEnv)
 	if err != nil {
 		return
 	}
 
+	s.Mailer, err = mail.Setup(s.MailEnv)
+	if err != nil {
+		return
+	}
+
+	if len(env.EventUserDataURL) > 0 {
+		s.UserData = append(s.UserData, userdata.NewClient("event", env.EventUserDataURL))
+	}
+
 	s.HTTPServer = service.SetupHTTP(env.HTTPEnv, router(&s))
 
 	s.AllowOrigins = map[string]struct{}{}
@@ -206,5 +664,9 @@ func (env Env) Setup() (s Service, err error) {
 }
 
 func (s *Service) Run(ctx context.Context) (err error) {
-	return s.Serve(ctx)
+	go s.TokenGC.Run(ctx)
+	go s.Denylist.Run(ctx)
+	err = s.Serve(ctx)
+	s.background.Wait()
+	return
 }
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
+	authtokens "github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/volatiletech/null/v8"
+)
+
+// ServiceAccountBody describes a service account to create
+type ServiceAccountBody struct {
+	Name string     `json:"name"`
+	Role roles.Role `json:"role"`
+	// Scopes limit the access tokens of the service account in addition to its role
+	Scopes []string `json:"scopes"`
+}
+
+// ServiceAccountResponse describes a service account, the secret is only returned when it is created or rotated
+type ServiceAccountResponse struct {
+	ClientID     string     `json:"clientID"`
+	ClientSecret string     `json:"clientSecret,omitempty"`
+	Name         string     `json:"name"`
+	Role         roles.Role `json:"role"`
+	Scopes       []string   `json:"scopes"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// ServiceAccounts lists the service accounts of the authorized instance.
+func (s *Service) ServiceAccounts(ctx *gin.Context) ([]ServiceAccountResponse, error) {
+	instanceID, err := serviceAccountInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := s.DBAPI.ListServiceAccounts(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]ServiceAccountResponse, 0, len(clients))
+	for _, c := range clients {
+		res = append(res, serviceAccountResponse(c))
+	}
+	return res, nil
+}
+
+// CreateServiceAccount creates a service account in the authorized instance.
+// The role of the service account is limited to roles the authorized user can act in.
+func (s *Service) CreateServiceAccount(ctx *gin.Context) (res ServiceAccountResponse, err error) {
+	var body ServiceAccountBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+	instanceID, err := serviceAccountInstance(ctx)
+	if err != nil {
+		return
+	}
+	if !validRole(body.Role) {
+		err = errors.WithStack(roles.ErrInvalidRole)
+		return
+	}
+	if !roles.CanActIn(ctx, body.Role) {
+		err = errors.WithStack(roles.ErrUnauthorized)
+		return
+	}
+
+	secret, digest, err := authtokens.GenerateOpaqueToken()
+	if err != nil {
+		return
+	}
+	client := &m.OauthClient{
+		InstanceID: instanceID,
+		Name:       body.Name,
+		Secret:     null.BytesFrom(digest),
+		Role:       null.StringFrom(string(body.Role)),
+		Scopes:     strings.Join(body.Scopes, " "),
+	}
+	err = s.DBAPI.CreateOAuthClient(ctx, client)
+	if err != nil {
+		return
+	}
+
+	res = serviceAccountResponse(client)
+	res.ClientSecret = secret
+	return
+}
+
+// RotateServiceAccountSecret replaces the secret of a service account of the authorized instance.
+// Access tokens issued with the old secret stay valid until they expire.
+func (s *Service) RotateServiceAccountSecret(ctx *gin.Context) (secret string, err error) {
+	instanceID, err := serviceAccountInstance(ctx)
+	if err != nil {
+		return
+	}
+	secret, digest, err := authtokens.GenerateOpaqueToken()
+	if err != nil {
+		return
+	}
+	n, err := s.DBAPI.UpdateServiceAccountSecret(ctx, instanceID, ctx.Param("id"), digest)
+	if err != nil {
+		return
+	}
+	if n == 0 {
+		err = errors.WithStack(ErrServiceAccountNotFound)
+		return
+	}
+	return
+}
+
+// DeleteServiceAccount deletes a service account of the authorized instance.
+// Access tokens issued to the service account stay valid until they expire.
+func (s *Service) DeleteServiceAccount(ctx *gin.Context) error {
+	instanceID, err := serviceAccountInstance(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := s.DBAPI.DeleteServiceAccount(ctx, instanceID, ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.WithStack(ErrServiceAccountNotFound)
+	}
+	return nil
+}
+
+// issueServiceToken issues an access token to a service account, limited to the requested scopes or all scopes of the service account.
+// No refresh token is issued, as the service account can request a new access token with its credentials at any time.
+func (s *Service) issueServiceToken(client *m.OauthClient, scope string) (res oidc.TokenResponse, err error) {
+	if !client.Role.Valid || !client.Secret.Valid {
+		err = oauthError("unauthorized_client", "client is no service account")
+		return
+	}
+	allowed := strings.Fields(client.Scopes)
+	for _, requested := range strings.Fields(scope) {
+		if !hasScope(client.Scopes, requested) {
+			err = oauthError("invalid_scope", requested)
+			return
+		}
+	}
+	if len(scope) > 0 {
+		allowed = strings.Fields(scope)
+	}
+
+	res.Scope = strings.Join(allowed, " ")
+	res.AccessToken, _, err = s.TokenAPI.GenerateServiceAccessToken(client.ID, client.InstanceID, roles.Role(client.Role.String), res.Scope)
+	if err != nil {
+		return
+	}
+	res.TokenType = "Bearer"
+	res.ExpiresIn = int64(authtokens.AccessTokenExpiry.Seconds())
+	return
+}
+
+// serviceAccountInstance returns the authorized instance if the user can manage its service accounts.
+func serviceAccountInstance(ctx *gin.Context) (string, error) {
+	if !roles.CanActIn(ctx, roles.RoleInstanceAdmin) {
+		return "", errors.WithStack(roles.ErrUnauthorized)
+	}
+	return roles.Instance(ctx)
+}
+
+func validRole(role roles.Role) bool {
+	for _, r := range roles.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func serviceAccountResponse(c *m.OauthClient) ServiceAccountResponse {
+	return ServiceAccountResponse{
+		ClientID:  c.ID,
+		Name:      c.Name,
+		Role:      roles.Role(c.Role.String),
+		Scopes:    strings.Fields(c.Scopes),
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+var (
+	ErrServiceAccountNotFound = errors.New("service account not found")
+)
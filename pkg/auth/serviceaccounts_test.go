@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	libtokens "github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_clientCredentialsGrant(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	tokenEnv := tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+		Issuer:            "auth",
+		Audience:          "test",
+	}
+	tokenAPI, err := tokens.Setup(tokenEnv)
+	require.CmpNoError(err)
+
+	secret, digest, err := tokens.GenerateOpaqueToken()
+	require.CmpNoError(err)
+	client := &m.OauthClient{
+		ID:         xid.New().String(),
+		InstanceID: xid.New().String(),
+		Name:       "billing",
+		Secret:     null.BytesFrom(digest),
+		Role:       null.StringFrom(string(roles.RoleEventOrganizer)),
+		Scopes:     "workshops:read workshops:write",
+	}
+	mock.EXPECT().
+		GetOAuthClient(gomock.Any(), gomock.Eq(client.ID)).
+		Return(client, nil).
+		AnyTimes()
+
+	service := Service{Env: Env{TokenEnv: tokenEnv}, DBAPI: mock, TokenAPI: tokenAPI}
+	token := func(form url.Values) *gin.Context {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+		ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		ctx.Request.SetBasicAuth(client.ID, secret)
+		return ctx
+	}
+
+	// when
+	ctx := token(url.Values{"grant_type": {"client_credentials"}, "scope": {"workshops:read"}})
+	res, err := service.Token(ctx)
+
+	// then
+	require.CmpNoError(err)
+	assert.Cmp(res.Scope, "workshops:read")
+	assert.Empty(res.RefreshToken)
+	var claims libtokens.AccessTokenClaims
+	_, _, err = jwt.NewParser().ParseUnverified(res.AccessToken, &claims)
+	require.CmpNoError(err)
+	assert.Cmp(claims.Subject, client.ID)
+	assert.Cmp(claims.Instance, client.InstanceID)
+	assert.Cmp(claims.Role, string(roles.RoleEventOrganizer))
+
+	// scopes beyond the allowed ones are rejected
+	ctx = token(url.Values{"grant_type": {"client_credentials"}, "scope": {"users:write"}})
+	_, err = service.Token(ctx)
+	var oauthErr *OAuthError
+	require.True(errors.As(err, &oauthErr))
+	assert.Cmp(oauthErr.Code, "invalid_scope")
+}
+
+func (s *MySuite) Test_createServiceAccountRequiresRole(assert, require *td.T) {
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+
+	create := func(role roles.Role, body string) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/service-accounts", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set(roles.RoleKey, role)
+		ctx.Set(roles.InstanceKey, xid.New().String())
+		_, err := service.CreateServiceAccount(ctx)
+		return err
+	}
+
+	// teachers can not manage service accounts
+	err := create(roles.RoleTeacher, `{"name":"billing","role":"teacher"}`)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// instance admins can not create service accounts acting as super admin
+	err = create(roles.RoleInstanceAdmin, `{"name":"billing","role":"super admin"}`)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// instance admins can create service accounts with lower roles
+	mock.EXPECT().
+		CreateOAuthClient(gomock.Any(), gomock.Any()).
+		Return(nil)
+	err = create(roles.RoleInstanceAdmin, `{"name":"billing","role":"event organizer","scopes":["workshops:read"]}`)
+	assert.CmpNoError(err)
+}
+
+func (s *MySuite) Test_serviceAccountOfDeletedInstance(assert, require *td.T) {
+	// given a service account whose instance is deleted, so it is not loaded with the client
+	secret, digest, err := tokens.GenerateOpaqueToken()
+	require.CmpNoError(err)
+	clientID := xid.New().String()
+	instanceID := xid.New().String()
+	deleted := false
+	conn := openFakeDB(func(query string, args []driver.Value) (driver.Rows, error) {
+		switch {
+		case strings.Contains(query, `FROM "auth"."oauth_clients"`):
+			return &fakeRows{
+				columns: []string{"id", "instance_id", "name", "secret", "role", "scopes"},
+				values:  [][]driver.Value{{clientID, instanceID, "billing", digest, string(roles.RoleEventOrganizer), "workshops:read"}},
+			}, nil
+		case strings.Contains(query, `FROM "auth"."instances"`):
+			instances := &fakeRows{columns: []string{"id", "url"}}
+			if !deleted {
+				instances.values = [][]driver.Value{{instanceID, "smartnuance.com"}}
+			}
+			return instances, nil
+		}
+		return nil, errors.Errorf("unexpected query %s", query)
+	})
+	defer conn.Close()
+
+	service := Service{Env: Env{TokenEnv: tokens.TokenEnv{Issuer: "auth", Audience: "test"}}, DBAPI: &dbAPI{DB: conn}, TokenAPI: testTokenAPI(require)}
+	token := func() (int, string) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(url.Values{"grant_type": {"client_credentials"}}.Encode()))
+		ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		ctx.Request.SetBasicAuth(clientID, secret)
+		TokenHandler(ctx, &service)
+		return w.Code, w.Body.String()
+	}
+
+	// when/then the service account gets tokens while its instance exists
+	code, _ := token()
+	assert.Cmp(code, http.StatusOK)
+
+	// and is rejected as unknown client after the instance is deleted
+	deleted = true
+	code, body := token()
+	assert.Cmp(code, http.StatusUnauthorized)
+	assert.Contains(body, `"invalid_client"`)
+}
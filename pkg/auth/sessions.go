@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/friendsofgo/errors"
+
+	"github.com/gin-gonic/gin"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+// SessionsQuery describes the user/instance to list sessions for
+type SessionsQuery struct {
+	Email       string `form:"email"`
+	InstanceURL string `form:"instance"`
+}
+
+// SessionResponse describes a session, i.e. the refresh tokens descending from one login.
+type SessionResponse struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"createdAt"`
+	RefreshedAt time.Time `json:"refreshedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	UserAgent   string    `json:"userAgent"`
+	IP          string    `json:"ip"`
+}
+
+// Sessions lists the active sessions of a user's profile in a specific instance or falls back to the authorization tokens instance.
+func (s *Service) Sessions(ctx *gin.Context) ([]SessionResponse, error) {
+	var query SessionsQuery
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID string
+	if len(query.Email) > 0 {
+		user, err := s.DBAPI.FindUserByEmail(ctx, query.Email)
+		if err != nil {
+			return nil, err
+		}
+		userID = user.ID
+	} else {
+		userID, err = roles.User(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var instanceID string
+	if len(query.InstanceURL) > 0 {
+		instance, err := s.DBAPI.GetInstance(ctx, query.InstanceURL)
+		if err != nil {
+			return nil, err
+		}
+		instanceID = instance.ID
+	} else {
+		// fallback to default instance from headers
+		instanceID, err = roles.Instance(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check permission to list sessions of potentially different user
+	if !canManageSessions(ctx, userID, instanceID) {
+		return nil, errors.WithStack(roles.ErrUnauthorized)
+	}
+
+	profile, err := s.DBAPI.GetProfile(ctx, userID, instanceID)
+	if err != nil {
+		return nil, errors.WithStack(ErrProfileDoesNotExist)
+	}
+
+	tokens, err := s.DBAPI.ListSessions(ctx, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]SessionResponse, 0, len(tokens))
+	for _, t := range tokens {
+		sessions = append(sessions, sessionResponse(t))
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes all refresh tokens of a single session.
+func (s *Service) RevokeSession(ctx *gin.Context) error {
+	token, err := s.DBAPI.GetSession(ctx, ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	// Check permission to revoke session of potentially different user
+	if !canManageSessions(ctx, token.UserID, token.R.Profile.InstanceID) {
+		return errors.WithStack(roles.ErrUnauthorized)
+	}
+
+	_, err = s.DBAPI.DeleteTokenFamily(ctx, token.Family)
+	if err != nil {
+		return err
+	}
+	s.syncDenylist(ctx)
+	return nil
+}
+
+// canManageSessions checks the same permission as required to revoke a profile's tokens.
+func canManageSessions(ctx *gin.Context, userID, instanceID string) bool {
+	return roles.CanActAs(ctx, userID) ||
+		(roles.CanActFor(ctx, instanceID) && roles.CanActIn(ctx, roles.RoleInstanceAdmin))
+}
+
+func sessionResponse(t *m.Token) SessionResponse {
+	return SessionResponse{
+		ID:          t.Family,
+		CreatedAt:   t.LoggedInAt,
+		RefreshedAt: t.CreatedAt,
+		ExpiresAt:   t.ExpiresAt,
+		UserAgent:   t.UserAgent.String,
+		IP:          t.IP.String,
+	}
+}
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
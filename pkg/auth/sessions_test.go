@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_sessions(assert, require *td.T) {
+	// given a user with two sessions in an instance
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+
+	instance := &m.Instance{ID: xid.New().String(), URL: "dance.example.com"}
+	user := &m.User{ID: xid.New().String(), Email: "jane@example.com"}
+	profile := &m.Profile{ID: xid.New().String(), UserID: user.ID, InstanceID: instance.ID}
+	loggedInAt := time.Now().Add(-time.Hour)
+	tokens := m.TokenSlice{
+		{Family: "f1", UserID: user.ID, ProfileID: profile.ID, LoggedInAt: loggedInAt, UserAgent: null.StringFrom("firefox"), IP: null.StringFrom("192.0.2.1")},
+		{Family: "f2", UserID: user.ID, ProfileID: profile.ID, LoggedInAt: loggedInAt, UserAgent: null.StringFrom("curl")},
+	}
+	mock.EXPECT().
+		FindUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Return(user, nil).
+		AnyTimes()
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq(instance.URL)).
+		Return(instance, nil).
+		AnyTimes()
+	list := func(query, userID, instanceID string, role roles.Role) ([]SessionResponse, error) {
+		return service.Sessions(meContext(http.MethodGet, "/sessions"+query, "", userID, instanceID, role))
+	}
+	expectList := func() {
+		mock.EXPECT().
+			GetProfile(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(instance.ID)).
+			Return(profile, nil)
+		mock.EXPECT().
+			ListSessions(gomock.Any(), gomock.Eq(profile.ID)).
+			Return(tokens, nil)
+	}
+
+	// when/then users list their own sessions
+	expectList()
+	sessions, err := list("", user.ID, instance.ID, roles.NoRole)
+	require.CmpNoError(err)
+	assert.Cmp(sessions, []SessionResponse{
+		{ID: "f1", CreatedAt: loggedInAt, UserAgent: "firefox", IP: "192.0.2.1"},
+		{ID: "f2", CreatedAt: loggedInAt, UserAgent: "curl"},
+	})
+
+	// when/then other users of the instance can not list them
+	_, err = list("?email=jane@example.com", xid.New().String(), instance.ID, roles.RoleEventOrganizer)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// when/then instance admins list them in their instance
+	expectList()
+	sessions, err = list("?email=jane@example.com", xid.New().String(), instance.ID, roles.RoleInstanceAdmin)
+	require.CmpNoError(err)
+	assert.Len(sessions, 2)
+
+	// when/then instance admins of other instances can not list them
+	_, err = list("?email=jane@example.com&instance=dance.example.com", xid.New().String(), xid.New().String(), roles.RoleInstanceAdmin)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+}
+
+func (s *MySuite) Test_revokeSession(assert, require *td.T) {
+	// given a session of a user in an instance
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+
+	userID := xid.New().String()
+	instanceID := xid.New().String()
+	token := &m.Token{Family: xid.New().String(), UserID: userID}
+	token.R = token.R.NewStruct()
+	token.R.Profile = &m.Profile{UserID: userID, InstanceID: instanceID}
+	mock.EXPECT().
+		GetSession(gomock.Any(), gomock.Eq(token.Family)).
+		Return(token, nil).
+		AnyTimes()
+	revoke := func(userID, instanceID string, role roles.Role) error {
+		ctx := meContext(http.MethodDelete, "/sessions/"+token.Family, "", userID, instanceID, role)
+		ctx.Params = gin.Params{{Key: "id", Value: token.Family}}
+		return service.RevokeSession(ctx)
+	}
+
+	// when/then sessions of other users can not be revoked
+	err := revoke(xid.New().String(), instanceID, roles.RoleTeacher)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// when/then instance admins of other instances can not revoke it
+	err = revoke(xid.New().String(), xid.New().String(), roles.RoleInstanceAdmin)
+	assert.True(errors.Is(err, roles.ErrUnauthorized))
+
+	// when/then users revoke their own session and instance admins those in their instance
+	mock.EXPECT().
+		DeleteTokenFamily(gomock.Any(), gomock.Eq(token.Family)).
+		Return(int64(1), nil).
+		Times(2)
+	assert.CmpNoError(revoke(userID, instanceID, roles.NoRole))
+	assert.CmpNoError(revoke(xid.New().String(), instanceID, roles.RoleInstanceAdmin))
+}
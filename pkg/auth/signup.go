@@ -2,14 +2,15 @@ package auth
 
 import (
 	"database/sql"
+	"net/mail"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/friendsofgo/errors"
 	"github.com/gin-gonic/gin"
 	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
 	"github.com/smartnuance/saas-kit/pkg/lib/roles"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // SignupBody describes the signup body with desired credentials
@@ -20,6 +21,7 @@ type SignupBody struct {
 	Password    string `json:"password"`
 }
 
+// Signup creates a user with a profile for the instance, if the password satisfies the instance's password policy.
 func (s *Service) Signup(ctx *gin.Context) (userID string, err error) {
 	var body SignupBody
 	err = ctx.ShouldBind(&body)
@@ -31,14 +33,20 @@ func (s *Service) Signup(ctx *gin.Context) (userID string, err error) {
 	if err != nil {
 		return
 	}
+	err = s.checkPassword(instancePolicy(instance), body.Password)
+	if err != nil {
+		return
+	}
 
-	return s.signup(ctx, instance.ID, body, roles.NoRole)
+	return s.signup(ctx, instance.ID, body, roles.NoRole, false)
 }
 
-func (s *Service) signup(ctx *gin.Context, instanceID string, body SignupBody, role roles.Role) (userID string, err error) {
+// signup creates a user with a profile for the given instance.
+// Unless the user is activated right away, a verification mail is sent to the user's email.
+func (s *Service) signup(ctx *gin.Context, instanceID string, body SignupBody, role roles.Role, activate bool) (userID string, err error) {
 	log.Debug().Msgf("Signup user %s with email %s to %s with role %s", body.Name, body.Email, instanceID, role)
-	if len(body.Email) == 0 {
-		err = ErrInvalidEmail
+	err = checkEmail(body.Email)
+	if err != nil {
 		return
 	}
 	if len(body.Password) == 0 {
@@ -46,7 +54,7 @@ func (s *Service) signup(ctx *gin.Context, instanceID string, body SignupBody, r
 		return
 	}
 
-	hashedPassword, err := hashAndSaltPassword(body.Password)
+	hashedPassword, err := s.hashPassword(body.Password)
 	if err != nil {
 		return
 	}
@@ -69,6 +77,16 @@ func (s *Service) signup(ctx *gin.Context, instanceID string, body SignupBody, r
 		return
 	}
 
+	var verificationToken string
+	if activate {
+		err = s.DBAPI.ActivateUser(ctx, tx, user.ID)
+	} else {
+		verificationToken, err = s.createVerification(ctx, tx, user.ID, user.Email)
+	}
+	if err != nil {
+		return
+	}
+
 	err = s.DBAPI.Commit(tx)
 	if err != nil {
 		errRollback := s.DBAPI.Rollback(tx)
@@ -78,11 +96,36 @@ func (s *Service) signup(ctx *gin.Context, instanceID string, body SignupBody, r
 		return
 	}
 
+	if !activate {
+		// the user can request another verification mail, so do not fail the signup
+		errMail := s.sendVerification(ctx, user.Email, verificationToken)
+		if errMail != nil {
+			log.Error().Stack().Err(errMail).Msg("")
+		}
+	}
+
 	return user.ID, nil
 }
 
-func hashAndSaltPassword(password string) ([]byte, error) {
-	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// checkEmail only accepts plain addresses like jane@example.com, without display name or line breaks that could end up in mail headers.
+func checkEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return errors.WithStack(ErrInvalidEmail)
+	}
+	return nil
+}
+
+// hashPassword salts and hashes a password with the configured hasher, argon2id if none is configured.
+func (s *Service) hashPassword(pw string) ([]byte, error) {
+	return s.passwordHasher().Hash(pw)
+}
+
+func (s *Service) passwordHasher() password.Hasher {
+	if len(s.PasswordHasher.Algorithm) == 0 {
+		return password.DefaultHasher
+	}
+	return s.PasswordHasher
 }
 
 var (
@@ -1,14 +1,23 @@
 package auth
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/friendsofgo/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/maxatome/go-testdeep/helpers/tdsuite"
 	"github.com/maxatome/go-testdeep/td"
 	"github.com/rs/xid"
 	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
 	"github.com/volatiletech/null/v8"
 )
 
@@ -41,7 +50,7 @@ func (s *MySuite) Test_signup(assert, require *td.T) {
 		Return(user, nil)
 
 	mock.EXPECT().
-		CreateProfile(gomock.Any(), gomock.Any(), gomock.Eq(instanceID), gomock.Eq(user), gomock.Eq("teacher")).
+		CreateProfile(gomock.Any(), gomock.Any(), gomock.Eq(instanceID), gomock.Eq(user), gomock.Eq(roles.RoleTeacher)).
 		Return(&m.Profile{
 			ID:         xid.New().String(),
 			UserID:     user.ID,
@@ -49,20 +58,81 @@ func (s *MySuite) Test_signup(assert, require *td.T) {
 			Role:       null.StringFrom("teacher"),
 		}, nil)
 
+	mock.EXPECT().
+		CreateVerification(gomock.Any(), gomock.Any(), gomock.Eq(user.ID), gomock.Eq("yanis@example.com"), gomock.Len(32), gomock.Any()).
+		Return(nil)
+
 	mock.
 		EXPECT().
 		Commit(gomock.Any()).
 		Return(nil)
 
+	mailDir := require.TempDir()
 	service := Service{
-		DBAPI: mock,
+		Env: Env{
+			PublicURL: "http://localhost",
+		},
+		DBAPI:  mock,
+		Mailer: mail.FileSender{Dir: mailDir},
 	}
 	ctx := &gin.Context{}
 
 	// when
-	userID, err := service.signup(ctx, instanceID, SignupBody{Name: "Yanis", Email: "yanis@example.com", Password: "test"}, "teacher")
+	userID, err := service.signup(ctx, instanceID, SignupBody{Name: "Yanis", Email: "yanis@example.com", Password: "test"}, "teacher", false)
 
 	// then
 	assert.CmpNoError(err)
 	assert.CmpLax(userID, user.ID)
+
+	mails, err := filepath.Glob(filepath.Join(mailDir, "*.eml"))
+	require.CmpNoError(err)
+	require.Len(mails, 1)
+	content, err := ioutil.ReadFile(mails[0])
+	require.CmpNoError(err)
+	assert.Contains(string(content), "To: yanis@example.com")
+	assert.Contains(string(content), "http://localhost/verify?token=")
+}
+
+func (s *MySuite) Test_signupRejectsWeakPassword(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+
+	mock.EXPECT().
+		GetInstance(gomock.Any(), gomock.Eq("smartnuance.com")).
+		Return(&m.Instance{ID: xid.New().String(), URL: "smartnuance.com", PasswordMinLength: 10, PasswordMinClasses: 2}, nil)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPut, "/signup", strings.NewReader(`{"instance":"smartnuance.com","email":"yanis@example.com","password":"test"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	// when
+	_, err := service.Signup(ctx)
+
+	// then no user is created and the violated rules are listed
+	var policyErr *PasswordPolicyError
+	require.True(errors.As(err, &policyErr))
+	assert.Cmp(policyErr.Rules, []password.Rule{password.RuleMinLength, password.RuleCharacterClasses})
+}
+
+func (s *MySuite) Test_signupRejectsInvalidEmail(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+	ctx := &gin.Context{}
+
+	for _, email := range []string{
+		"",
+		"yanis",
+		"Yanis <yanis@example.com>",
+		"yanis@example.com\r\nBcc: victim@example.com",
+	} {
+		// when
+		_, err := service.signup(ctx, xid.New().String(), SignupBody{Name: "Yanis", Email: email, Password: "test"}, roles.NoRole, false)
+
+		// then no user is created
+		assert.True(errors.Is(err, ErrInvalidEmail), email)
+	}
 }
@@ -0,0 +1,298 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	authtokens "github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+// lockCacheTTL limits the time a lock is served from memory, so unlocks on other replicas take effect soon.
+const lockCacheTTL = 10 * time.Second
+
+// maxChallengeFailures is the number of wrong second factors after which a login challenge is invalidated.
+const maxChallengeFailures = 3
+
+// LoginThrottle locks out accounts and client IPs after repeated failed logins.
+// Each failure beyond the threshold doubles the lockout, failures are forgotten a window after the last one.
+// Locks are stored in the database and served from memory, so locked out attempts do not hit the database.
+type LoginThrottle struct {
+	DBAPI DBAPI
+	// AccountThreshold is the number of failed logins of an account before it is locked
+	AccountThreshold int
+	// IPThreshold is the number of failed logins from a client IP before it is locked
+	IPThreshold int
+	// Lockout is the duration of the first lockout
+	Lockout time.Duration
+	// MaxLockout caps the exponential backoff
+	MaxLockout time.Duration
+	// Window is the time failed logins are remembered after the last failure
+	Window time.Duration
+	// Now returns the current time
+	Now func() time.Time
+
+	mu    sync.Mutex
+	locks map[string]cachedLock
+}
+
+type cachedLock struct {
+	until    time.Time
+	cachedAt time.Time
+}
+
+func NewLoginThrottle(dbAPI DBAPI, env Env) *LoginThrottle {
+	return &LoginThrottle{
+		DBAPI:            dbAPI,
+		AccountThreshold: env.LoginAccountThreshold,
+		IPThreshold:      env.LoginIPThreshold,
+		Lockout:          env.LoginLockout,
+		MaxLockout:       env.LoginMaxLockout,
+		Window:           env.LoginAttemptWindow,
+		Now:              time.Now,
+		locks:            map[string]cachedLock{},
+	}
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func challengeKey(id string) string {
+	return "challenge:" + id
+}
+
+// Check fails with ErrLoginLocked if the account or client IP is locked out.
+func (t *LoginThrottle) Check(ctx context.Context, email, ip string) error {
+	return t.check(ctx, accountKey(email), ipKey(ip))
+}
+
+// CheckChallenge fails with ErrLoginLocked if the account or client IP is locked out or the challenge was invalidated.
+func (t *LoginThrottle) CheckChallenge(ctx context.Context, email, ip, challengeID string) error {
+	if len(challengeID) == 0 {
+		return t.Check(ctx, email, ip)
+	}
+	return t.check(ctx, accountKey(email), ipKey(ip), challengeKey(challengeID))
+}
+
+func (t *LoginThrottle) check(ctx context.Context, keys ...string) error {
+	now := t.Now()
+
+	t.mu.Lock()
+	for _, key := range keys {
+		lock, ok := t.locks[key]
+		if ok && now.Before(lock.until) && now.Sub(lock.cachedAt) < lockCacheTTL {
+			t.mu.Unlock()
+			return errors.Wrapf(ErrLoginLocked, "%s until %s", key, lock.until)
+		}
+	}
+	t.mu.Unlock()
+
+	locks, err := t.DBAPI.GetLoginLocks(ctx, keys, now)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		until, ok := locks[key]
+		if ok {
+			t.cache(key, until, now)
+			return errors.Wrapf(ErrLoginLocked, "%s until %s", key, until)
+		}
+	}
+	return nil
+}
+
+// Fail counts a failed login of the account from the client IP and locks either out if its threshold is reached.
+func (t *LoginThrottle) Fail(ctx context.Context, email, ip string) error {
+	err := t.fail(ctx, accountKey(email), t.AccountThreshold)
+	if err != nil {
+		return err
+	}
+	return t.fail(ctx, ipKey(ip), t.IPThreshold)
+}
+
+// FailChallenge counts a wrong second factor like a failed login and invalidates the challenge
+// after maxChallengeFailures, so a challenge can not be used to guess codes until it expires.
+func (t *LoginThrottle) FailChallenge(ctx context.Context, email, ip, challengeID string, expiresAt time.Time) error {
+	err := t.Fail(ctx, email, ip)
+	if err != nil || len(challengeID) == 0 {
+		return err
+	}
+
+	now := t.Now()
+	key := challengeKey(challengeID)
+	failures, err := t.DBAPI.RecordLoginFailure(ctx, key, now, expiresAt)
+	if err != nil {
+		return err
+	}
+	if failures < maxChallengeFailures {
+		return nil
+	}
+	err = t.DBAPI.LockLogin(ctx, key, expiresAt, expiresAt)
+	if err != nil {
+		return err
+	}
+	t.cache(key, expiresAt, now)
+	return nil
+}
+
+func (t *LoginThrottle) fail(ctx context.Context, key string, threshold int) error {
+	now := t.Now()
+	failures, err := t.DBAPI.RecordLoginFailure(ctx, key, now, now.Add(t.Window))
+	if err != nil {
+		return err
+	}
+	if failures < threshold {
+		return nil
+	}
+
+	lockout := t.Lockout
+	for i := threshold; i < failures && lockout < t.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > t.MaxLockout {
+		lockout = t.MaxLockout
+	}
+	until := now.Add(lockout)
+	err = t.DBAPI.LockLogin(ctx, key, until, until.Add(t.Window))
+	if err != nil {
+		return err
+	}
+	t.cache(key, until, now)
+	return nil
+}
+
+// Reset forgets the failed logins of an account and lifts its lock, after a successful login or by an admin.
+// Failures of client IPs are not reset by successful logins, as attackers could interleave logins to their own account.
+func (t *LoginThrottle) Reset(ctx context.Context, key string) error {
+	t.mu.Lock()
+	delete(t.locks, key)
+	t.mu.Unlock()
+	_, err := t.DBAPI.ResetLoginAttempts(ctx, key)
+	return err
+}
+
+func (t *LoginThrottle) cache(key string, until, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for k, l := range t.locks {
+		if !now.Before(l.until) {
+			delete(t.locks, k)
+		}
+	}
+	t.locks[key] = cachedLock{until: until, cachedAt: now}
+}
+
+// UnlockBody describes the account or client IP to unlock
+type UnlockBody struct {
+	Email string `json:"email"`
+	IP    string `json:"ip"`
+}
+
+// UnlockLogin lifts the lockout of an account or client IP.
+// Instance admins can unlock accounts with a profile in their instance, client IPs can only be unlocked by super admins.
+func (s *Service) UnlockLogin(ctx *gin.Context) error {
+	var body UnlockBody
+	err := ctx.ShouldBind(&body)
+	if err != nil {
+		return err
+	}
+	if s.Throttle == nil {
+		return nil
+	}
+
+	if len(body.IP) > 0 {
+		if !roles.CanActIn(ctx, roles.RoleSuperAdmin) {
+			return errors.WithStack(roles.ErrUnauthorized)
+		}
+		return s.Throttle.Reset(ctx, ipKey(body.IP))
+	}
+
+	if !roles.CanActIn(ctx, roles.RoleInstanceAdmin) {
+		return errors.WithStack(roles.ErrUnauthorized)
+	}
+	instanceID, err := roles.Instance(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := s.DBAPI.FindUserByEmail(ctx, body.Email)
+	if err != nil {
+		return err
+	}
+	_, err = s.DBAPI.GetProfile(ctx, user.ID, instanceID)
+	if err != nil {
+		return errors.WithStack(roles.ErrUnauthorized)
+	}
+	return s.Throttle.Reset(ctx, accountKey(body.Email))
+}
+
+// checkLoginThrottle fails if the account or the client IP is locked out.
+func (s *Service) checkLoginThrottle(ctx *gin.Context, email string) error {
+	if s.Throttle == nil {
+		return nil
+	}
+	return s.Throttle.Check(ctx, email, ctx.ClientIP())
+}
+
+// checkChallengeThrottle fails if the account or the client IP is locked out or the challenge was invalidated.
+func (s *Service) checkChallengeThrottle(ctx *gin.Context, email string, challengeID string) error {
+	if s.Throttle == nil {
+		return nil
+	}
+	return s.Throttle.CheckChallenge(ctx, email, ctx.ClientIP(), challengeID)
+}
+
+// recordLogin counts failed logins with wrong credentials and resets the account's failures after a successful one.
+// A login requiring a second factor only succeeds with the second factor,
+// otherwise knowing the password would allow to reset the failures of guessed codes.
+func (s *Service) recordLogin(ctx *gin.Context, email string, loginErr error) {
+	if s.Throttle == nil {
+		return
+	}
+	var err error
+	switch {
+	case loginErr == nil:
+		err = s.Throttle.Reset(ctx, accountKey(email))
+	case errors.Is(loginErr, ErrInvalidCredentials) || errors.Is(loginErr, ErrUserDoesNotExist):
+		// unknown emails are counted as well, so they behave like existing accounts
+		err = s.Throttle.Fail(ctx, email, ctx.ClientIP())
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("recording login attempt failed")
+	}
+}
+
+// recordChallenge counts wrong second factors of a login challenge and resets the account's failures after a correct one.
+func (s *Service) recordChallenge(ctx *gin.Context, email string, claims tokens.ChallengeTokenClaims, loginErr error) {
+	if s.Throttle == nil {
+		return
+	}
+	var err error
+	switch {
+	case loginErr == nil:
+		err = s.Throttle.Reset(ctx, accountKey(email))
+	case errors.Is(loginErr, ErrTwoFactorCodeInvalid):
+		expiresAt := s.Throttle.Now().Add(authtokens.ChallengeTokenExpiry)
+		if claims.ExpiresAt != nil {
+			expiresAt = claims.ExpiresAt.Time
+		}
+		err = s.Throttle.FailChallenge(ctx, email, ctx.ClientIP(), claims.ID, expiresAt)
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("recording login attempt failed")
+	}
+}
+
+var (
+	ErrLoginLocked = errors.New("login locked after too many failed attempts")
+)
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/auth/totp"
+	"github.com/volatiletech/null/v8"
+)
+
+func (s *MySuite) Test_loginThrottleBacksOff(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+
+	now := time.Date(2022, 2, 1, 12, 0, 0, 0, time.UTC)
+	throttle := NewLoginThrottle(mock, Env{
+		LoginAccountThreshold: 3,
+		LoginIPThreshold:      100,
+		LoginLockout:          time.Minute,
+		LoginMaxLockout:       3 * time.Minute,
+		LoginAttemptWindow:    time.Hour,
+	})
+	throttle.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	fail := func(failures int) {
+		mock.EXPECT().
+			RecordLoginFailure(gomock.Any(), gomock.Eq("account:simon@smartnuance.com"), gomock.Eq(now), gomock.Eq(now.Add(time.Hour))).
+			Return(failures, nil)
+		mock.EXPECT().
+			RecordLoginFailure(gomock.Any(), gomock.Eq("ip:10.0.0.1"), gomock.Eq(now), gomock.Eq(now.Add(time.Hour))).
+			Return(failures, nil)
+	}
+	lock := func(lockout time.Duration) {
+		mock.EXPECT().
+			LockLogin(gomock.Any(), gomock.Eq("account:simon@smartnuance.com"), gomock.Eq(now.Add(lockout)), gomock.Eq(now.Add(lockout+time.Hour))).
+			Return(nil)
+	}
+
+	// when failing below the threshold, then nothing is locked
+	fail(2)
+	require.CmpNoError(throttle.Fail(ctx, "Simon@smartnuance.com", "10.0.0.1"))
+
+	// when reaching the threshold, then the account is locked
+	fail(3)
+	lock(time.Minute)
+	require.CmpNoError(throttle.Fail(ctx, "simon@smartnuance.com", "10.0.0.1"))
+
+	// and locked attempts are rejected from memory
+	err := throttle.Check(ctx, "simon@smartnuance.com", "10.0.0.2")
+	assert.True(errors.Is(err, ErrLoginLocked))
+
+	// when failing further, then the lockout doubles up to the maximum
+	fail(4)
+	lock(2 * time.Minute)
+	require.CmpNoError(throttle.Fail(ctx, "simon@smartnuance.com", "10.0.0.1"))
+	fail(6)
+	lock(3 * time.Minute)
+	require.CmpNoError(throttle.Fail(ctx, "simon@smartnuance.com", "10.0.0.1"))
+
+	// when unlocked, then the lock is checked with the database again
+	mock.EXPECT().
+		ResetLoginAttempts(gomock.Any(), gomock.Eq("account:simon@smartnuance.com")).
+		Return(int64(1), nil)
+	require.CmpNoError(throttle.Reset(ctx, accountKey("simon@smartnuance.com")))
+	mock.EXPECT().
+		GetLoginLocks(gomock.Any(), gomock.Eq([]string{"account:simon@smartnuance.com", "ip:10.0.0.2"}), gomock.Eq(now)).
+		Return(map[string]time.Time{}, nil)
+	assert.CmpNoError(throttle.Check(ctx, "simon@smartnuance.com", "10.0.0.2"))
+}
+
+func (s *MySuite) Test_lockedLoginSkipsCredentials(assert, require *td.T) {
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock, Throttle: NewLoginThrottle(mock, Env{})}
+
+	// the lock is checked before the credentials, so a correct password does not reveal anything
+	mock.EXPECT().
+		GetLoginLocks(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(map[string]time.Time{"account:simon@smartnuance.com": time.Now().Add(time.Minute)}, nil)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"instance":"smartnuance.com","email":"simon@smartnuance.com","password":"password"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	_, _, _, _, _, err := service.Login(ctx)
+	assert.True(errors.Is(err, ErrLoginLocked))
+}
+
+func (s *MySuite) Test_twoFactorThrottle(assert, require *td.T) {
+	// given a user with TOTP enrolled and a pending login challenge
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+	service := Service{
+		Env:      Env{TokenEnv: tokens.TokenEnv{Issuer: "auth", Audience: "test"}},
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+		Throttle: NewLoginThrottle(mock, Env{LoginAccountThreshold: 10, LoginIPThreshold: 100, LoginLockout: time.Minute, LoginMaxLockout: time.Hour, LoginAttemptWindow: time.Hour}),
+	}
+
+	secret, err := totp.GenerateSecret()
+	require.CmpNoError(err)
+	user := &m.User{ID: xid.New().String(), Email: "simon@smartnuance.com"}
+	mock.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Return(user, nil).
+		AnyTimes()
+	mock.EXPECT().
+		GetTOTPSecret(gomock.Any(), gomock.Eq(user.ID)).
+		Return(&m.TotpSecret{UserID: user.ID, Secret: secret, ConfirmedAt: null.TimeFrom(time.Now())}, nil).
+		AnyTimes()
+	challengeToken, err := tokenAPI.GenerateChallengeToken(user.ID, xid.New().String())
+	require.CmpNoError(err)
+
+	// failures and locks are kept like the database does
+	failures := map[string]int{}
+	locks := map[string]time.Time{}
+	mock.EXPECT().
+		GetLoginLocks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, keys []string, now time.Time) (map[string]time.Time, error) {
+			res := map[string]time.Time{}
+			for _, key := range keys {
+				if until, ok := locks[key]; ok && now.Before(until) {
+					res[key] = until
+				}
+			}
+			return res, nil
+		}).
+		AnyTimes()
+	mock.EXPECT().
+		RecordLoginFailure(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, key string, _, _ time.Time) (int, error) {
+			failures[key]++
+			return failures[key], nil
+		}).
+		AnyTimes()
+	mock.EXPECT().
+		LockLogin(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, key string, until, _ time.Time) error {
+			locks[key] = until
+			return nil
+		}).
+		AnyTimes()
+	login := func(code string) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/login/2fa", strings.NewReader(`{"challengeToken":"`+challengeToken+`","code":"`+code+`"}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Request.RemoteAddr = "10.0.0.1:1234"
+		_, _, _, _, err := service.LoginTwoFactor(ctx)
+		return err
+	}
+
+	// when guessing wrong codes, then they count as failed logins of the account
+	for i := 0; i < maxChallengeFailures; i++ {
+		err = login("000000")
+		assert.True(errors.Is(err, ErrTwoFactorCodeInvalid))
+	}
+	assert.Cmp(failures[accountKey(user.Email)], maxChallengeFailures)
+	assert.Cmp(failures[ipKey("10.0.0.1")], maxChallengeFailures)
+
+	// and the challenge is invalidated, so even the correct code is rejected without being checked
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.CmpNoError(err)
+	err = login(code)
+	assert.True(errors.Is(err, ErrLoginLocked))
+}
+
+func (s *MySuite) Test_throttleIgnoresSpoofedForwardedFor(assert, require *td.T) {
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+	login := func(trustedProxies ...string) {
+		service := &Service{Env: Env{TrustedProxies: trustedProxies}, DBAPI: mock, TokenAPI: tokenAPI, Throttle: NewLoginThrottle(mock, Env{})}
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"email":"simon@smartnuance.com","password":"password"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", "10.0.0.9")
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		router(service).ServeHTTP(w, req)
+		assert.Cmp(w.Code, http.StatusUnauthorized)
+	}
+	locked := func(ip string) {
+		mock.EXPECT().
+			GetLoginLocks(gomock.Any(), gomock.Eq([]string{"account:simon@smartnuance.com", "ip:" + ip}), gomock.Any()).
+			Return(map[string]time.Time{ipKey(ip): time.Now().Add(time.Minute)}, nil)
+	}
+
+	// a client can not choose the IP it is throttled by
+	locked("192.0.2.1")
+	login()
+
+	// unless it is the forwarded IP of a trusted proxy
+	locked("10.0.0.9")
+	login("192.0.2.1")
+}
@@ -0,0 +1,152 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+// A key directory holds a key set for rotation:
+//   <kid>.key      private signing key (PEM)
+//   <kid>.key.pub  public validation key (PEM)
+//   active         the kid of the key used for signing
+// Retired keys keep their public key until all tokens signed with them expired.
+
+const (
+	privateKeySuffix = ".key"
+	publicKeySuffix  = ".key.pub"
+	activeKeyFile    = "active"
+	keyBits          = 4096
+)
+
+func loadKeyDir(dir string) (signingKeyID string, signingKey *rsa.PrivateKey, validationKeys tokens.StaticKeySet, err error) {
+	kids, signingKeyID, err := ListKeys(dir)
+	if err != nil {
+		return
+	}
+	if signingKeyID == "" {
+		err = errors.Errorf("no active signing key in %s, generate and promote one first", dir)
+		return
+	}
+
+	validationKeys = tokens.StaticKeySet{}
+	for _, kid := range kids {
+		var data []byte
+		data, err = ioutil.ReadFile(filepath.Join(dir, kid+publicKeySuffix))
+		if err != nil {
+			err = errors.Wrapf(err, "could not read validation key %s", kid)
+			return
+		}
+		validationKeys[kid], err = jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return
+		}
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, signingKeyID+privateKeySuffix))
+	if err != nil {
+		err = errors.Wrapf(err, "could not read signing key %s", signingKeyID)
+		return
+	}
+	signingKey, err = jwt.ParseRSAPrivateKeyFromPEM(data)
+	return
+}
+
+// ListKeys lists the IDs of all validation keys in dir and the ID of the active signing key.
+func ListKeys(dir string) (kids []string, active string, err error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+publicKeySuffix))
+	if err != nil {
+		return
+	}
+	for _, p := range paths {
+		kids = append(kids, strings.TrimSuffix(filepath.Base(p), publicKeySuffix))
+	}
+	sort.Strings(kids)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, activeKeyFile))
+	if os.IsNotExist(err) {
+		return kids, "", nil
+	}
+	if err != nil {
+		return
+	}
+	active = strings.TrimSpace(string(data))
+	return
+}
+
+// GenerateKey creates a new RSA key pair in dir without activating it.
+// Distribute the new key's public key (e.g. via JWKS) before promoting it to sign tokens.
+func GenerateKey(dir string) (kid string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return
+	}
+	kid = tokens.KeyID(&key.PublicKey)
+
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return
+	}
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, kid+privateKeySuffix), pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600)
+	if err != nil {
+		return
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, kid+publicKeySuffix), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}), 0o644)
+	return
+}
+
+// PromoteKey makes the key with kid the active signing key, retiring the previously active key.
+// The private key of the retired key is deleted, its public key is kept to validate tokens issued before.
+func PromoteKey(dir, kid string) error {
+	_, err := os.Stat(filepath.Join(dir, kid+privateKeySuffix))
+	if err != nil {
+		return errors.Wrapf(err, "no private key for %s", kid)
+	}
+	_, previous, err := ListKeys(dir)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, activeKeyFile), []byte(kid+"\n"), 0o600)
+	if err != nil {
+		return err
+	}
+
+	if previous != "" && previous != kid {
+		err = os.Remove(filepath.Join(dir, previous+privateKeySuffix))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveKey removes a retired key, so tokens signed with it are no longer accepted.
+func RemoveKey(dir, kid string) error {
+	_, active, err := ListKeys(dir)
+	if err != nil {
+		return err
+	}
+	if kid == active {
+		return errors.New("the active signing key can not be removed, promote another key first")
+	}
+	err = os.Remove(filepath.Join(dir, kid+privateKeySuffix))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Remove(filepath.Join(dir, kid+publicKeySuffix))
+}
@@ -0,0 +1,57 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+func (s *MySuite) Test_keyRotation(assert, require *td.T) {
+	// given
+	env := TokenEnv{KeyDir: require.TempDir(), Issuer: "auth", Audience: "test"}
+	oldKid, err := GenerateKey(env.KeyDir)
+	require.CmpNoError(err)
+	require.CmpNoError(PromoteKey(env.KeyDir, oldKid))
+
+	c, err := Setup(env)
+	require.CmpNoError(err)
+	oldToken, _, err := c.GenerateAccessToken("user", "instance", roles.RoleTeacher, time.Now())
+	require.CmpNoError(err)
+
+	// when
+	newKid, err := GenerateKey(env.KeyDir)
+	require.CmpNoError(err)
+	require.CmpNoError(PromoteKey(env.KeyDir, newKid))
+	c, err = Setup(env)
+	require.CmpNoError(err)
+	newToken, _, err := c.GenerateAccessToken("user", "instance", roles.RoleTeacher, time.Now())
+	require.CmpNoError(err)
+
+	// then
+	assert.Cmp(c.signingKeyID, newKid)
+	assert.Len(c.JWKS().Keys, 2)
+	var claims tokens.AccessTokenClaims
+	assert.CmpNoError(tokens.CheckAccessToken(context.Background(), oldToken, &claims, c.ValidationKeys, "auth", "test"))
+	assert.CmpNoError(tokens.CheckAccessToken(context.Background(), newToken, &claims, c.ValidationKeys, "auth", "test"))
+
+	// when retired key is removed
+	assert.CmpError(RemoveKey(env.KeyDir, newKid))
+	require.CmpNoError(RemoveKey(env.KeyDir, oldKid))
+	c, err = Setup(env)
+	require.CmpNoError(err)
+
+	// then
+	assert.CmpError(tokens.CheckAccessToken(context.Background(), oldToken, &claims, c.ValidationKeys, "auth", "test"))
+	assert.CmpNoError(tokens.CheckAccessToken(context.Background(), newToken, &claims, c.ValidationKeys, "auth", "test"))
+}
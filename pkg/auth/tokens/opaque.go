@@ -0,0 +1,43 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/friendsofgo/errors"
+	"github.com/rs/xid"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+// GenerateOpaqueToken creates a random, URL-safe token to be handed out once (e.g. by mail)
+// together with the digest under which it should be stored.
+func GenerateOpaqueToken() (token string, digest []byte, err error) {
+	b := make([]byte, 32)
+	_, err = rand.Read(b)
+	if err != nil {
+		err = errors.Wrap(err, "generating opaque token failed")
+		return
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	digest = Digest(token)
+	return
+}
+
+// GenerateAPIKey creates a prefixed API key with a new ID together with the digest under which it should be stored.
+func GenerateAPIKey() (id, key string, digest []byte, err error) {
+	secret, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return
+	}
+	id = xid.New().String()
+	key = tokens.FormatAPIKey(id, secret)
+	digest = Digest(key)
+	return
+}
+
+// Digest hashes a token for storage and lookup, so a database leak does not expose usable tokens.
+func Digest(token string) []byte {
+	d := sha256.Sum256([]byte(token))
+	return d[:]
+}
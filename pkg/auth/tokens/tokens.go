@@ -3,16 +3,21 @@ package tokens
 import (
 	"crypto/rsa"
 	"io/ioutil"
+	"sort"
 	"time"
 
 	"github.com/friendsofgo/errors"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/rs/xid"
+	"github.com/smartnuance/saas-kit/pkg/auth/oidc"
 	"github.com/smartnuance/saas-kit/pkg/lib"
 	"github.com/smartnuance/saas-kit/pkg/lib/roles"
 	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
 )
 
 type TokenEnv struct {
+	// KeyDir is a directory holding a key set for rotation; when set, SigningKeyPath and ValidationKeyPath are ignored
+	KeyDir            string
 	SigningKeyPath    string
 	ValidationKeyPath string
 	// Issuer is the issuer string of JWT tokens; defaults to service name
@@ -23,8 +28,10 @@ type TokenEnv struct {
 
 type TokenController struct {
 	TokenEnv
-	signingKey    *rsa.PrivateKey
-	ValidationKey *rsa.PublicKey
+	signingKey   *rsa.PrivateKey
+	signingKeyID string
+	// ValidationKeys are the public keys of the active and retired signing keys, indexed by key ID
+	ValidationKeys tokens.StaticKeySet
 }
 
 func Load(envs map[string]string, serviceName string) TokenEnv {
@@ -37,6 +44,7 @@ func Load(envs map[string]string, serviceName string) TokenEnv {
 		audience = lib.DefaultAudience
 	}
 	return TokenEnv{
+		KeyDir:            envs["TOKEN_KEY_DIR"],
 		SigningKeyPath:    envs["TOKEN_SIGNING_KEY_PATH"],
 		ValidationKeyPath: envs["TOKEN_VALIDATION_KEY_PATH"],
 		Issuer:            issuer,
@@ -47,6 +55,11 @@ func Load(envs map[string]string, serviceName string) TokenEnv {
 func Setup(env TokenEnv) (c *TokenController, err error) {
 	c = &TokenController{TokenEnv: env}
 
+	if len(env.KeyDir) > 0 {
+		c.signingKeyID, c.signingKey, c.ValidationKeys, err = loadKeyDir(env.KeyDir)
+		return
+	}
+
 	signingKey, err := ioutil.ReadFile(env.SigningKeyPath)
 	if err != nil {
 		err = errors.Wrapf(err, "could not read signing key file at "+env.SigningKeyPath)
@@ -62,27 +75,60 @@ func Setup(env TokenEnv) (c *TokenController, err error) {
 		err = errors.Wrapf(err, "could not read validation key file at "+env.ValidationKeyPath)
 		return
 	}
-	c.ValidationKey, err = jwt.ParseRSAPublicKeyFromPEM(validationKey)
+	key, err := jwt.ParseRSAPublicKeyFromPEM(validationKey)
 	if err != nil {
 		return
 	}
+	c.signingKeyID = tokens.KeyID(key)
+	c.ValidationKeys = tokens.StaticKeySet{c.signingKeyID: key}
 	return
 }
 
-func (c *TokenController) GenerateAccessToken(userID, instanceID string, role roles.Role) (token string, err error) {
+// JWKS returns the public keys to validate tokens issued by this controller.
+func (c *TokenController) JWKS() tokens.JWKS {
+	jwks := tokens.JWKS{Keys: []tokens.JWK{}}
+	for kid, key := range c.ValidationKeys {
+		jwks.Keys = append(jwks.Keys, tokens.NewJWK(kid, key))
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID })
+	return jwks
+}
+
+// AccessTokenExpiry is the lifetime of access tokens.
+const AccessTokenExpiry = 15 * time.Minute
+
+// GenerateAccessToken issues an access token with a unique ID (jti) to deny it later on.
+// The time the user authenticated with credentials is included, so recent authentication can be required.
+func (c *TokenController) GenerateAccessToken(userID, instanceID string, role roles.Role, authTime time.Time) (token, jti string, err error) {
+	return c.generateAccessToken(userID, instanceID, role, "", authTime)
+}
+
+// GenerateServiceAccessToken issues an access token to a service account, limited to the given space separated scopes.
+func (c *TokenController) GenerateServiceAccessToken(clientID, instanceID string, role roles.Role, scope string) (token, jti string, err error) {
+	return c.generateAccessToken(clientID, instanceID, role, scope, time.Time{})
+}
+
+func (c *TokenController) generateAccessToken(subject, instanceID string, role roles.Role, scope string, authTime time.Time) (token, jti string, err error) {
+	jti = xid.New().String()
 	claims := tokens.AccessTokenClaims{
 		Purpose:  tokens.AccessPurpose,
 		Role:     string(role),
 		Instance: instanceID,
+		Scope:    scope,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   userID,
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 15)),
+			ID:        jti,
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExpiry)),
 			Issuer:    c.Issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Audience:  []string{c.Audience},
 		},
 	}
+	if !authTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(authTime)
+	}
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, &claims)
+	jwtToken.Header["kid"] = c.signingKeyID
 
 	token, err = jwtToken.SignedString(c.signingKey)
 	if err != nil {
@@ -103,10 +149,13 @@ func (c *TokenController) GenerateRefreshToken(userID, instanceID string) (token
 			Issuer:    c.Issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Audience:  []string{c.Audience},
+			// tokens issued within the same second differ only by their ID, which keeps their digests unique
+			ID: xid.New().String(),
 		},
 	}
 
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	jwtToken.Header["kid"] = c.signingKeyID
 
 	token, err = jwtToken.SignedString(c.signingKey)
 	if err != nil {
@@ -115,3 +164,49 @@ func (c *TokenController) GenerateRefreshToken(userID, instanceID string) (token
 	}
 	return
 }
+
+// GenerateIDToken issues an ID token to a client of the auth service as OpenID Connect provider.
+// Issuer and audience of the claims are set by the caller, as they differ from access tokens.
+func (c *TokenController) GenerateIDToken(claims oidc.IDTokenClaims) (token string, err error) {
+	claims.IssuedAt = jwt.NewNumericDate(time.Now())
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(AccessTokenExpiry))
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	jwtToken.Header["kid"] = c.signingKeyID
+
+	token, err = jwtToken.SignedString(c.signingKey)
+	if err != nil {
+		err = errors.Wrap(err, "signing id token failed")
+		return
+	}
+	return
+}
+
+// ChallengeTokenExpiry is the time a user has to provide the second factor after the password step of a login.
+const ChallengeTokenExpiry = 5 * time.Minute
+
+func (c *TokenController) GenerateChallengeToken(userID, instanceID string) (token string, err error) {
+	claims := tokens.ChallengeTokenClaims{
+		Purpose:  tokens.ChallengePurpose,
+		Instance: instanceID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			// the ID identifies the challenge to invalidate it after too many wrong codes
+			ID:        xid.New().String(),
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ChallengeTokenExpiry)),
+			Issuer:    c.Issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Audience:  []string{c.Audience},
+		},
+	}
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	jwtToken.Header["kid"] = c.signingKeyID
+
+	token, err = jwtToken.SignedString(c.signingKey)
+	if err != nil {
+		err = errors.Wrap(err, "signing challenge token failed")
+		return
+	}
+	return
+}
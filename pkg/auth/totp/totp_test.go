@@ -0,0 +1,56 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+// secret of the RFC 6238 test vectors for SHA1
+var secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func (s *MySuite) Test_Code(assert, require *td.T) {
+	// RFC 6238 lists 8 digit codes, the 6 digit codes are their last digits
+	for unix, expected := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := Code(secret, Step(time.Unix(unix, 0)))
+		require.CmpNoError(err)
+		assert.Cmp(code, expected, "time %d", unix)
+	}
+}
+
+func (s *MySuite) Test_Validate(assert, require *td.T) {
+	now := time.Unix(1234567890, 0)
+
+	step, ok := Validate(secret, "005924", now)
+	assert.True(ok)
+	assert.Cmp(step, Step(now))
+
+	// code of the previous period is accepted to tolerate clock drift
+	_, ok = Validate(secret, "005924", now.Add(Period))
+	assert.True(ok)
+
+	_, ok = Validate(secret, "005924", now.Add(3*Period))
+	assert.False(ok)
+
+	_, ok = Validate(secret, "000000", now)
+	assert.False(ok)
+}
+
+func (s *MySuite) Test_URI(assert, require *td.T) {
+	assert.Cmp(URI("saas kit", "simon@smartnuance.com", "ABC"),
+		"otpauth://totp/saas%20kit:simon@smartnuance.com?algorithm=SHA1&digits=6&issuer=saas+kit&period=30&secret=ABC")
+}
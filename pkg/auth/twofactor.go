@@ -0,0 +1,305 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	authtokens "github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/auth/totp"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+// numRecoveryCodes is the number of recovery codes handed out when enrolling.
+const numRecoveryCodes = 10
+
+// ChallengeResponse is returned by the password step of a login requiring a second factor.
+type ChallengeResponse struct {
+	ChallengeToken string `json:"challengeToken"`
+	// Enroll is set if the user has to enroll TOTP before completing the login
+	Enroll bool `json:"enroll"`
+	// Instances are the instances the user has a profile in, if the login did not name an instance
+	Instances []UserInstanceResponse `json:"instances,omitempty"`
+}
+
+// TOTPEnrollment describes the secret to add to an authenticator app.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TwoFactorCodeBody describes a second factor, either a TOTP code or a recovery code
+type TwoFactorCodeBody struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+// ChallengeBody describes the challenge token of a pending login
+type ChallengeBody struct {
+	ChallengeToken string `json:"challengeToken"`
+}
+
+// LoginTwoFactorBody describes the second step of a login
+type LoginTwoFactorBody struct {
+	ChallengeToken string `json:"challengeToken"`
+	TwoFactorCodeBody
+}
+
+// twoFactorChallenge returns a challenge if the login of a user into an instance requires a second factor, otherwise nil.
+// A second factor is required if the user enrolled TOTP or if the instance requires it for the user's role.
+func (s *Service) twoFactorChallenge(ctx *gin.Context, user *m.User, instance *m.Instance) (*ChallengeResponse, error) {
+	enrolled := false
+	secret, err := s.DBAPI.GetTOTPSecret(ctx, user.ID)
+	if err == nil {
+		enrolled = secret.ConfirmedAt.Valid
+	} else if !errors.Is(err, ErrTwoFactorNotEnrolled) {
+		return nil, err
+	}
+
+	required := false
+	if !enrolled && instance.RequireTwoFactor {
+		profile, err := s.DBAPI.GetProfile(ctx, user.ID, instance.ID)
+		if err != nil {
+			return nil, errors.WithStack(ErrProfileDoesNotExist)
+		}
+		required = roles.CanSwitchTo(roles.Role(profile.Role.String), roles.RoleInstanceAdmin)
+	}
+
+	if !enrolled && !required {
+		return nil, nil
+	}
+
+	challengeToken, err := s.TokenAPI.GenerateChallengeToken(user.ID, instance.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &ChallengeResponse{ChallengeToken: challengeToken, Enroll: !enrolled}, nil
+}
+
+// LoginTwoFactor completes a login by the second factor and returns a fresh set of tokens.
+// If the user enrolled during this login, the enrollment is confirmed and recovery codes are returned.
+// Wrong codes count as failed logins of the account and invalidate the challenge after a few attempts.
+func (s *Service) LoginTwoFactor(ctx *gin.Context) (accessToken, refreshToken string, role roles.Role, recoveryCodes []string, err error) {
+	var body LoginTwoFactorBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		err = errors.WithStack(ErrMissingTwoFactorCode)
+		return
+	}
+
+	claims, err := s.checkChallenge(ctx, body.ChallengeToken)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, claims.Subject)
+	if err != nil {
+		return
+	}
+	// wrong codes are throttled like wrong passwords, checked before the code to not reveal anything
+	err = s.checkChallengeThrottle(ctx, user.Email, claims.ID)
+	if err != nil {
+		return
+	}
+
+	secret, err := s.DBAPI.GetTOTPSecret(ctx, user.ID)
+	if err != nil {
+		return
+	}
+	if secret.ConfirmedAt.Valid {
+		err = s.checkSecondFactor(ctx, secret, body.TwoFactorCodeBody)
+	} else {
+		recoveryCodes, err = s.confirmTOTP(ctx, secret, body.Code)
+	}
+	if err != nil {
+		s.recordChallenge(ctx, user.Email, claims, err)
+		return
+	}
+
+	accessToken, refreshToken, role, err = s.startSession(ctx, user.ID, claims.Instance)
+	if err != nil {
+		return
+	}
+	s.recordChallenge(ctx, user.Email, claims, nil)
+	return
+}
+
+// EnrollTOTPForLogin starts the enrollment of a user who has to enroll to complete a pending login.
+func (s *Service) EnrollTOTPForLogin(ctx *gin.Context) (enrollment TOTPEnrollment, err error) {
+	var body ChallengeBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		err = errors.WithStack(ErrMissingChallengeToken)
+		return
+	}
+
+	claims, err := s.checkChallenge(ctx, body.ChallengeToken)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, claims.Subject)
+	if err != nil {
+		return
+	}
+	return s.enrollTOTP(ctx, user)
+}
+
+// EnrollTOTP starts the enrollment of the authorized user.
+func (s *Service) EnrollTOTP(ctx *gin.Context) (enrollment TOTPEnrollment, err error) {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	user, err := s.DBAPI.GetUser(ctx, userID)
+	if err != nil {
+		return
+	}
+	return s.enrollTOTP(ctx, user)
+}
+
+// ConfirmTOTP completes the enrollment of the authorized user with a first code and returns recovery codes.
+func (s *Service) ConfirmTOTP(ctx *gin.Context) (recoveryCodes []string, err error) {
+	var body TwoFactorCodeBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		err = errors.WithStack(ErrMissingTwoFactorCode)
+		return
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return
+	}
+	secret, err := s.DBAPI.GetTOTPSecret(ctx, userID)
+	if err != nil {
+		return
+	}
+	return s.confirmTOTP(ctx, secret, body.Code)
+}
+
+// DisableTOTP disables two-factor authentication of the authorized user, which requires a second factor.
+func (s *Service) DisableTOTP(ctx *gin.Context) error {
+	var body TwoFactorCodeBody
+	err := ctx.ShouldBind(&body)
+	if err != nil {
+		return errors.WithStack(ErrMissingTwoFactorCode)
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return err
+	}
+	secret, err := s.DBAPI.GetTOTPSecret(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !secret.ConfirmedAt.Valid {
+		return errors.WithStack(ErrTwoFactorNotEnrolled)
+	}
+	err = s.checkSecondFactor(ctx, secret, body)
+	if err != nil {
+		return err
+	}
+	return s.DBAPI.DeleteTOTPSecret(ctx, userID)
+}
+
+func (s *Service) checkChallenge(ctx context.Context, challengeToken string) (claims tokens.ChallengeTokenClaims, err error) {
+	err = tokens.CheckChallengeToken(ctx, challengeToken, &claims, s.TokenAPI.ValidationKeys, s.Issuer, s.Audience)
+	if err != nil {
+		err = errors.WithStack(errors.Wrap(err, ErrTokenInvalid.Error()))
+	}
+	return
+}
+
+func (s *Service) enrollTOTP(ctx *gin.Context, user *m.User) (enrollment TOTPEnrollment, err error) {
+	existing, err := s.DBAPI.GetTOTPSecret(ctx, user.ID)
+	if err == nil && existing.ConfirmedAt.Valid {
+		err = errors.WithStack(ErrTwoFactorEnabled)
+		return
+	}
+	if err != nil && !errors.Is(err, ErrTwoFactorNotEnrolled) {
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.SaveTOTPSecret(ctx, user.ID, secret)
+	if err != nil {
+		return
+	}
+	enrollment = TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(s.TOTPIssuer, user.Email, secret),
+	}
+	return
+}
+
+func (s *Service) confirmTOTP(ctx *gin.Context, secret *m.TotpSecret, code string) (recoveryCodes []string, err error) {
+	if secret.ConfirmedAt.Valid {
+		err = errors.WithStack(ErrTwoFactorEnabled)
+		return
+	}
+	step, ok := totp.Validate(secret.Secret, code, time.Now())
+	if !ok {
+		err = errors.WithStack(ErrTwoFactorCodeInvalid)
+		return
+	}
+
+	recoveryCodes, digests, err := generateRecoveryCodes()
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.ConfirmTOTPSecret(ctx, secret.UserID, step, digests)
+	if err != nil {
+		recoveryCodes = nil
+	}
+	return
+}
+
+// checkSecondFactor checks a TOTP code or consumes a recovery code of an enrolled user.
+func (s *Service) checkSecondFactor(ctx *gin.Context, secret *m.TotpSecret, body TwoFactorCodeBody) error {
+	if len(body.RecoveryCode) > 0 {
+		return s.DBAPI.UseRecoveryCode(ctx, secret.UserID, authtokens.Digest(normalizeRecoveryCode(body.RecoveryCode)))
+	}
+	step, ok := totp.Validate(secret.Secret, body.Code, time.Now())
+	if !ok {
+		return errors.WithStack(ErrTwoFactorCodeInvalid)
+	}
+	return s.DBAPI.UseTOTPStep(ctx, secret.UserID, step)
+}
+
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// generateRecoveryCodes creates single-use codes formatted like "abcde-fghij" together with their digests for storage.
+func generateRecoveryCodes() (codes []string, digests [][]byte, err error) {
+	for i := 0; i < numRecoveryCodes; i++ {
+		b := make([]byte, 10)
+		_, err = rand.Read(b)
+		if err != nil {
+			err = errors.Wrap(err, "generating recovery code failed")
+			return
+		}
+		code := recoveryCodeEncoding.EncodeToString(b)[:10]
+		codes = append(codes, code[:5]+"-"+code[5:])
+		digests = append(digests, authtokens.Digest(code))
+	}
+	return
+}
+
+// normalizeRecoveryCode accepts recovery codes typed without dash or in upper case.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+var (
+	ErrMissingTwoFactorCode  = errors.New("missing two-factor code")
+	ErrMissingChallengeToken = errors.New("missing challenge token")
+	ErrTwoFactorNotEnrolled  = errors.New("two-factor authentication is not enrolled")
+	ErrTwoFactorEnabled      = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorCodeInvalid  = errors.New("two-factor code invalid")
+)
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/userdata"
+)
+
+// ErasedEmailSuffix follows the user ID in the email of erased users; the reserved TLD .invalid never receives mail.
+const ErasedEmailSuffix = "@erased.invalid"
+
+// ReauthenticationMaxAge is the time after a login in which users without password can confirm an erasure.
+const ReauthenticationMaxAge = 5 * time.Minute
+
+// UserExport is the data all services hold about a user as JSON, by service name
+type UserExport map[string]json.RawMessage
+
+// UserData is the data the auth service holds about a user
+type UserData struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Email      string            `json:"email"`
+	Verified   bool              `json:"verified"`
+	TwoFactor  bool              `json:"twoFactor"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+	ErasedAt   *time.Time        `json:"erasedAt,omitempty"`
+	Profiles   []ProfileData     `json:"profiles"`
+	Sessions   []SessionResponse `json:"sessions"`
+	Identities []IdentityData    `json:"identities"`
+	Passkeys   []PasskeyResponse `json:"passkeys"`
+	APIKeys    []APIKeyResponse  `json:"apiKeys"`
+}
+
+// ProfileData describes the profile of a user in an instance
+type ProfileData struct {
+	ID           string     `json:"id"`
+	InstanceID   string     `json:"instanceID"`
+	InstanceName string     `json:"instanceName"`
+	InstanceURL  string     `json:"instanceURL"`
+	Role         roles.Role `json:"role"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// IdentityData describes an account at an identity provider linked to a user
+type IdentityData struct {
+	ProviderID string    `json:"providerID"`
+	Subject    string    `json:"subject"`
+	Email      string    `json:"email"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ExportMe exports the data all services hold about the authorized user.
+func (s *Service) ExportMe(ctx *gin.Context) (UserExport, error) {
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ExportUser(ctx, userID)
+}
+
+// EraseMeBody confirms the erasure with the authorized user's password, which users without password leave empty
+type EraseMeBody struct {
+	Password string `json:"password"`
+}
+
+// EraseMe erases the authorized user in all services after the password is confirmed.
+// Users without password, who log in by passkey or an identity provider, have to have logged in recently instead.
+func (s *Service) EraseMe(ctx *gin.Context) error {
+	var body EraseMeBody
+	err := ctx.ShouldBind(&body)
+	if err != nil {
+		return err
+	}
+	userID, err := roles.User(ctx)
+	if err != nil {
+		return err
+	}
+	user, err := s.DBAPI.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(user.Password) > 0 {
+		err = password.Compare(user.Password, body.Password)
+		if err != nil {
+			return errors.WithStack(ErrInvalidCredentials)
+		}
+	} else {
+		authTime, ok := roles.AuthTime(ctx)
+		if !ok || time.Since(authTime) > ReauthenticationMaxAge {
+			return errors.WithStack(ErrReauthenticationRequired)
+		}
+	}
+	return s.EraseUser(ctx, user.ID)
+}
+
+// ExportUser gathers the data all services hold about a user.
+// Secrets like password hashes and token digests are left out.
+func (s *Service) ExportUser(ctx context.Context, userID string) (UserExport, error) {
+	user, err := s.DBAPI.ExportUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(userData(user))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	export := UserExport{ServiceName: data}
+
+	if len(s.UserData) == 0 {
+		return export, nil
+	}
+	token, err := s.userDataToken()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range s.UserData {
+		export[c.Name], err = c.Export(ctx, token, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return export, nil
+}
+
+// EraseUser anonymizes a user in all services.
+// Other services are erased first, so a failed erasure can be repeated until it succeeded everywhere.
+// The user is kept anonymized, so events and workshops organized by the user keep referring to an existing user.
+func (s *Service) EraseUser(ctx context.Context, userID string) error {
+	if len(s.UserData) > 0 {
+		token, err := s.userDataToken()
+		if err != nil {
+			return err
+		}
+		for _, c := range s.UserData {
+			err = c.Erase(ctx, token, userID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	n, err := s.DBAPI.EraseUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	s.syncDenylist(ctx)
+	log.Info().Str("user", userID).Int64("revoked", n).Msg("user erased")
+	return nil
+}
+
+// userDataToken issues an access token that authorizes the auth service to export and erase user data in other services.
+func (s *Service) userDataToken() (string, error) {
+	token, _, err := s.TokenAPI.GenerateServiceAccessToken(ServiceName, "", roles.RoleSuperAdmin, userdata.Scope)
+	return token, err
+}
+
+// WriteZip writes the export as ZIP archive with a JSON file per service.
+func (e UserExport) WriteZip(w io.Writer) error {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	z := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := z.Create(name + ".json")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		_, err = f.Write(e[name])
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return errors.WithStack(z.Close())
+}
+
+func userData(u *m.User) UserData {
+	data := UserData{
+		ID:         u.ID,
+		Name:       u.Name.String,
+		Email:      u.Email,
+		Verified:   u.ActivatedAt.Valid,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+		ErasedAt:   u.ErasedAt.Ptr(),
+		Profiles:   []ProfileData{},
+		Sessions:   []SessionResponse{},
+		Identities: []IdentityData{},
+		Passkeys:   []PasskeyResponse{},
+		APIKeys:    []APIKeyResponse{},
+	}
+	if u.R == nil {
+		return data
+	}
+	for _, p := range u.R.Profiles {
+		profile := ProfileData{
+			ID:         p.ID,
+			InstanceID: p.InstanceID,
+			Role:       roles.Role(p.Role.String),
+			CreatedAt:  p.CreatedAt,
+		}
+		if p.R != nil && p.R.Instance != nil {
+			profile.InstanceName = p.R.Instance.Name
+			profile.InstanceURL = p.R.Instance.URL
+		}
+		data.Profiles = append(data.Profiles, profile)
+	}
+	for _, t := range u.R.Tokens {
+		data.Sessions = append(data.Sessions, sessionResponse(t))
+	}
+	for _, i := range u.R.Identities {
+		data.Identities = append(data.Identities, IdentityData{
+			ProviderID: i.ProviderID,
+			Subject:    i.Subject,
+			Email:      i.Email,
+			CreatedAt:  i.CreatedAt,
+		})
+	}
+	for _, p := range u.R.Passkeys {
+		data.Passkeys = append(data.Passkeys, passkeyResponse(p))
+	}
+	for _, k := range u.R.APIKeys {
+		data.APIKeys = append(data.APIKeys, apiKeyResponse(k))
+	}
+	for _, t := range u.R.TotpSecrets {
+		data.TwoFactor = data.TwoFactor || t.ConfirmedAt.Valid
+	}
+	return data
+}
+
+var (
+	ErrReauthenticationRequired = errors.New("recent login required")
+)
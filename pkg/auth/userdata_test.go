@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	m "github.com/smartnuance/saas-kit/pkg/auth/dbmodels"
+	"github.com/smartnuance/saas-kit/pkg/auth/password"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	libtokens "github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/userdata"
+	"github.com/volatiletech/null/v8"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// userDataServer fakes a service holding user data, checking it is called by the auth service.
+func userDataServer(assert *td.T, tokenAPI *tokens.TokenController, userID string, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var claims libtokens.AccessTokenClaims
+		err := libtokens.CheckAccessToken(r.Context(), strings.TrimPrefix(r.Header.Get("Authorization"), libtokens.BearerSchema), &claims, tokenAPI.ValidationKeys, "auth", "test")
+		assert.CmpNoError(err)
+		assert.Cmp(claims.Role, string(roles.RoleSuperAdmin))
+		assert.Cmp(claims.Scope, userdata.Scope)
+		assert.Cmp(r.URL.Path, userdata.Path+"/"+userID)
+
+		*requests = append(*requests, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"participations":[{"workshopID":"w1"}]}`))
+	}))
+}
+
+func testTokenAPI(require *td.T) *tokens.TokenController {
+	tokenAPI, err := tokens.Setup(tokens.TokenEnv{
+		SigningKeyPath:    "../../test/data/jwtRS256.key",
+		ValidationKeyPath: "../../test/data/jwtRS256.key.pub",
+		Issuer:            "auth",
+		Audience:          "test",
+	})
+	require.CmpNoError(err)
+	return tokenAPI
+}
+
+func (s *MySuite) Test_exportMe(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+
+	userID := xid.New().String()
+	var requests []string
+	server := userDataServer(assert, tokenAPI, userID, &requests)
+	defer server.Close()
+
+	user := &m.User{
+		ID:          userID,
+		Name:        null.StringFrom("Jane"),
+		Email:       "jane@example.com",
+		Password:    []byte("secret hash"),
+		ActivatedAt: null.TimeFrom(time.Now()),
+	}
+	user.R = user.R.NewStruct()
+	profile := &m.Profile{ID: xid.New().String(), UserID: userID, InstanceID: xid.New().String(), Role: null.StringFrom("teacher")}
+	profile.R = profile.R.NewStruct()
+	profile.R.Instance = &m.Instance{ID: profile.InstanceID, Name: "Dance school", URL: "dance.example.com"}
+	user.R.Profiles = m.ProfileSlice{profile}
+	user.R.Tokens = m.TokenSlice{{Family: "f1", UserID: userID, ProfileID: profile.ID, UserAgent: null.StringFrom("curl")}}
+	mock.EXPECT().
+		ExportUser(gomock.Any(), gomock.Eq(userID)).
+		Return(user, nil)
+
+	service := Service{
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+		UserData: []*userdata.Client{userdata.NewClient("event", server.URL+userdata.Path)},
+	}
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/me/export", nil)
+	ctx.Set(roles.UserKey, userID)
+
+	// when
+	export, err := service.ExportMe(ctx)
+
+	// then the export holds the data of all services, without secrets
+	require.CmpNoError(err)
+	assert.Cmp(requests, []string{http.MethodGet})
+	assert.Cmp(export, td.Keys([]string{"auth", "event"}))
+	assert.Cmp(string(export["event"]), `{"participations":[{"workshopID":"w1"}]}`)
+	assert.False(bytes.Contains(export["auth"], user.Password))
+	var data UserData
+	require.CmpNoError(json.Unmarshal(export["auth"], &data))
+	assert.Cmp(data, td.SStruct(UserData{
+		ID:       userID,
+		Name:     "Jane",
+		Email:    "jane@example.com",
+		Verified: true,
+	}, td.StructFields{
+		"CreatedAt": td.Ignore(),
+		"UpdatedAt": td.Ignore(),
+		"Profiles": []ProfileData{{
+			ID:           profile.ID,
+			InstanceID:   profile.InstanceID,
+			InstanceName: "Dance school",
+			InstanceURL:  "dance.example.com",
+			Role:         roles.RoleTeacher,
+			CreatedAt:    profile.CreatedAt,
+		}},
+		"Sessions":   td.All(td.Len(1), td.ArrayEach(td.SuperJSONOf(`{"id":"f1","userAgent":"curl"}`))),
+		"Identities": td.Empty(),
+		"Passkeys":   td.Empty(),
+		"APIKeys":    td.Empty(),
+	}))
+
+	// and the ZIP archive has a JSON file per service
+	var buf bytes.Buffer
+	require.CmpNoError(export.WriteZip(&buf))
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.CmpNoError(err)
+	names := []string{}
+	for _, f := range z.File {
+		names = append(names, f.Name)
+	}
+	assert.Cmp(names, []string{"auth.json", "event.json"})
+}
+
+func (s *MySuite) Test_eraseMe(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	tokenAPI := testTokenAPI(require)
+
+	userID := xid.New().String()
+	var requests []string
+	server := userDataServer(assert, tokenAPI, userID, &requests)
+	defer server.Close()
+
+	hash, err := password.Hasher{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}.Hash("secret")
+	require.CmpNoError(err)
+	mock.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(userID)).
+		Return(&m.User{ID: userID, Email: "jane@example.com", Password: hash}, nil).
+		Times(2)
+
+	service := Service{
+		DBAPI:    mock,
+		TokenAPI: tokenAPI,
+		UserData: []*userdata.Client{userdata.NewClient("event", server.URL+userdata.Path)},
+	}
+	erase := func(body string) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/me", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set(roles.UserKey, userID)
+		return service.EraseMe(ctx)
+	}
+
+	// a wrong password erases nothing
+	err = erase(`{"password":"wrong"}`)
+	assert.True(errors.Is(err, ErrInvalidCredentials))
+	assert.Empty(requests)
+
+	// when the password is confirmed, then the user is erased in the other services before the auth service
+	mock.EXPECT().
+		EraseUser(gomock.Any(), gomock.Eq(userID)).
+		DoAndReturn(func(_ interface{}, _ string) (int64, error) {
+			assert.Cmp(requests, []string{http.MethodDelete})
+			return 1, nil
+		})
+	err = erase(`{"password":"secret"}`)
+	require.CmpNoError(err)
+	assert.Cmp(requests, []string{http.MethodDelete})
+}
+
+func (s *MySuite) Test_eraseMeWithoutPassword(assert, require *td.T) {
+	// given a user who logs in by an identity provider only
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	userID := xid.New().String()
+	mock.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(userID)).
+		Return(&m.User{ID: userID, Email: "jane@example.com", Password: []byte{}}, nil).
+		Times(3)
+
+	service := Service{DBAPI: mock}
+	erase := func(authTime time.Time) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/me", strings.NewReader(`{}`))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		ctx.Set(roles.UserKey, userID)
+		if !authTime.IsZero() {
+			ctx.Set(roles.AuthTimeKey, authTime)
+		}
+		return service.EraseMe(ctx)
+	}
+
+	// when/then tokens without or with an old authentication time erase nothing
+	assert.True(errors.Is(erase(time.Time{}), ErrReauthenticationRequired))
+	assert.True(errors.Is(erase(time.Now().Add(-time.Hour)), ErrReauthenticationRequired))
+
+	// when/then a recent login confirms the erasure
+	mock.EXPECT().
+		EraseUser(gomock.Any(), gomock.Eq(userID)).
+		Return(int64(1), nil)
+	assert.CmpNoError(erase(time.Now().Add(-time.Minute)))
+}
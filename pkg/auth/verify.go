@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/mail"
+)
+
+// VerifyBody describes the verification body with the token received by mail
+type VerifyBody struct {
+	Token string `json:"token" form:"token"`
+}
+
+// Verify activates the user's account and confirms the user's email.
+func (s *Service) Verify(ctx *gin.Context) (userID string, err error) {
+	var body VerifyBody
+	err = ctx.ShouldBind(&body)
+	if err != nil || len(body.Token) == 0 {
+		err = errors.WithStack(ErrMissingVerificationToken)
+		return
+	}
+
+	user, err := s.DBAPI.VerifyEmail(ctx, tokens.Digest(body.Token))
+	if err != nil {
+		return
+	}
+	return user.ID, nil
+}
+
+// ResendVerificationBody describes the user to resend the verification mail to
+type ResendVerificationBody struct {
+	Email string `json:"email"`
+}
+
+// ResendVerification sends a new verification mail, invalidating previously sent ones.
+func (s *Service) ResendVerification(ctx *gin.Context) (err error) {
+	var body ResendVerificationBody
+	err = ctx.ShouldBind(&body)
+	if err != nil {
+		return
+	}
+
+	user, err := s.DBAPI.FindUserByEmail(ctx, body.Email)
+	if err != nil {
+		return
+	}
+	if user.ActivatedAt.Valid {
+		return errors.WithStack(ErrUserAlreadyActivated)
+	}
+
+	tx, err := s.DBAPI.BeginTx(ctx)
+	if err != nil {
+		return
+	}
+	token, err := s.createVerification(ctx, tx, user.ID, user.Email)
+	if err != nil {
+		s.DBAPI.Rollback(tx)
+		return
+	}
+	err = s.DBAPI.Commit(tx)
+	if err != nil {
+		return
+	}
+
+	return s.sendVerification(ctx, user.Email, token)
+}
+
+// createVerification creates a single-use verification token for the email of a user.
+func (s *Service) createVerification(ctx context.Context, tx *sql.Tx, userID, email string) (token string, err error) {
+	token, digest, err := tokens.GenerateOpaqueToken()
+	if err != nil {
+		return
+	}
+	err = s.DBAPI.CreateVerification(ctx, tx, userID, email, digest, time.Now().Add(s.VerificationExpiry))
+	return
+}
+
+func (s *Service) sendVerification(ctx context.Context, email, token string) error {
+	link := s.PublicURL + "/verify?token=" + url.QueryEscape(token)
+	return s.Mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body:    fmt.Sprintf("Please confirm your email address by opening the following link within %s:\n\n%s\n", s.VerificationExpiry, link),
+	})
+}
+
+var (
+	ErrMissingVerificationToken = errors.New("missing verification token")
+	ErrVerificationInvalid      = errors.New("verification token invalid or expired")
+	ErrUserNotActivated         = errors.New("user has not verified the email yet")
+	ErrUserAlreadyActivated     = errors.New("user is already activated")
+)
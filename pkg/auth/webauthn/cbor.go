@@ -0,0 +1,129 @@
+package webauthn
+
+import (
+	"encoding/binary"
+
+	"github.com/friendsofgo/errors"
+)
+
+// decodeCBOR decodes the first CBOR data item of b and returns the remaining bytes, see RFC 8949.
+// Only the subset used by WebAuthn is supported: integers, byte and text strings, arrays, maps and simple values.
+// Integers decode to int64, maps to map[interface{}]interface{}.
+func decodeCBOR(b []byte) (v interface{}, rest []byte, err error) {
+	return decodeItem(b, 0)
+}
+
+// maxDepth limits nesting to protect against malicious input.
+const maxDepth = 16
+
+func decodeItem(b []byte, depth int) (v interface{}, rest []byte, err error) {
+	if depth > maxDepth {
+		return nil, nil, errors.WithStack(ErrInvalidCBOR)
+	}
+	if len(b) == 0 {
+		return nil, nil, errors.WithStack(ErrInvalidCBOR)
+	}
+	major := b[0] >> 5
+	info := b[0] & 0x1f
+	b = b[1:]
+
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, b, nil
+		case 21:
+			return true, b, nil
+		case 22, 23:
+			return nil, b, nil
+		default:
+			return nil, nil, errors.Wrapf(ErrInvalidCBOR, "unsupported simple value %d", info)
+		}
+	}
+
+	n, b, err := decodeArgument(info, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0:
+		if n > 1<<63-1 {
+			return nil, nil, errors.WithStack(ErrInvalidCBOR)
+		}
+		return int64(n), b, nil
+	case 1:
+		if n > 1<<63-1 {
+			return nil, nil, errors.WithStack(ErrInvalidCBOR)
+		}
+		return -1 - int64(n), b, nil
+	case 2, 3:
+		if uint64(len(b)) < n {
+			return nil, nil, errors.WithStack(ErrInvalidCBOR)
+		}
+		if major == 2 {
+			return b[:n], b[n:], nil
+		}
+		return string(b[:n]), b[n:], nil
+	case 4:
+		if uint64(len(b)) < n {
+			return nil, nil, errors.WithStack(ErrInvalidCBOR)
+		}
+		arr := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			var item interface{}
+			item, b, err = decodeItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			arr = append(arr, item)
+		}
+		return arr, b, nil
+	case 5:
+		if uint64(len(b)) < n {
+			return nil, nil, errors.WithStack(ErrInvalidCBOR)
+		}
+		m := make(map[interface{}]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			var key, value interface{}
+			key, b, err = decodeItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, errors.Wrap(ErrInvalidCBOR, "unsupported map key")
+			}
+			value, b, err = decodeItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[key] = value
+		}
+		return m, b, nil
+	default:
+		return nil, nil, errors.Wrapf(ErrInvalidCBOR, "unsupported major type %d", major)
+	}
+}
+
+func decodeArgument(info byte, b []byte) (n uint64, rest []byte, err error) {
+	switch {
+	case info < 24:
+		return uint64(info), b, nil
+	case info == 24 && len(b) >= 1:
+		return uint64(b[0]), b[1:], nil
+	case info == 25 && len(b) >= 2:
+		return uint64(binary.BigEndian.Uint16(b)), b[2:], nil
+	case info == 26 && len(b) >= 4:
+		return uint64(binary.BigEndian.Uint32(b)), b[4:], nil
+	case info == 27 && len(b) >= 8:
+		return binary.BigEndian.Uint64(b), b[8:], nil
+	default:
+		// indefinite lengths are not used by authenticators
+		return 0, nil, errors.WithStack(ErrInvalidCBOR)
+	}
+}
+
+var (
+	ErrInvalidCBOR = errors.New("invalid or unsupported CBOR")
+)
@@ -0,0 +1,106 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/friendsofgo/errors"
+)
+
+// COSE algorithm identifiers, see https://www.iana.org/assignments/cose/cose.xhtml#algorithms
+const (
+	AlgES256 = -7
+	AlgRS256 = -257
+)
+
+// COSE key parameters
+const (
+	coseKty   = 1
+	coseAlg   = 3
+	coseCrv   = -1
+	coseX     = -2
+	coseY     = -3
+	coseRSAN  = -1
+	coseRSAE  = -2
+	ktyEC2    = 2
+	ktyRSA    = 3
+	crvP256   = 1
+	maxRSALen = 4096 / 8
+)
+
+// publicKey is a credential public key decoded from its COSE representation.
+type publicKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+// parsePublicKey decodes a COSE key as stored with a credential, see RFC 8152.
+func parsePublicKey(cose []byte) (k publicKey, err error) {
+	v, _, err := decodeCBOR(cose)
+	if err != nil {
+		return
+	}
+	params, ok := v.(map[interface{}]interface{})
+	if !ok {
+		err = errors.WithStack(ErrUnsupportedKey)
+		return
+	}
+	kty, _ := params[int64(coseKty)].(int64)
+	k.alg, _ = params[int64(coseAlg)].(int64)
+
+	switch {
+	case kty == ktyEC2 && k.alg == AlgES256:
+		crv, _ := params[int64(coseCrv)].(int64)
+		x, okX := params[int64(coseX)].([]byte)
+		y, okY := params[int64(coseY)].([]byte)
+		if crv != crvP256 || !okX || !okY || len(x) != 32 || len(y) != 32 {
+			err = errors.WithStack(ErrUnsupportedKey)
+			return
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			err = errors.WithStack(ErrUnsupportedKey)
+			return
+		}
+		k.key = pub
+	case kty == ktyRSA && k.alg == AlgRS256:
+		n, okN := params[int64(coseRSAN)].([]byte)
+		e, okE := params[int64(coseRSAE)].([]byte)
+		if !okN || !okE || len(n) > maxRSALen || len(e) > 4 {
+			err = errors.WithStack(ErrUnsupportedKey)
+			return
+		}
+		k.key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	default:
+		err = errors.Wrapf(ErrUnsupportedKey, "key type %d with algorithm %d", kty, k.alg)
+	}
+	return
+}
+
+// verify checks a signature over data.
+func (k publicKey) verify(data, sig []byte) error {
+	digest := sha256.Sum256(data)
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], sig) {
+			return errors.WithStack(ErrInvalidSignature)
+		}
+	case *rsa.PublicKey:
+		err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig)
+		if err != nil {
+			return errors.WithStack(ErrInvalidSignature)
+		}
+	default:
+		return errors.WithStack(ErrUnsupportedKey)
+	}
+	return nil
+}
+
+var (
+	ErrUnsupportedKey   = errors.New("unsupported credential public key")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
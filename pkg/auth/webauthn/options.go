@@ -0,0 +1,86 @@
+package webauthn
+
+import "time"
+
+// Timeout is the time a user has to complete a ceremony.
+const Timeout = 5 * time.Minute
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          Base64URL `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type string    `json:"type"`
+	ID   Base64URL `json:"id"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are passed to navigator.credentials.create() to register a passkey.
+type CreationOptions struct {
+	Challenge              Base64URL              `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	Attestation            string                 `json:"attestation"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+}
+
+// RequestOptions are passed to navigator.credentials.get() to log in with a passkey.
+type RequestOptions struct {
+	Challenge        Base64URL `json:"challenge"`
+	RPID             string    `json:"rpId"`
+	Timeout          int64     `json:"timeout"`
+	UserVerification string    `json:"userVerification"`
+}
+
+// CreationOptions builds the options to register a discoverable credential for a user, excluding already registered credentials.
+func (rp RelyingParty) CreationOptions(challenge []byte, userID, name, displayName string, exclude [][]byte) CreationOptions {
+	excludeCredentials := make([]CredentialDescriptor, 0, len(exclude))
+	for _, id := range exclude {
+		excludeCredentials = append(excludeCredentials, CredentialDescriptor{Type: "public-key", ID: id})
+	}
+	return CreationOptions{
+		Challenge: challenge,
+		RP:        RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:      UserEntity{ID: Base64URL(userID), Name: name, DisplayName: displayName},
+		PubKeyCredParams: []CredentialParameter{
+			{Type: "public-key", Alg: AlgES256},
+			{Type: "public-key", Alg: AlgRS256},
+		},
+		Timeout:            Timeout.Milliseconds(),
+		Attestation:        "none",
+		ExcludeCredentials: excludeCredentials,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "required",
+			UserVerification: "required",
+		},
+	}
+}
+
+// RequestOptions builds the options to log in with any discoverable credential of the relying party.
+func (rp RelyingParty) RequestOptions(challenge []byte) RequestOptions {
+	return RequestOptions{
+		Challenge:        challenge,
+		RPID:             rp.ID,
+		Timeout:          Timeout.Milliseconds(),
+		UserVerification: "required",
+	}
+}
@@ -0,0 +1,252 @@
+// Package webauthn verifies the registration and assertion ceremonies of passkeys, see https://www.w3.org/TR/webauthn-2/.
+// Attestation is not requested and therefore not verified, credentials are trusted on first use by an authenticated user.
+// Supported credential algorithms are ES256 and RS256.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+)
+
+const (
+	CreateType = "webauthn.create"
+	GetType    = "webauthn.get"
+)
+
+// authenticator data flags
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+// Base64URL are bytes encoded as unpadded base64url in JSON, as used by the WebAuthn JSON serialization.
+type Base64URL []byte
+
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	*b, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	return err
+}
+
+// RelyingParty identifies the service credentials are scoped to.
+type RelyingParty struct {
+	// ID is the effective domain credentials are bound to
+	ID string
+	// Name is shown by authenticators
+	Name string
+	// Origin is the expected origin of the web app running the ceremonies
+	Origin string
+}
+
+// GenerateChallenge creates a random challenge for a ceremony.
+func GenerateChallenge() ([]byte, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating challenge failed")
+	}
+	return b, nil
+}
+
+// CollectedClientData is the client data signed by the authenticator.
+type CollectedClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// ClientChallenge extracts the challenge from client data to look up the pending ceremony.
+func ClientChallenge(clientDataJSON []byte) ([]byte, error) {
+	var clientData CollectedClientData
+	err := json.Unmarshal(clientDataJSON, &clientData)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidClientData, err.Error())
+	}
+	challenge, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(clientData.Challenge, "="))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidClientData, err.Error())
+	}
+	return challenge, nil
+}
+
+// AttestationResponse is the response of an authenticator to a registration.
+type AttestationResponse struct {
+	ClientDataJSON    Base64URL `json:"clientDataJSON"`
+	AttestationObject Base64URL `json:"attestationObject"`
+}
+
+// AssertionResponse is the response of an authenticator to a login.
+type AssertionResponse struct {
+	ClientDataJSON    Base64URL `json:"clientDataJSON"`
+	AuthenticatorData Base64URL `json:"authenticatorData"`
+	Signature         Base64URL `json:"signature"`
+	UserHandle        Base64URL `json:"userHandle"`
+}
+
+// Credential is a registered public key credential.
+type Credential struct {
+	ID []byte
+	// PublicKey is the COSE encoded public key
+	PublicKey []byte
+	SignCount uint32
+}
+
+// VerifyRegistration verifies the response to a registration with the given challenge and returns the new credential.
+func (rp RelyingParty) VerifyRegistration(res AttestationResponse, challenge []byte) (cred Credential, err error) {
+	err = rp.verifyClientData(res.ClientDataJSON, CreateType, challenge)
+	if err != nil {
+		return
+	}
+
+	v, _, err := decodeCBOR(res.AttestationObject)
+	if err != nil {
+		return
+	}
+	attestation, ok := v.(map[interface{}]interface{})
+	if !ok {
+		err = errors.Wrap(ErrInvalidAuthenticatorData, "invalid attestation object")
+		return
+	}
+	authData, ok := attestation["authData"].([]byte)
+	if !ok {
+		err = errors.Wrap(ErrInvalidAuthenticatorData, "missing authenticator data")
+		return
+	}
+
+	flags, signCount, rest, err := rp.parseAuthenticatorData(authData)
+	if err != nil {
+		return
+	}
+	if flags&flagAttested == 0 {
+		err = errors.Wrap(ErrInvalidAuthenticatorData, "missing attested credential data")
+		return
+	}
+
+	// attested credential data: aaguid (16), credential ID length (2), credential ID, COSE public key
+	if len(rest) < 18 {
+		err = errors.WithStack(ErrInvalidAuthenticatorData)
+		return
+	}
+	idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < idLen || idLen == 0 || idLen > 1023 {
+		err = errors.WithStack(ErrInvalidAuthenticatorData)
+		return
+	}
+	cred.ID = rest[:idLen]
+	rest = rest[idLen:]
+
+	_, extensions, err := decodeCBOR(rest)
+	if err != nil {
+		return
+	}
+	cred.PublicKey = rest[:len(rest)-len(extensions)]
+	_, err = parsePublicKey(cred.PublicKey)
+	if err != nil {
+		return
+	}
+	cred.SignCount = signCount
+	return
+}
+
+// VerifyAssertion verifies the response to a login with the given challenge against a registered credential
+// and returns the new signature counter to store.
+// Logins require user verification, as the passkey replaces the password.
+func (rp RelyingParty) VerifyAssertion(res AssertionResponse, challenge []byte, cred Credential) (signCount uint32, err error) {
+	err = rp.verifyClientData(res.ClientDataJSON, GetType, challenge)
+	if err != nil {
+		return
+	}
+
+	flags, signCount, _, err := rp.parseAuthenticatorData(res.AuthenticatorData)
+	if err != nil {
+		return
+	}
+	if flags&flagUserVerified == 0 {
+		err = errors.WithStack(ErrUserNotVerified)
+		return
+	}
+
+	key, err := parsePublicKey(cred.PublicKey)
+	if err != nil {
+		return
+	}
+	clientDataHash := sha256.Sum256(res.ClientDataJSON)
+	signed := append(append([]byte{}, res.AuthenticatorData...), clientDataHash[:]...)
+	err = key.verify(signed, res.Signature)
+	if err != nil {
+		return
+	}
+
+	// authenticators without counter always report 0, otherwise a counter not increasing indicates a cloned authenticator
+	if (signCount != 0 || cred.SignCount != 0) && signCount <= cred.SignCount {
+		err = errors.WithStack(ErrSignCountInvalid)
+		return
+	}
+	return
+}
+
+func (rp RelyingParty) verifyClientData(clientDataJSON []byte, ceremonyType string, challenge []byte) error {
+	var clientData CollectedClientData
+	err := json.Unmarshal(clientDataJSON, &clientData)
+	if err != nil {
+		return errors.Wrap(ErrInvalidClientData, err.Error())
+	}
+	if clientData.Type != ceremonyType {
+		return errors.Wrapf(ErrInvalidClientData, "type %s", clientData.Type)
+	}
+	clientChallenge, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(clientData.Challenge, "="))
+	if err != nil || subtle.ConstantTimeCompare(clientChallenge, challenge) != 1 {
+		return errors.Wrap(ErrInvalidClientData, "challenge mismatch")
+	}
+	if clientData.Origin != rp.Origin {
+		return errors.Wrapf(ErrInvalidClientData, "origin %s", clientData.Origin)
+	}
+	return nil
+}
+
+// parseAuthenticatorData checks the fixed part of authenticator data and returns the variable part.
+func (rp RelyingParty) parseAuthenticatorData(authData []byte) (flags byte, signCount uint32, rest []byte, err error) {
+	// rpIdHash (32), flags (1), signCount (4)
+	if len(authData) < 37 {
+		err = errors.WithStack(ErrInvalidAuthenticatorData)
+		return
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(authData[:32], rpIDHash[:]) {
+		err = errors.Wrap(ErrInvalidAuthenticatorData, "relying party mismatch")
+		return
+	}
+	flags = authData[32]
+	if flags&flagUserPresent == 0 {
+		err = errors.Wrap(ErrInvalidAuthenticatorData, "user not present")
+		return
+	}
+	signCount = binary.BigEndian.Uint32(authData[33:37])
+	rest = authData[37:]
+	return
+}
+
+var (
+	ErrInvalidClientData        = errors.New("invalid client data")
+	ErrInvalidAuthenticatorData = errors.New("invalid authenticator data")
+	ErrUserNotVerified          = errors.New("user not verified by authenticator")
+	ErrSignCountInvalid         = errors.New("signature counter did not increase, authenticator might be cloned")
+)
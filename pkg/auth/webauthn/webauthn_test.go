@@ -0,0 +1,95 @@
+package webauthn_test
+
+import (
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/smartnuance/saas-kit/pkg/auth/webauthn"
+	"github.com/smartnuance/saas-kit/pkg/auth/webauthn/webauthntest"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+var rp = webauthn.RelyingParty{ID: "localhost", Name: "saas-kit", Origin: "http://localhost:8801"}
+
+func (s *MySuite) Test_ceremonies(assert, require *td.T) {
+	// given
+	authenticator, err := webauthntest.New(rp.ID, rp.Origin)
+	require.CmpNoError(err)
+
+	// when registering
+	challenge, err := webauthn.GenerateChallenge()
+	require.CmpNoError(err)
+	res := authenticator.Register(rp.CreationOptions(challenge, "user", "simon@smartnuance.com", "Simon", nil))
+
+	clientChallenge, err := webauthn.ClientChallenge(res.ClientDataJSON)
+	require.CmpNoError(err)
+	assert.Cmp(clientChallenge, challenge)
+	cred, err := rp.VerifyRegistration(res, challenge)
+
+	// then
+	require.CmpNoError(err)
+	assert.Cmp(cred.ID, authenticator.CredentialID)
+	assert.Cmp(cred.PublicKey, authenticator.PublicKey())
+
+	// when logging in
+	challenge, err = webauthn.GenerateChallenge()
+	require.CmpNoError(err)
+	assertion, err := authenticator.Assert(rp.RequestOptions(challenge))
+	require.CmpNoError(err)
+	signCount, err := rp.VerifyAssertion(assertion, challenge, cred)
+
+	// then
+	require.CmpNoError(err)
+	assert.Cmp(signCount, uint32(1))
+	assert.Cmp([]byte(assertion.UserHandle), []byte("user"))
+
+	// when replaying the assertion with a stale counter
+	cred.SignCount = signCount
+	_, err = rp.VerifyAssertion(assertion, challenge, cred)
+
+	// then
+	assert.True(errors.Is(err, webauthn.ErrSignCountInvalid))
+}
+
+func (s *MySuite) Test_rejectForeignCeremonies(assert, require *td.T) {
+	authenticator, err := webauthntest.New(rp.ID, rp.Origin)
+	require.CmpNoError(err)
+	challenge, err := webauthn.GenerateChallenge()
+	require.CmpNoError(err)
+	res := authenticator.Register(rp.CreationOptions(challenge, "user", "simon@smartnuance.com", "Simon", nil))
+	cred, err := rp.VerifyRegistration(res, challenge)
+	require.CmpNoError(err)
+
+	// other challenge
+	other, err := webauthn.GenerateChallenge()
+	require.CmpNoError(err)
+	_, err = rp.VerifyRegistration(res, other)
+	assert.True(errors.Is(err, webauthn.ErrInvalidClientData))
+
+	// phishing origin
+	phished, err := webauthntest.New(rp.ID, "https://evil.example.com")
+	require.CmpNoError(err)
+	_, err = rp.VerifyRegistration(phished.Register(rp.CreationOptions(challenge, "user", "", "", nil)), challenge)
+	assert.True(errors.Is(err, webauthn.ErrInvalidClientData))
+
+	// other relying party
+	foreign, err := webauthntest.New("example.com", rp.Origin)
+	require.CmpNoError(err)
+	_, err = rp.VerifyRegistration(foreign.Register(rp.CreationOptions(challenge, "user", "", "", nil)), challenge)
+	assert.True(errors.Is(err, webauthn.ErrInvalidAuthenticatorData))
+
+	// assertion signed by another key
+	impostor, err := webauthntest.New(rp.ID, rp.Origin)
+	require.CmpNoError(err)
+	assertion, err := impostor.Assert(rp.RequestOptions(challenge))
+	require.CmpNoError(err)
+	_, err = rp.VerifyAssertion(assertion, challenge, cred)
+	assert.True(errors.Is(err, webauthn.ErrInvalidSignature))
+}
@@ -0,0 +1,163 @@
+// Package webauthntest provides a software authenticator to create attestation and assertion fixtures offline.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/smartnuance/saas-kit/pkg/auth/webauthn"
+)
+
+// Authenticator is a software passkey with an ES256 key, behaving like a platform authenticator with user verification.
+type Authenticator struct {
+	RPID   string
+	Origin string
+	// CredentialID identifies the passkey
+	CredentialID []byte
+	// UserHandle is the user ID the passkey was registered for
+	UserHandle []byte
+	// SignCount is incremented on each assertion; authenticators without counter keep it 0
+	SignCount uint32
+	// NoCounter disables the signature counter
+	NoCounter bool
+
+	key *ecdsa.PrivateKey
+}
+
+func New(rpID, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	_, err = rand.Read(id)
+	if err != nil {
+		return nil, err
+	}
+	return &Authenticator{RPID: rpID, Origin: origin, CredentialID: id, key: key}, nil
+}
+
+// Register answers a registration ceremony with "none" attestation.
+func (a *Authenticator) Register(options webauthn.CreationOptions) webauthn.AttestationResponse {
+	a.UserHandle = options.User.ID
+
+	var credentialData []byte
+	credentialData = append(credentialData, make([]byte, 16)...) // zero aaguid
+	credentialData = append(credentialData, byte(len(a.CredentialID)>>8), byte(len(a.CredentialID)))
+	credentialData = append(credentialData, a.CredentialID...)
+	credentialData = append(credentialData, a.PublicKey()...)
+
+	authData := a.authenticatorData(0x01|0x04|0x40, credentialData)
+	attestationObject := encodeMap(
+		pair{encodeText("fmt"), encodeText("none")},
+		pair{encodeText("attStmt"), encodeMap()},
+		pair{encodeText("authData"), encodeBytes(authData)},
+	)
+	return webauthn.AttestationResponse{
+		ClientDataJSON:    a.clientData(webauthn.CreateType, options.Challenge),
+		AttestationObject: attestationObject,
+	}
+}
+
+// Assert answers a login ceremony.
+func (a *Authenticator) Assert(options webauthn.RequestOptions) (webauthn.AssertionResponse, error) {
+	if !a.NoCounter {
+		a.SignCount++
+	}
+	authData := a.authenticatorData(0x01|0x04, nil)
+	clientDataJSON := a.clientData(webauthn.GetType, options.Challenge)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return webauthn.AssertionResponse{}, err
+	}
+	return webauthn.AssertionResponse{
+		ClientDataJSON:    clientDataJSON,
+		AuthenticatorData: authData,
+		Signature:         sig,
+		UserHandle:        a.UserHandle,
+	}, nil
+}
+
+// PublicKey returns the COSE encoded public key.
+func (a *Authenticator) PublicKey() []byte {
+	x := a.key.PublicKey.X.FillBytes(make([]byte, 32))
+	y := a.key.PublicKey.Y.FillBytes(make([]byte, 32))
+	return encodeMap(
+		pair{encodeInt(1), encodeInt(2)},    // kty: EC2
+		pair{encodeInt(3), encodeInt(-7)},   // alg: ES256
+		pair{encodeInt(-1), encodeInt(1)},   // crv: P-256
+		pair{encodeInt(-2), encodeBytes(x)}, // x
+		pair{encodeInt(-3), encodeBytes(y)}, // y
+	)
+}
+
+func (a *Authenticator) authenticatorData(flags byte, credentialData []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	var signCount [4]byte
+	binary.BigEndian.PutUint32(signCount[:], a.SignCount)
+	data = append(data, signCount[:]...)
+	return append(data, credentialData...)
+}
+
+func (a *Authenticator) clientData(ceremonyType string, challenge []byte) []byte {
+	b, _ := json.Marshal(webauthn.CollectedClientData{
+		Type:      ceremonyType,
+		Challenge: base64.RawURLEncoding.EncodeToString(challenge),
+		Origin:    a.Origin,
+	})
+	return b
+}
+
+// minimal CBOR encoding of the items used by authenticators
+
+type pair struct{ key, value []byte }
+
+func encodeHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		b := []byte{major<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	default:
+		b := []byte{major<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b
+	}
+}
+
+func encodeInt(i int64) []byte {
+	if i < 0 {
+		return encodeHead(1, uint64(-1-i))
+	}
+	return encodeHead(0, uint64(i))
+}
+
+func encodeBytes(b []byte) []byte {
+	return append(encodeHead(2, uint64(len(b))), b...)
+}
+
+func encodeText(s string) []byte {
+	return append(encodeHead(3, uint64(len(s))), s...)
+}
+
+func encodeMap(pairs ...pair) []byte {
+	b := encodeHead(5, uint64(len(pairs)))
+	for _, p := range pairs {
+		b = append(b, p.key...)
+		b = append(b, p.value...)
+	}
+	return b
+}
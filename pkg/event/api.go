@@ -8,6 +8,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/smartnuance/saas-kit/pkg/lib/roles"
 	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+	"github.com/smartnuance/saas-kit/pkg/lib/userdata"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -19,6 +20,7 @@ func router(s *Service) *gin.Engine {
 	config.AddAllowMethods("PUT", "PATCH", "GET", "POST", "DELETE", "OPTIONS")
 	config.AddAllowHeaders("Authorization")
 	config.AddAllowHeaders(roles.RoleHeader)
+	config.AddAllowHeaders(tokens.APIKeyHeader)
 	if s.release {
 		config.AllowOriginFunc = func(origin string) bool {
 			_, ok := s.AllowOrigins[origin]
@@ -29,11 +31,13 @@ func router(s *Service) *gin.Engine {
 	}
 	router.Use(cors.New(config))
 
-	// with authorization middleware
-	api := router.Group("/", tokens.AuthorizeJWT(s.TokenAPI.ValidationKey, s.Issuer, s.Audience))
+	// with authorization middleware, rejecting access tokens revoked at the auth service once the denylist is synced
+	api := router.Group("/", tokens.AuthorizeJWTWithDenylist(s.Keys, s.Issuer, s.Audience, s.Denylist, s.APIKeys))
 	api.PUT("/workshop", s.CreateWorkshopHandler())
 	api.GET("/workshop/list", s.ListWorkshopHandler())
 	api.DELETE("/workshop/:id", s.DeleteWorkshopHandler())
+	api.GET(userdata.Path+"/:id", s.ExportUserHandler())
+	api.DELETE(userdata.Path+"/:id", s.EraseUserHandler())
 
 	// without authorization middleware
 	s.AddInfoHandlers(api.Group("/info"))
@@ -91,6 +95,32 @@ func (s *Service) DeleteWorkshopHandler() gin.HandlerFunc {
 	}
 }
 
+// ExportUserHandler exports the data held about a user to the auth service.
+func (s *Service) ExportUserHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		data, err := s.ExportUser(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("")
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+		} else {
+			ctx.JSON(http.StatusOK, data)
+		}
+	}
+}
+
+// EraseUserHandler erases a user on behalf of the auth service.
+func (s *Service) EraseUserHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := s.EraseUser(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("")
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+		} else {
+			ctx.Status(http.StatusOK)
+		}
+	}
+}
+
 func respondProto(ctx *gin.Context, m proto.Message) {
 	jsonData, err := protojson.Marshal(m)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/friendsofgo/errors"
 	"github.com/rs/xid"
@@ -16,6 +17,7 @@ import (
 	"github.com/smartnuance/saas-kit/pkg/lib/paging"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/types"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -32,6 +34,9 @@ type DBAPI interface {
 	DeleteWorkshop(ctx context.Context, workshopID string) (err error)
 	CreateEvent(ctx context.Context, data *Event) (event *m.Event, err error)
 	GetEvent(ctx context.Context, eventID string) (event *m.Event, err error)
+	PurgeDeleted(ctx context.Context, before time.Time) (purged int64, err error)
+	UserData(ctx context.Context, userID string) (events m.EventSlice, workshops m.WorkshopSlice, err error)
+	EraseUser(ctx context.Context, userID string) (int64, error)
 }
 
 type dbAPI struct {
@@ -87,6 +92,8 @@ func (db *dbAPI) ListWorkshops(ctx context.Context, instanceID string, page pagi
 		qm.InnerJoin(fmt.Sprintf("%s on %s = %s", m.TableNames.Events, m.EventTableColumns.ID, m.WorkshopColumns.EventID)),
 		qm.Load(m.WorkshopRels.Event),
 		m.EventWhere.InstanceID.EQ(instanceID),
+		// workshops of deleted events are gone as well
+		m.EventWhere.DeletedAt.IsNull(),
 		m.EventWhere.ID.Page(page),
 	).All(ctx, db.DB)
 	if err == sql.ErrNoRows {
@@ -111,27 +118,11 @@ func (db *dbAPI) ListWorkshops(ctx context.Context, instanceID string, page pagi
 		list.Items = append(list.Items, &workshop)
 	}
 
-	list.Paging = &paging.Paging{Cur: &paging.Paging_Current{PageSize: int32(len(list.Items))}}
-	if len(list.Items) > 0 {
-		list.Paging.Cur.Start = list.Items[0].Id
-		list.Paging.Cur.End = list.Items[len(list.Items)-1].Id
-
-		_, isFirst := page.(*paging.Paging_First)
-		if !isFirst {
-			list.Paging.Prev = &paging.Paging_Previous{
-				End:      list.Items[0].Id,
-				PageSize: int32(page.Size()),
-			}
-		}
-		isLast := len(list.Items) < page.Size()
-		if !isLast {
-			list.Paging.Next = &paging.Paging_Next{
-				Start:    list.Items[len(list.Items)-1].Id,
-				PageSize: int32(page.Size()),
-			}
-		}
+	var first, last string
+	if n := len(list.Items); n > 0 {
+		first, last = list.Items[0].Id, list.Items[n-1].Id
 	}
-
+	list.Paging = paging.Loaded(page, first, last, len(list.Items))
 	return
 }
 
@@ -236,6 +227,60 @@ func loadWorkshop(row m.Workshop) (workshop Workshop, err error) {
 	return
 }
 
+// PurgeDeleted hard-deletes workshops and events soft-deleted before the given time and returns their number.
+// Workshops of purged events are deleted by cascade.
+func (db *dbAPI) PurgeDeleted(ctx context.Context, before time.Time) (purged int64, err error) {
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	n, err := m.Workshops(qm.WithDeleted(), m.WorkshopWhere.DeletedAt.LT(null.TimeFrom(before))).DeleteAll(ctx, tx, true)
+	purged += n
+	if err != nil {
+		return
+	}
+	n, err = m.Events(qm.WithDeleted(), m.EventWhere.DeletedAt.LT(null.TimeFrom(before))).DeleteAll(ctx, tx, true)
+	purged += n
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// UserData loads the events owned by a user and the workshops the user participates in, soft-deleted ones included.
+// Participants of a workshop are keyed by user ID.
+func (db *dbAPI) UserData(ctx context.Context, userID string) (events m.EventSlice, workshops m.WorkshopSlice, err error) {
+	events, err = m.Events(qm.WithDeleted(), m.EventWhere.OwnerID.EQ(null.StringFrom(userID))).All(ctx, db.DB)
+	if err != nil {
+		return
+	}
+	workshops, err = m.Workshops(
+		qm.WithDeleted(),
+		qm.Where(fmt.Sprintf("%s -> ? IS NOT NULL", m.WorkshopColumns.Participants), userID),
+	).All(ctx, db.DB)
+	return
+}
+
+// EraseUser removes a user from the participants of all workshops and returns the number of changed workshops.
+// Events owned by the user are kept, they refer to the anonymized user kept by the auth service.
+func (db *dbAPI) EraseUser(ctx context.Context, userID string) (int64, error) {
+	res, err := queries.Raw(fmt.Sprintf("UPDATE %s SET %s = %s - $1, %s = NOW() WHERE %s -> $1 IS NOT NULL",
+		m.TableNames.Workshops, m.WorkshopColumns.Participants, m.WorkshopColumns.Participants,
+		m.WorkshopColumns.UpdatedAt, m.WorkshopColumns.Participants), userID).ExecContext(ctx, db.DB)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
 var (
 	ErrEventDoesNotExist    = errors.New("event does not exist")
 	ErrWorkshopDoesNotExist = errors.New("workshop does not exist")
@@ -8,6 +8,7 @@ import (
 	context "context"
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	dbmodels "github.com/smartnuance/saas-kit/pkg/event/dbmodels"
@@ -110,6 +111,21 @@ func (mr *MockDBAPIMockRecorder) DeleteWorkshop(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkshop", reflect.TypeOf((*MockDBAPI)(nil).DeleteWorkshop), arg0, arg1)
 }
 
+// EraseUser mocks base method.
+func (m *MockDBAPI) EraseUser(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockDBAPIMockRecorder) EraseUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockDBAPI)(nil).EraseUser), arg0, arg1)
+}
+
 // GetEvent mocks base method.
 func (m *MockDBAPI) GetEvent(arg0 context.Context, arg1 string) (*dbmodels.Event, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +171,21 @@ func (mr *MockDBAPIMockRecorder) ListWorkshops(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkshops", reflect.TypeOf((*MockDBAPI)(nil).ListWorkshops), arg0, arg1, arg2)
 }
 
+// PurgeDeleted mocks base method.
+func (m *MockDBAPI) PurgeDeleted(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockDBAPIMockRecorder) PurgeDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockDBAPI)(nil).PurgeDeleted), arg0, arg1)
+}
+
 // Rollback mocks base method.
 func (m *MockDBAPI) Rollback(arg0 *sql.Tx) error {
 	m.ctrl.T.Helper()
@@ -168,3 +199,19 @@ func (mr *MockDBAPIMockRecorder) Rollback(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockDBAPI)(nil).Rollback), arg0)
 }
+
+// UserData mocks base method.
+func (m *MockDBAPI) UserData(arg0 context.Context, arg1 string) (dbmodels.EventSlice, dbmodels.WorkshopSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserData", arg0, arg1)
+	ret0, _ := ret[0].(dbmodels.EventSlice)
+	ret1, _ := ret[1].(dbmodels.WorkshopSlice)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UserData indicates an expected call of UserData.
+func (mr *MockDBAPIMockRecorder) UserData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserData", reflect.TypeOf((*MockDBAPI)(nil).UserData), arg0, arg1)
+}
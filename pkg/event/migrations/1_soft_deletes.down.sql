@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS workshop_deleted_idx;
+DROP INDEX IF EXISTS event_deleted_idx;
+ALTER TABLE workshops DROP CONSTRAINT fk_event, ADD CONSTRAINT fk_event FOREIGN KEY(event_id) REFERENCES events(id);
@@ -0,0 +1,4 @@
+--Soft-deleted events and workshops are purged after a retention window, an event taking its workshops with it.
+ALTER TABLE workshops DROP CONSTRAINT fk_event, ADD CONSTRAINT fk_event FOREIGN KEY(event_id) REFERENCES events(id) ON DELETE CASCADE;
+CREATE INDEX event_deleted_idx ON events(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX workshop_deleted_idx ON workshops(deleted_at) WHERE deleted_at IS NOT NULL;
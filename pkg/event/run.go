@@ -7,14 +7,15 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/RichardKnop/go-fixtures"
 	"github.com/friendsofgo/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
-	"github.com/smartnuance/saas-kit/pkg/auth/tokens"
 	"github.com/smartnuance/saas-kit/pkg/lib"
 	"github.com/smartnuance/saas-kit/pkg/lib/service"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
 )
 
 //go:embed migrations/*
@@ -32,10 +33,12 @@ var (
 // Env is a hierarchical environment configuration for the authentication service and it's API handlers.
 type Env struct {
 	service.DBEnv
-	tokens.TokenEnv
+	tokens.ValidationEnv
 	service.HTTPEnv
 	AllowOrigins []string
-	release      bool
+	// DeletedRetention is the duration soft-deleted events and workshops are kept before they are purged
+	DeletedRetention time.Duration
+	release          bool
 
 	modelInfoPath string
 }
@@ -47,7 +50,9 @@ type Service struct {
 	service.DBConn
 	DBAPI DBAPI
 	service.HTTPServer
-	TokenAPI     *tokens.TokenController
+	Keys         *tokens.RemoteKeySet
+	APIKeys      *tokens.RemoteAPIKeyVerifier
+	Denylist     *tokens.CachedDenylist
 	AllowOrigins map[string]struct{}
 }
 
@@ -113,6 +118,18 @@ func Main() (s Service, err error) {
 			if err != nil {
 				return
 			}
+		case "purge":
+			if s.DeletedRetention == 0 {
+				// like in the auth service, a retention of zero keeps soft-deleted rows
+				log.Warn().Msg("purging is disabled by DELETED_RETENTION=0")
+				return
+			}
+			var n int64
+			n, err = s.DBAPI.PurgeDeleted(context.Background(), time.Now().Add(-s.DeletedRetention))
+			if err != nil {
+				return
+			}
+			log.Info().Int64("count", n).Msg("purged soft-deleted events and workshops")
 		default:
 			err = errors.Errorf("invalid command: %s", os.Args[1])
 			return
@@ -145,8 +162,12 @@ func Load() (env Env, err error) {
 	}
 
 	env.DBEnv = service.LoadDBEnv(envs)
-	env.TokenEnv = tokens.Load(envs, ServiceName)
+	env.ValidationEnv, err = tokens.LoadValidationEnv(envs)
+	if err != nil {
+		return
+	}
 	env.AllowOrigins = strings.Split(envs["ALLOW_ORIGINS"], ",")
+	env.DeletedRetention, err = lib.Duration(envs, "DELETED_RETENTION", 30*24*time.Hour)
 	return
 }
 
@@ -161,10 +182,9 @@ func (env Env) Setup() (s Service, err error) {
 	}
 	s.DBAPI = &dbAPI{DB: s.DB}
 
-	s.TokenAPI, err = tokens.Setup(s.TokenEnv)
-	if err != nil {
-		return
-	}
+	s.Keys = tokens.NewRemoteKeySet(s.ValidationEnv)
+	s.APIKeys = tokens.NewRemoteAPIKeyVerifier(s.APIKeyURL, s.APIKeyCacheTTL)
+	s.Denylist = tokens.NewCachedDenylist(tokens.NewRemoteDenylistStore(s.DenylistURL), s.DenylistSyncInterval)
 
 	s.HTTPServer = service.SetupHTTP(env.HTTPEnv, router(&s))
 
@@ -183,5 +203,7 @@ func (env Env) Setup() (s Service, err error) {
 }
 
 func (s *Service) Run(ctx context.Context) (err error) {
+	go s.Keys.Run(ctx)
+	go s.Denylist.Run(ctx)
 	return s.Serve(ctx)
 }
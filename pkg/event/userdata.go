@@ -0,0 +1,110 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/userdata"
+)
+
+// UserData is the data the event service holds about a user
+type UserData struct {
+	OwnedEvents    []OwnedEvent    `json:"ownedEvents"`
+	Participations []Participation `json:"participations"`
+}
+
+// OwnedEvent is an event organized by the user
+type OwnedEvent struct {
+	ID         string          `json:"id"`
+	InstanceID string          `json:"instanceID"`
+	Info       json.RawMessage `json:"info"`
+	Starts     time.Time       `json:"starts"`
+	Ends       *time.Time      `json:"ends,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	DeletedAt  *time.Time      `json:"deletedAt,omitempty"`
+}
+
+// Participation is the user's entry in the participants of a workshop
+type Participation struct {
+	WorkshopID  string          `json:"workshopID"`
+	EventID     string          `json:"eventID"`
+	Info        json.RawMessage `json:"info"`
+	Starts      time.Time       `json:"starts"`
+	Ends        *time.Time      `json:"ends,omitempty"`
+	Participant json.RawMessage `json:"participant"`
+	DeletedAt   *time.Time      `json:"deletedAt,omitempty"`
+}
+
+// ExportUser returns the data held about the user of the request path.
+func (s *Service) ExportUser(ctx *gin.Context) (data UserData, err error) {
+	err = checkUserDataAccess(ctx)
+	if err != nil {
+		return
+	}
+	userID := ctx.Param("id")
+	events, workshops, err := s.DBAPI.UserData(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	data.OwnedEvents = make([]OwnedEvent, 0, len(events))
+	for _, e := range events {
+		data.OwnedEvents = append(data.OwnedEvents, OwnedEvent{
+			ID:         e.ID,
+			InstanceID: e.InstanceID,
+			Info:       json.RawMessage(e.Info),
+			Starts:     e.Starts,
+			Ends:       e.Ends.Ptr(),
+			CreatedAt:  e.CreatedAt,
+			DeletedAt:  e.DeletedAt.Ptr(),
+		})
+	}
+	data.Participations = make([]Participation, 0, len(workshops))
+	for _, w := range workshops {
+		var participants map[string]json.RawMessage
+		err = json.Unmarshal(w.Participants, &participants)
+		if err != nil {
+			err = errors.WithStack(err)
+			return
+		}
+		data.Participations = append(data.Participations, Participation{
+			WorkshopID:  w.ID,
+			EventID:     w.EventID,
+			Info:        json.RawMessage(w.Info),
+			Starts:      w.Starts,
+			Ends:        w.Ends.Ptr(),
+			Participant: participants[userID],
+			DeletedAt:   w.DeletedAt.Ptr(),
+		})
+	}
+	return
+}
+
+// EraseUser removes the user of the request path from all workshops.
+// Events organized by the user keep referring to the user, who is anonymized by the auth service.
+func (s *Service) EraseUser(ctx *gin.Context) error {
+	err := checkUserDataAccess(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := s.DBAPI.EraseUser(ctx, ctx.Param("id"))
+	if err != nil {
+		return err
+	}
+	log.Debug().Str("user", ctx.Param("id")).Int64("workshops", n).Msg("user erased")
+	return nil
+}
+
+// checkUserDataAccess only lets super admins with the user data scope, i.e. the auth service, access data of arbitrary users.
+func checkUserDataAccess(ctx *gin.Context) error {
+	// the scope has to be granted explicitly, as tokens of users without scopes grant all scopes
+	if !roles.CanActIn(ctx, roles.RoleSuperAdmin) || !roles.HasExplicitScope(ctx, userdata.Scope) {
+		r, _ := roles.FromContext(ctx)
+		return errors.Wrapf(ErrUnauthorized, "'%s' can not access user data", r)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+	"github.com/smartnuance/saas-kit/pkg/lib/userdata"
+)
+
+func (s *MySuite) Test_eraseUserRequiresExplicitScope(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+	userID := xid.New().String()
+
+	erase := func(scopes string) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodDelete, userdata.Path+"/"+userID, nil)
+		ctx.Params = gin.Params{{Key: "id", Value: userID}}
+		ctx.Set(roles.UserKey, xid.New().String())
+		ctx.Set(roles.RoleKey, roles.RoleSuperAdmin)
+		ctx.Set(roles.ScopeKey, scopes)
+		return service.EraseUser(ctx)
+	}
+
+	// when/then tokens of super admins without scopes can not erase users
+	assert.True(errors.Is(erase(""), ErrUnauthorized))
+	assert.True(errors.Is(erase(ScopeWorkshopsWrite), ErrUnauthorized))
+
+	// when/then the auth service's token with the user data scope can
+	mock.EXPECT().
+		EraseUser(gomock.Any(), gomock.Eq(userID)).
+		Return(int64(1), nil)
+	assert.CmpNoError(erase(userdata.Scope))
+}
@@ -13,12 +13,23 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// ScopeWorkshopsRead authorizes service accounts and API keys to list workshops
+	ScopeWorkshopsRead = "workshops:read"
+	// ScopeWorkshopsWrite authorizes service accounts and API keys to create and delete workshops
+	ScopeWorkshopsWrite = "workshops:write"
+)
+
 func (s *Service) CreateWorkshop(ctx *gin.Context) (workshop *m.Workshop, err error) {
 	// Check permission
 	if !roles.CanActIn(ctx, roles.RoleEventOrganizer) {
 		err = errors.WithStack(ErrUnauthorized)
 		return
 	}
+	if !roles.HasScope(ctx, ScopeWorkshopsWrite) {
+		err = errors.Wrapf(ErrUnauthorized, "missing scope %s", ScopeWorkshopsWrite)
+		return
+	}
 
 	jsonData, err := ioutil.ReadAll(ctx.Request.Body)
 	if err != nil {
@@ -85,6 +96,10 @@ func (s *Service) ListWorkshops(ctx *gin.Context) (list WorkshopList, err error)
 		err = errors.Wrapf(ErrUnauthorized, "'%s' can not act as %s", r, roles.RoleEventOrganizer)
 		return
 	}
+	if !roles.HasScope(ctx, ScopeWorkshopsRead) {
+		err = errors.Wrapf(ErrUnauthorized, "missing scope %s", ScopeWorkshopsRead)
+		return
+	}
 
 	var instanceID string
 	instanceID, err = roles.Instance(ctx)
@@ -108,6 +123,10 @@ func (s *Service) DeleteWorkshop(ctx *gin.Context) (err error) {
 		err = errors.Wrapf(ErrUnauthorized, "'%s' can not act as %s", r, roles.RoleEventOrganizer)
 		return
 	}
+	if !roles.HasScope(ctx, ScopeWorkshopsWrite) {
+		err = errors.Wrapf(ErrUnauthorized, "missing scope %s", ScopeWorkshopsWrite)
+		return
+	}
 
 	_, err = roles.Instance(ctx)
 	if err != nil {
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/rs/xid"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+// scopedContext is the context of a request authorized for an event organizer of the instance, limited to the scopes if not empty.
+func scopedContext(method, path, instanceID, scopes string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(method, path, nil)
+	ctx.Set(roles.UserKey, xid.New().String())
+	ctx.Set(roles.InstanceKey, instanceID)
+	ctx.Set(roles.RoleKey, roles.RoleEventOrganizer)
+	ctx.Set(roles.ScopeKey, scopes)
+	return ctx
+}
+
+func (s *MySuite) Test_workshopScopes(assert, require *td.T) {
+	// given
+	ctrl := gomock.NewController(require.TB)
+	mock := NewMockDBAPI(ctrl)
+	service := Service{DBAPI: mock}
+	instanceID := xid.New().String()
+
+	// when/then a read-only token lists workshops
+	mock.EXPECT().
+		ListWorkshops(gomock.Any(), gomock.Eq(instanceID), gomock.Any()).
+		Return(WorkshopList{}, nil)
+	_, err := service.ListWorkshops(scopedContext(http.MethodGet, "/workshop/list", instanceID, ScopeWorkshopsRead))
+	assert.CmpNoError(err)
+
+	// when/then a read-only token can not delete workshops
+	ctx := scopedContext(http.MethodDelete, "/workshop/1", instanceID, ScopeWorkshopsRead)
+	ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+	err = service.DeleteWorkshop(ctx)
+	assert.True(errors.Is(err, ErrUnauthorized))
+
+	// when/then a write-only token can not list workshops
+	_, err = service.ListWorkshops(scopedContext(http.MethodGet, "/workshop/list", instanceID, ScopeWorkshopsWrite))
+	assert.True(errors.Is(err, ErrUnauthorized))
+
+	// when/then tokens of users are not limited by scopes
+	mock.EXPECT().
+		DeleteWorkshop(gomock.Any(), gomock.Eq("1")).
+		Return(nil)
+	ctx = scopedContext(http.MethodDelete, "/workshop/1", instanceID, "")
+	ctx.Params = gin.Params{{Key: "id", Value: "1"}}
+	assert.CmpNoError(service.DeleteWorkshop(ctx))
+}
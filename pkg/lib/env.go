@@ -2,6 +2,8 @@ package lib
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/friendsofgo/errors"
 	"github.com/joho/godotenv"
@@ -59,3 +61,29 @@ func EnvMux(serviceName string) (envs map[string]string, err error) {
 
 	return
 }
+
+// Duration parses the duration stored under key in envs, falling back to fallback if the key is not set.
+func Duration(envs map[string]string, key string, fallback time.Duration) (time.Duration, error) {
+	v, ok := envs[key]
+	if !ok || v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid duration for %s", key)
+	}
+	return d, nil
+}
+
+// Int parses the integer stored under key in envs, falling back to fallback if the key is not set.
+func Int(envs map[string]string, key string, fallback int) (int, error) {
+	v, ok := envs[key]
+	if !ok || v == "" {
+		return fallback, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid integer for %s", key)
+	}
+	return i, nil
+}
@@ -0,0 +1,150 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	LogSenderType  = "log"
+	FileSenderType = "file"
+	SMTPSenderType = "smtp"
+)
+
+// Message is a plain text mail.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers mails. Implementations have to be safe for concurrent use.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type MailEnv struct {
+	// SenderType selects the Sender implementation; defaults to LogSenderType
+	SenderType string
+	// From is the sender address of all mails
+	From string
+	// Dir is the directory mails are written to by the FileSender
+	Dir string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+}
+
+func Load(envs map[string]string) MailEnv {
+	senderType := envs["MAIL_SENDER"]
+	if len(senderType) == 0 {
+		senderType = LogSenderType
+	}
+	return MailEnv{
+		SenderType:   senderType,
+		From:         envs["MAIL_FROM"],
+		Dir:          envs["MAIL_DIR"],
+		SMTPHost:     envs["SMTP_HOST"],
+		SMTPPort:     envs["SMTP_PORT"],
+		SMTPUser:     envs["SMTP_USER"],
+		SMTPPassword: envs["SMTP_PASSWORD"],
+	}
+}
+
+func Setup(env MailEnv) (Sender, error) {
+	switch env.SenderType {
+	case LogSenderType:
+		return LogSender{}, nil
+	case FileSenderType:
+		err := os.MkdirAll(env.Dir, 0o755)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create mail directory at %s", env.Dir)
+		}
+		return FileSender{Dir: env.Dir, From: env.From}, nil
+	case SMTPSenderType:
+		return SMTPSender{MailEnv: env}, nil
+	default:
+		return nil, errors.Errorf("invalid mail sender: %s", env.SenderType)
+	}
+}
+
+// LogSender is a stand-in for development that only logs mails.
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, msg Message) error {
+	log.Info().Str("to", msg.To).Str("subject", msg.Subject).Msg(msg.Body)
+	return nil
+}
+
+// FileSender is a stand-in for development and tests that writes each mail to a separate file in Dir.
+type FileSender struct {
+	Dir  string
+	From string
+}
+
+func (s FileSender) Send(ctx context.Context, msg Message) error {
+	content, err := format(s.From, msg)
+	if err != nil {
+		return err
+	}
+	p := filepath.Join(s.Dir, xid.New().String()+".eml")
+	err = ioutil.WriteFile(p, content, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "could not write mail to %s", p)
+	}
+	return nil
+}
+
+// SMTPSender delivers mails over an SMTP server using plain authentication.
+type SMTPSender struct {
+	MailEnv
+}
+
+func (s SMTPSender) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if len(s.SMTPUser) > 0 {
+		auth = smtp.PlainAuth("", s.SMTPUser, s.SMTPPassword, s.SMTPHost)
+	}
+	content, err := format(s.From, msg)
+	if err != nil {
+		return err
+	}
+	err = smtp.SendMail(s.SMTPHost+":"+s.SMTPPort, auth, s.From, []string{msg.To}, content)
+	if err != nil {
+		return errors.Wrap(err, "sending mail failed")
+	}
+	return nil
+}
+
+// format renders the mail with its headers. Line breaks in header values are rejected, so they can not inject further headers.
+func format(from string, msg Message) ([]byte, error) {
+	for _, v := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.WithStack(ErrInvalidHeader)
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+	return []byte(b.String()), nil
+}
+
+var (
+	ErrInvalidHeader = errors.New("mail header contains line breaks")
+)
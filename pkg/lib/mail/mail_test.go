@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"testing"
+
+	"github.com/friendsofgo/errors"
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+func (s *MySuite) Test_formatRejectsHeaderInjection(assert, require *td.T) {
+	// given
+	msg := Message{To: "jane@example.com", Subject: "Welcome", Body: "Hello\r\nJane"}
+
+	// when
+	content, err := format("noreply@example.com", msg)
+
+	// then line breaks are only allowed in the body
+	require.CmpNoError(err)
+	assert.Contains(string(content), "To: jane@example.com\r\nSubject: Welcome\r\n")
+
+	for _, msg := range []Message{
+		{To: "jane@example.com\r\nBcc: victim@example.com", Subject: "Welcome"},
+		{To: "jane@example.com", Subject: "Welcome\nBcc: victim@example.com"},
+	} {
+		_, err = format("noreply@example.com", msg)
+		assert.True(errors.Is(err, ErrInvalidHeader))
+	}
+}
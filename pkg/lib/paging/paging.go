@@ -57,6 +57,24 @@ func (f *Paging_Current) Query(query *url.Values) {
 	pageQuery(query, f)
 }
 
+// Loaded describes the loaded page of n items from first to last and the pages around it.
+func Loaded(page Page, first, last string, n int) *Paging {
+	p := &Paging{Cur: &Paging_Current{PageSize: int32(n)}}
+	if n == 0 {
+		return p
+	}
+	p.Cur.Start = first
+	p.Cur.End = last
+	if _, isFirst := page.(*Paging_First); !isFirst {
+		p.Prev = &Paging_Previous{End: first, PageSize: int32(page.Size())}
+	}
+	isLast := n < page.Size()
+	if !isLast {
+		p.Next = &Paging_Next{Start: last, PageSize: int32(page.Size())}
+	}
+	return p
+}
+
 func FromQuery(c *gin.Context) Page {
 	start := c.Query(PageStartQueryParam)
 	end := c.Query(PageEndQueryParam)
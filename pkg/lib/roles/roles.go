@@ -2,6 +2,8 @@ package roles
 
 import (
 	"container/list"
+	"strings"
+	"time"
 
 	"github.com/friendsofgo/errors"
 	"github.com/gin-gonic/gin"
@@ -14,6 +16,8 @@ const (
 	UserKey     = "user"
 	RoleKey     = "role"
 	InstanceKey = "instance"
+	ScopeKey    = "scope"
+	AuthTimeKey = "authTime"
 )
 
 type Role string
@@ -284,6 +288,38 @@ func Instance(ctx *gin.Context) (string, error) {
 	return instanceID_.(string), nil
 }
 
+// HasScope checks if the access token or API key grants the given scope.
+// Only service accounts and API keys are limited by scopes, tokens of users grant all scopes.
+func HasScope(ctx *gin.Context, scope string) bool {
+	scopes := ctx.GetString(ScopeKey)
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, s := range strings.Fields(scopes) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasExplicitScope checks if the access token or API key is limited to scopes including the given scope.
+// Unlike HasScope, tokens of users do not grant it, so it is suited for scopes only services are meant to have.
+func HasExplicitScope(ctx *gin.Context, scope string) bool {
+	for _, s := range strings.Fields(ctx.GetString(ScopeKey)) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthTime retrieves the time the user authenticated with credentials, if the access token tells it.
+func AuthTime(ctx *gin.Context) (time.Time, bool) {
+	authTime := ctx.GetTime(AuthTimeKey)
+	return authTime, !authTime.IsZero()
+}
+
 var (
 	ErrMissingUser      = errors.New("missing user")
 	ErrInvalidRole      = errors.New("invalid role provided")
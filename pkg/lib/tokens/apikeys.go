@@ -0,0 +1,141 @@
+package tokens
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+)
+
+const (
+	// APIKeyHeader is the header to authorize with an API key instead of a Bearer token
+	APIKeyHeader = "X-API-Key"
+	// APIKeyPrefix identifies API keys, e.g. for secret scanning
+	APIKeyPrefix = "snk_"
+	// APIKeyVerifyPath is the auth service's endpoint to verify API keys
+	APIKeyVerifyPath = "/apikeys/verify"
+
+	apiKeyIDLength = 20
+)
+
+// APIKeyClaims describe what the holder of an API key is authorized for, like the claims of an access token.
+type APIKeyClaims struct {
+	Subject   string     `json:"sub"`
+	Role      string     `json:"role"`
+	Instance  string     `json:"inst"`
+	Scope     string     `json:"scope,omitempty"`
+	ExpiresAt *time.Time `json:"exp,omitempty"`
+}
+
+// APIKeyVerifier verifies API keys presented in the APIKeyHeader.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*APIKeyClaims, error)
+}
+
+// FormatAPIKey builds an API key from the key's ID and secret.
+// The ID is part of the key, so the key can be looked up by ID and the secret compared to a stored digest.
+func FormatAPIKey(id, secret string) string {
+	return APIKeyPrefix + id + "_" + secret
+}
+
+// ParseAPIKey extracts the ID of an API key.
+func ParseAPIKey(key string) (id string, err error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return "", errors.Wrap(ErrInvalidAPIKey, "missing prefix")
+	}
+	rest := key[len(APIKeyPrefix):]
+	if len(rest) <= apiKeyIDLength+1 || rest[apiKeyIDLength] != '_' {
+		return "", errors.Wrap(ErrInvalidAPIKey, "malformed key")
+	}
+	return rest[:apiKeyIDLength], nil
+}
+
+// RemoteAPIKeyVerifier verifies API keys at the auth service and caches the results.
+// Deleted or downgraded keys are accepted until their cache entry expires.
+type RemoteAPIKeyVerifier struct {
+	// URL is the auth service's endpoint to verify API keys
+	URL    string
+	Client *http.Client
+	// CacheTTL is the time a verified key is accepted without asking the auth service again
+	CacheTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cachedAPIKey
+}
+
+type cachedAPIKey struct {
+	claims   *APIKeyClaims
+	cachedAt time.Time
+}
+
+func NewRemoteAPIKeyVerifier(url string, cacheTTL time.Duration) *RemoteAPIKeyVerifier {
+	return &RemoteAPIKeyVerifier{
+		URL:      url,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+		CacheTTL: cacheTTL,
+		entries:  map[[sha256.Size]byte]cachedAPIKey{},
+	}
+}
+
+// VerifyAPIKey returns the claims of a valid API key.
+func (v *RemoteAPIKeyVerifier) VerifyAPIKey(ctx context.Context, key string) (*APIKeyClaims, error) {
+	_, err := ParseAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// cache by digest to not keep usable keys in memory
+	digest := sha256.Sum256([]byte(key))
+	now := time.Now()
+	v.mu.Lock()
+	entry, ok := v.entries[digest]
+	v.mu.Unlock()
+	if ok && now.Sub(entry.cachedAt) < v.CacheTTL && !expired(entry.claims, now) {
+		return entry.claims, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(APIKeyHeader, key)
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "verifying api key failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, errors.WithStack(ErrInvalidAPIKey)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("verifying api key failed with status %d", resp.StatusCode)
+	}
+	var claims APIKeyClaims
+	err = json.NewDecoder(resp.Body).Decode(&claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid api key claims")
+	}
+
+	v.mu.Lock()
+	for d, e := range v.entries {
+		if now.Sub(e.cachedAt) >= v.CacheTTL {
+			delete(v.entries, d)
+		}
+	}
+	v.entries[digest] = cachedAPIKey{claims: &claims, cachedAt: now}
+	v.mu.Unlock()
+	return &claims, nil
+}
+
+func expired(claims *APIKeyClaims, now time.Time) bool {
+	return claims.ExpiresAt != nil && !now.Before(*claims.ExpiresAt)
+}
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
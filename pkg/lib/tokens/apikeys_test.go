@@ -0,0 +1,82 @@
+package tokens
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxatome/go-testdeep/td"
+	"github.com/smartnuance/saas-kit/pkg/lib/roles"
+)
+
+// apiKeyStandIn verifies API keys like the auth service does.
+type apiKeyStandIn struct {
+	mu       sync.Mutex
+	keys     map[string]APIKeyClaims
+	verifies int
+}
+
+func (a *apiKeyStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.verifies++
+	claims, ok := a.keys[r.Header.Get(APIKeyHeader)]
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(claims)
+}
+
+func (a *apiKeyStandIn) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.verifies
+}
+
+func (s *MySuite) Test_AuthorizeAPIKey(assert, require *td.T) {
+	// given
+	key := FormatAPIKey("c8u3rrqk4cl8qbvk7bq0", "secret")
+	standIn := &apiKeyStandIn{keys: map[string]APIKeyClaims{
+		key: {Subject: "user", Instance: "instance", Role: string(roles.RoleTeacher), Scope: "workshops:read"},
+	}}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+	verifier := NewRemoteAPIKeyVerifier(server.URL, time.Hour)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", AuthorizeJWT(StaticKeySet{}, "auth", "test", verifier), func(ctx *gin.Context) {
+		userID, _ := roles.User(ctx)
+		role, _ := roles.FromContext(ctx)
+		ctx.JSON(http.StatusOK, gin.H{"user": userID, "role": role, "read": roles.HasScope(ctx, "workshops:read"), "write": roles.HasScope(ctx, "workshops:write")})
+	})
+	get := func(apiKey string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(APIKeyHeader, apiKey)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// when
+	w := get(key)
+
+	// then the key authorizes like an access token
+	require.Cmp(w.Code, http.StatusOK)
+	var got map[string]interface{}
+	require.CmpNoError(json.Unmarshal(w.Body.Bytes(), &got))
+	assert.JSON(got, `{"user":"user","role":"teacher","read":true,"write":false}`, nil)
+
+	// verified keys are cached
+	assert.Cmp(get(key).Code, http.StatusOK)
+	assert.Cmp(standIn.count(), 1)
+
+	// unknown and malformed keys are rejected, the latter without asking the auth service
+	assert.Cmp(get(FormatAPIKey("c8u3rrqk4cl8qbvk7bq1", "secret")).Code, http.StatusUnauthorized)
+	assert.Cmp(get("secret").Code, http.StatusUnauthorized)
+	assert.Cmp(standIn.count(), 2)
+}
@@ -0,0 +1,131 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// DenylistPath is the auth service's endpoint listing denied access tokens
+const DenylistPath = "/denylist"
+
+// DenylistResponse lists the IDs of denied access tokens that have not yet expired together with their expiry.
+type DenylistResponse struct {
+	Denied map[string]time.Time `json:"denied"`
+}
+
+// Denylist decides if an access token was revoked before it expired.
+type Denylist interface {
+	Denied(jti string) bool
+}
+
+// DenylistStore loads the IDs (jti claim) of revoked access tokens that have not yet expired together with their expiry.
+type DenylistStore interface {
+	LoadDenylist(ctx context.Context) (map[string]time.Time, error)
+}
+
+// CachedDenylist keeps the denylist of a store in memory, so checking a token does not hit the store.
+// The cache is synced periodically by Run and on demand by Sync, e.g. after a revocation.
+type CachedDenylist struct {
+	Store DenylistStore
+	// SyncInterval is the interval the cache is synced with the store in the background
+	SyncInterval time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]time.Time
+}
+
+func NewCachedDenylist(store DenylistStore, syncInterval time.Duration) *CachedDenylist {
+	return &CachedDenylist{
+		Store:        store,
+		SyncInterval: syncInterval,
+		entries:      map[string]time.Time{},
+	}
+}
+
+// Denied checks if the access token with the given ID is denied.
+// Tokens issued without ID are never denied.
+func (d *CachedDenylist) Denied(jti string) bool {
+	if jti == "" {
+		return false
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	expiresAt, ok := d.entries[jti]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// Sync replaces the cache by the store's denylist.
+func (d *CachedDenylist) Sync(ctx context.Context) error {
+	entries, err := d.Store.LoadDenylist(ctx)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.entries = entries
+	d.mu.Unlock()
+	return nil
+}
+
+// Run syncs the cache periodically until ctx is done.
+func (d *CachedDenylist) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.SyncInterval)
+	defer ticker.Stop()
+	for {
+		err := d.Sync(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("syncing denylist failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RemoteDenylistStore loads the denylist from the auth service,
+// so services validating tokens statelessly can reject revoked access tokens by a CachedDenylist.
+type RemoteDenylistStore struct {
+	// URL is the auth service's DenylistPath endpoint
+	URL    string
+	Client *http.Client
+}
+
+func NewRemoteDenylistStore(url string) *RemoteDenylistStore {
+	return &RemoteDenylistStore{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *RemoteDenylistStore) LoadDenylist(ctx context.Context) (map[string]time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching denylist failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetching denylist failed with status %d", resp.StatusCode)
+	}
+
+	var denylist DenylistResponse
+	err = json.NewDecoder(resp.Body).Decode(&denylist)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid denylist")
+	}
+	if denylist.Denied == nil {
+		denylist.Denied = map[string]time.Time{}
+	}
+	return denylist.Denied, nil
+}
@@ -0,0 +1,99 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+// denylistStore is an in-memory stand-in for the denylist table of the auth service.
+type denylistStore map[string]time.Time
+
+func (s denylistStore) LoadDenylist(ctx context.Context) (map[string]time.Time, error) {
+	denylist := map[string]time.Time{}
+	for jti, expiresAt := range s {
+		denylist[jti] = expiresAt
+	}
+	return denylist, nil
+}
+
+func (s *MySuite) Test_AuthorizeJWTWithDenylist(assert, require *td.T) {
+	// given
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.CmpNoError(err)
+	kid := KeyID(&key.PublicKey)
+	keys := StaticKeySet{kid: &key.PublicKey}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &AccessTokenClaims{
+		Purpose: AccessPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			Subject:   "user",
+			Issuer:    "auth",
+			Audience:  []string{"test"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	token.Header["kid"] = kid
+	accessToken, err := token.SignedString(key)
+	require.CmpNoError(err)
+
+	store := denylistStore{}
+	denylist := NewCachedDenylist(store, time.Hour)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/stateless", AuthorizeJWT(keys, "auth", "test", nil), func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	router.GET("/denylist", AuthorizeJWTWithDenylist(keys, "auth", "test", denylist, nil), func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", BearerSchema+accessToken)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// when/then token is accepted before denied
+	assert.Cmp(get("/denylist"), http.StatusOK)
+
+	// when token is denied
+	store["jti"] = time.Now().Add(time.Minute)
+	require.CmpNoError(denylist.Sync(context.Background()))
+
+	// then only services opting in reject it
+	assert.Cmp(get("/denylist"), http.StatusUnauthorized)
+	assert.Cmp(get("/stateless"), http.StatusOK)
+}
+
+func (s *MySuite) Test_RemoteDenylistStore(assert, require *td.T) {
+	// given the auth service's denylist
+	store := denylistStore{"jti": time.Now().Add(time.Minute)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		denied, err := store.LoadDenylist(r.Context())
+		assert.CmpNoError(err)
+		json.NewEncoder(w).Encode(DenylistResponse{Denied: denied})
+	}))
+	defer srv.Close()
+	denylist := NewCachedDenylist(NewRemoteDenylistStore(srv.URL+DenylistPath), time.Hour)
+
+	// when
+	err := denylist.Sync(context.Background())
+
+	// then the tokens denied by the auth service are denied
+	require.CmpNoError(err)
+	assert.True(denylist.Denied("jti"))
+	assert.False(denylist.Denied("other"))
+
+	// and a failed sync keeps them denied
+	srv.Close()
+	assert.CmpError(denylist.Sync(context.Background()))
+	assert.True(denylist.Denied("jti"))
+}
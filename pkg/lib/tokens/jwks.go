@@ -0,0 +1,111 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/friendsofgo/errors"
+)
+
+// KeySet looks up validation keys by their key ID (the kid header of a JWT).
+type KeySet interface {
+	Key(ctx context.Context, kid string) (*rsa.PublicKey, error)
+}
+
+// StaticKeySet is a fixed set of validation keys indexed by key ID.
+type StaticKeySet map[string]*rsa.PublicKey
+
+// Key returns the validation key with the given key ID.
+// Tokens issued before key IDs were introduced carry no kid; they are only accepted while the set holds a single key.
+func (s StaticKeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	if kid == "" && len(s) == 1 {
+		for _, key := range s {
+			return key, nil
+		}
+	}
+	key, ok := s[kid]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownKey, "kid '%s'", kid)
+	}
+	return key, nil
+}
+
+// JWK is the JSON web key representation of a RSA public key, see RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// JWKS is a JSON web key set as served under /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK encodes a RSA public key used to validate RS256 signatures.
+func NewJWK(kid string, key *rsa.PublicKey) JWK {
+	return JWK{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: "RS256",
+		KeyID:     kid,
+		N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// PublicKey decodes the RSA public key.
+func (k JWK) PublicKey() (*rsa.PublicKey, error) {
+	if k.KeyType != "RSA" {
+		return nil, errors.Errorf("unsupported key type %s", k.KeyType)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid modulus")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// KeySet decodes all RSA keys of the set.
+func (s JWKS) KeySet() (StaticKeySet, error) {
+	keys := StaticKeySet{}
+	for _, jwk := range s.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key %s", jwk.KeyID)
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys, nil
+}
+
+// KeyID derives a stable key ID from a RSA public key by its JWK thumbprint, see RFC 7638.
+func KeyID(key *rsa.PublicKey) string {
+	jwk := NewJWK("", key)
+	// members in lexicographic order and without whitespace as required for the thumbprint
+	b, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{jwk.E, jwk.KeyType, jwk.N})
+	d := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(d[:])
+}
+
+var (
+	ErrUnknownKey = errors.New("unknown validation key")
+)
@@ -0,0 +1,209 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/smartnuance/saas-kit/pkg/lib"
+	"golang.org/x/sync/singleflight"
+)
+
+const JWKSPath = "/.well-known/jwks.json"
+
+// ValidationEnv configures services that only validate tokens issued by the auth service.
+type ValidationEnv struct {
+	// JWKSURL is the URL of the auth service's key set
+	JWKSURL string
+	// RefreshInterval is the interval the key set is refetched in the background
+	RefreshInterval time.Duration
+	// Issuer is the expected issuer of JWT tokens; defaults to "auth"
+	Issuer string
+	// Audience is the expected audience of JWT tokens; defaults to DefaultAudience
+	Audience string
+	// APIKeyURL is the URL of the auth service's endpoint to verify API keys
+	APIKeyURL string
+	// APIKeyCacheTTL is the time a verified API key is accepted without asking the auth service again
+	APIKeyCacheTTL time.Duration
+	// DenylistURL is the URL of the auth service's list of denied access tokens
+	DenylistURL string
+	// DenylistSyncInterval is the interval the denylist is synced in the background
+	DenylistSyncInterval time.Duration
+}
+
+func LoadValidationEnv(envs map[string]string) (env ValidationEnv, err error) {
+	env.JWKSURL = envs["TOKEN_JWKS_URL"]
+	if len(env.JWKSURL) == 0 {
+		env.JWKSURL = "http://" + envs["AUTH_SERVICE_HOST"] + ":" + envs["AUTH_SERVICE_PORT"] + JWKSPath
+	}
+	env.APIKeyURL = envs["TOKEN_APIKEY_URL"]
+	if len(env.APIKeyURL) == 0 {
+		env.APIKeyURL = "http://" + envs["AUTH_SERVICE_HOST"] + ":" + envs["AUTH_SERVICE_PORT"] + APIKeyVerifyPath
+	}
+	env.DenylistURL = envs["TOKEN_DENYLIST_URL"]
+	if len(env.DenylistURL) == 0 {
+		env.DenylistURL = "http://" + envs["AUTH_SERVICE_HOST"] + ":" + envs["AUTH_SERVICE_PORT"] + DenylistPath
+	}
+	env.Issuer = envs["TOKEN_ISSUER"]
+	if len(env.Issuer) == 0 {
+		env.Issuer = "auth"
+	}
+	env.Audience = envs["TOKEN_AUDIENCE"]
+	if len(env.Audience) == 0 {
+		env.Audience = lib.DefaultAudience
+	}
+	env.RefreshInterval, err = lib.Duration(envs, "TOKEN_JWKS_REFRESH_INTERVAL", 10*time.Minute)
+	if err != nil {
+		return
+	}
+	env.APIKeyCacheTTL, err = lib.Duration(envs, "TOKEN_APIKEY_CACHE_TTL", time.Minute)
+	if err != nil {
+		return
+	}
+	env.DenylistSyncInterval, err = lib.Duration(envs, "TOKEN_DENYLIST_SYNC_INTERVAL", 10*time.Second)
+	return
+}
+
+// minRefreshInterval limits refetching the key set when tokens with unknown kid are presented,
+// also while the auth service is unavailable.
+const minRefreshInterval = 10 * time.Second
+
+// fetchTimeout limits a fetch of the key set, which is not bound to the request that started it.
+const fetchTimeout = 10 * time.Second
+
+// RemoteKeySet fetches and caches the key set of the auth service.
+// Keys are refreshed periodically by Run and on demand when an unknown kid is looked up.
+// Concurrent fetches are deduplicated.
+type RemoteKeySet struct {
+	ValidationEnv
+	Client *http.Client
+
+	fetches singleflight.Group
+
+	mu   sync.RWMutex
+	keys StaticKeySet
+	// fetchedAt is the time of the last fetch, successful or not
+	fetchedAt time.Time
+	fetchErr  error
+}
+
+func NewRemoteKeySet(env ValidationEnv) *RemoteKeySet {
+	return &RemoteKeySet{
+		ValidationEnv: env,
+		Client:        &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Key returns the validation key with the given key ID, refetching the key set if the kid is unknown
+// and the last fetch is at least minRefreshInterval ago.
+func (s *RemoteKeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	key, err := s.cachedKey(ctx, kid)
+	if err == nil {
+		return key, nil
+	}
+
+	err = s.fetch(ctx, func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		// another request may have fetched the key set in the meantime
+		return time.Since(s.fetchedAt) >= minRefreshInterval
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.cachedKey(ctx, kid)
+}
+
+// cachedKey looks up the key in the last fetched key set, failing with the error of the last fetch if there is none.
+func (s *RemoteKeySet) cachedKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.keys == nil {
+		if s.fetchErr != nil {
+			return nil, s.fetchErr
+		}
+		return nil, errors.Wrapf(ErrUnknownKey, "kid '%s'", kid)
+	}
+	return s.keys.Key(ctx, kid)
+}
+
+// Refresh fetches the key set from the auth service.
+func (s *RemoteKeySet) Refresh(ctx context.Context) error {
+	return s.fetch(ctx, func() bool { return true })
+}
+
+// fetch fetches the key set if stale reports so, joining a fetch already in flight.
+// Waiting is canceled with ctx, the fetch itself is detached from it,
+// so a canceled request neither fails the fetch for the others joining it nor is recorded as a failed fetch.
+func (s *RemoteKeySet) fetch(ctx context.Context, stale func() bool) error {
+	ch := s.fetches.DoChan(s.JWKSURL, func() (interface{}, error) {
+		if !stale() {
+			return nil, nil
+		}
+		fetchCtx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+		keys, err := s.fetchKeys(fetchCtx)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.fetchedAt = time.Now()
+		s.fetchErr = err
+		if err == nil {
+			// failed fetches keep the keys of the last successful one
+			s.keys = keys
+		}
+		return nil, err
+	})
+	select {
+	case res := <-ch:
+		return res.Err
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	}
+}
+
+func (s *RemoteKeySet) fetchKeys(ctx context.Context) (StaticKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching key set failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetching key set failed with status %d", resp.StatusCode)
+	}
+
+	var jwks JWKS
+	err = json.NewDecoder(resp.Body).Decode(&jwks)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid key set")
+	}
+	return jwks.KeySet()
+}
+
+// Run refreshes the key set periodically until ctx is done.
+func (s *RemoteKeySet) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		err := s.Refresh(ctx)
+		if err != nil {
+			// the auth service might not be up yet, keys are fetched on demand then
+			log.Warn().Err(err).Str("url", s.JWKSURL).Msg("refreshing key set failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
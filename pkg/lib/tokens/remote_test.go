@@ -0,0 +1,191 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/maxatome/go-testdeep/helpers/tdsuite"
+	"github.com/maxatome/go-testdeep/td"
+)
+
+func TestMySuite(t *testing.T) {
+	tdsuite.Run(t, &MySuite{})
+}
+
+type MySuite struct{}
+
+// authStandIn serves a mutable key set like the auth service does.
+type authStandIn struct {
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches int
+	// down makes the key set unavailable
+	down bool
+	// release holds back responses until closed, if not nil
+	release chan struct{}
+}
+
+func (a *authStandIn) add(require *td.T) (kid string, key *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.CmpNoError(err)
+	kid = KeyID(&key.PublicKey)
+	a.mu.Lock()
+	a.keys[kid] = key
+	a.mu.Unlock()
+	return
+}
+
+func (a *authStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.fetches++
+	release := a.release
+	a.mu.Unlock()
+	if release != nil {
+		<-release
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	jwks := JWKS{}
+	for kid, key := range a.keys {
+		jwks.Keys = append(jwks.Keys, NewJWK(kid, &key.PublicKey))
+	}
+	json.NewEncoder(w).Encode(jwks)
+}
+
+func sign(require *td.T, kid string, key *rsa.PrivateKey) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &AccessTokenClaims{
+		Purpose: AccessPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user",
+			Issuer:    "auth",
+			Audience:  []string{"test"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	})
+	token.Header["kid"] = kid
+	s, err := token.SignedString(key)
+	require.CmpNoError(err)
+	return s
+}
+
+func (s *MySuite) Test_RemoteKeySet(assert, require *td.T) {
+	// given
+	auth := &authStandIn{keys: map[string]*rsa.PrivateKey{}}
+	kid, key := auth.add(require)
+	srv := httptest.NewServer(auth)
+	defer srv.Close()
+
+	keys := NewRemoteKeySet(ValidationEnv{JWKSURL: srv.URL + JWKSPath, RefreshInterval: time.Hour})
+	var claims AccessTokenClaims
+
+	// when/then keys are fetched on first use and cached
+	assert.CmpNoError(CheckAccessToken(context.Background(), sign(require, kid, key), &claims, keys, "auth", "test"))
+	assert.CmpNoError(CheckAccessToken(context.Background(), sign(require, kid, key), &claims, keys, "auth", "test"))
+	assert.Cmp(auth.fetches, 1)
+	assert.Cmp(claims.Subject, "user")
+
+	// when/then unknown kids are rejected without refetching too often
+	_, err := keys.Key(context.Background(), "unknown")
+	assert.CmpError(err)
+	assert.Cmp(auth.fetches, 1)
+
+	// when a new key is rotated in after the minimal refresh interval
+	newKid, newKey := auth.add(require)
+	keys.fetchedAt = keys.fetchedAt.Add(-minRefreshInterval)
+
+	// then the key set is refetched
+	assert.CmpNoError(CheckAccessToken(context.Background(), sign(require, newKid, newKey), &claims, keys, "auth", "test"))
+	assert.Cmp(auth.fetches, 2)
+}
+
+func (s *MySuite) Test_RemoteKeySetUnavailable(assert, require *td.T) {
+	// given an auth service that is down
+	auth := &authStandIn{keys: map[string]*rsa.PrivateKey{}, down: true}
+	kid, key := auth.add(require)
+	srv := httptest.NewServer(auth)
+	defer srv.Close()
+
+	keys := NewRemoteKeySet(ValidationEnv{JWKSURL: srv.URL + JWKSPath, RefreshInterval: time.Hour})
+	var claims AccessTokenClaims
+
+	// when/then failed fetches are not retried for every token
+	assert.CmpError(CheckAccessToken(context.Background(), sign(require, kid, key), &claims, keys, "auth", "test"))
+	assert.CmpError(CheckAccessToken(context.Background(), sign(require, kid, key), &claims, keys, "auth", "test"))
+	assert.Cmp(auth.fetches, 1)
+
+	// when the auth service is up again after the minimal refresh interval
+	auth.down = false
+	keys.fetchedAt = keys.fetchedAt.Add(-minRefreshInterval)
+
+	// then the key set is refetched
+	assert.CmpNoError(CheckAccessToken(context.Background(), sign(require, kid, key), &claims, keys, "auth", "test"))
+	assert.Cmp(auth.fetches, 2)
+
+	// and kept while further fetches fail
+	auth.down = true
+	assert.CmpError(keys.Refresh(context.Background()))
+	assert.CmpNoError(CheckAccessToken(context.Background(), sign(require, kid, key), &claims, keys, "auth", "test"))
+	assert.Cmp(auth.fetches, 3)
+}
+
+func (s *MySuite) Test_RemoteKeySetConcurrent(assert, require *td.T) {
+	// given an auth service that responds slowly
+	auth := &authStandIn{keys: map[string]*rsa.PrivateKey{}, release: make(chan struct{})}
+	kid, key := auth.add(require)
+	srv := httptest.NewServer(auth)
+	defer srv.Close()
+
+	keys := NewRemoteKeySet(ValidationEnv{JWKSURL: srv.URL + JWKSPath, RefreshInterval: time.Hour})
+	token := sign(require, kid, key)
+
+	// when many tokens are checked at the same time
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var claims AccessTokenClaims
+			errs <- CheckAccessToken(context.Background(), token, &claims, keys, "auth", "test")
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(auth.release)
+	wg.Wait()
+	close(errs)
+
+	// then the key set is fetched once for all of them
+	for err := range errs {
+		assert.CmpNoError(err)
+	}
+	assert.Cmp(auth.fetches, 1)
+
+	// and waiting for a fetch ends with the request
+	newKid, _ := auth.add(require)
+	keys.fetchedAt = keys.fetchedAt.Add(-minRefreshInterval)
+	auth.release = make(chan struct{})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := keys.Key(ctx, newKid)
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+
+	// but the fetch goes on for other requests
+	close(auth.release)
+	_, err = keys.Key(context.Background(), newKid)
+	assert.CmpNoError(err)
+	assert.Cmp(auth.fetches, 2)
+}
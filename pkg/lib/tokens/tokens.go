@@ -1,11 +1,10 @@
 package tokens
 
 import (
-	"crypto/rsa"
-
 	"github.com/friendsofgo/errors"
 	"github.com/smartnuance/saas-kit/pkg/lib/roles"
 
+	"context"
 	"fmt"
 	"net/http"
 
@@ -15,15 +14,21 @@ import (
 )
 
 const (
-	AccessPurpose  = "access"
-	RefreshPurpose = "refresh"
+	AccessPurpose    = "access"
+	RefreshPurpose   = "refresh"
+	ChallengePurpose = "2fa"
 )
 
 // AccessTokenClaims contain temporary authorization information.
+// The token ID is set as jti claim (RegisteredClaims.ID), so a token can be denied before it expires.
+// Access tokens of service accounts are limited to the space separated scopes of the scope claim.
 type AccessTokenClaims struct {
 	Purpose  string `json:"purp"`
 	Role     string `json:"role"`
 	Instance string `json:"inst"`
+	Scope    string `json:"scope,omitempty"`
+	// AuthTime is the time the user authenticated with credentials, kept when the session is refreshed
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -35,33 +40,72 @@ type RefreshTokenClaims struct {
 	jwt.RegisteredClaims
 }
 
+// ChallengeTokenClaims prove that a user passed the password step of a login requiring a second factor.
+type ChallengeTokenClaims struct {
+	Purpose  string `json:"purp"`
+	Instance string `json:"inst"`
+	jwt.RegisteredClaims
+}
+
 const BearerSchema = "Bearer "
 
 // AuthorizeJWT creates a middleware that checks the presence and validity of the authorization header.
 // If this middleware is installed on an endpoint, the authorization header is required.
 // When the header is present and the access token (JWT) inside is valid, user, role and instance are set to context.
-// The middleware creation is parameterized by service specifics.
-func AuthorizeJWT(validationKey *rsa.PublicKey, issuer, audience string) gin.HandlerFunc {
+// The middleware creation is parameterized by service specifics, the validation key is selected from validationKeys by the token's kid header.
+// Instead of the authorization header, an API key can be presented in the APIKeyHeader if apiKeys is not nil.
+// The check is stateless, revoked access tokens are accepted until they expire.
+func AuthorizeJWT(validationKeys KeySet, issuer, audience string, apiKeys APIKeyVerifier) gin.HandlerFunc {
+	return AuthorizeJWTWithDenylist(validationKeys, issuer, audience, nil, apiKeys)
+}
+
+// AuthorizeJWTWithDenylist works like AuthorizeJWT but additionally rejects access tokens on the denylist, if not nil.
+func AuthorizeJWTWithDenylist(validationKeys KeySet, issuer, audience string, denylist Denylist, apiKeys APIKeyVerifier) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		authHeader := ctx.GetHeader("Authorization")
-		if len(authHeader) <= len(BearerSchema) {
-			log.Error().Msgf("missing/invalid authorization header, needs to start with '%s'", BearerSchema)
-			ctx.AbortWithStatus(http.StatusUnauthorized)
-			return
-		}
-		tokenString := authHeader[len(BearerSchema):]
 		var claims AccessTokenClaims
-		err := CheckAccessToken(tokenString, &claims, validationKey, issuer, audience)
-		if err != nil {
-			log.Error().Err(err).Msg("")
-			ctx.AbortWithStatus(http.StatusUnauthorized)
-			return
+		var err error
+		if apiKey := ctx.GetHeader(APIKeyHeader); len(apiKey) > 0 && apiKeys != nil {
+			var keyClaims *APIKeyClaims
+			keyClaims, err = apiKeys.VerifyAPIKey(ctx, apiKey)
+			if err != nil {
+				log.Error().Err(err).Msg("")
+				ctx.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+			// API keys authorize like access tokens, so handlers need not distinguish them
+			claims.Subject = keyClaims.Subject
+			claims.Instance = keyClaims.Instance
+			claims.Role = keyClaims.Role
+			claims.Scope = keyClaims.Scope
+		} else {
+			authHeader := ctx.GetHeader("Authorization")
+			if len(authHeader) <= len(BearerSchema) {
+				log.Error().Msgf("missing/invalid authorization header, needs to start with '%s'", BearerSchema)
+				ctx.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+			tokenString := authHeader[len(BearerSchema):]
+			err = CheckAccessToken(ctx.Request.Context(), tokenString, &claims, validationKeys, issuer, audience)
+			if err != nil {
+				log.Error().Err(err).Msg("")
+				ctx.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+			if denylist != nil && denylist.Denied(claims.ID) {
+				log.Error().Str("jti", claims.ID).Msg("access token denied")
+				ctx.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
 		}
 
 		// set default context from JWT attributes
 		ctx.Set(roles.UserKey, claims.Subject)          // acting subject (immutable)
 		ctx.Set(roles.InstanceKey, claims.Instance)     // instance (switchable by super admins onld)
 		ctx.Set(roles.RoleKey, roles.Role(claims.Role)) // role (switchable if permission to)
+		ctx.Set(roles.ScopeKey, claims.Scope)           // scopes of service accounts and API keys (immutable)
+		if claims.AuthTime != nil {
+			ctx.Set(roles.AuthTimeKey, claims.AuthTime.Time) // time the user authenticated, to require recent authentication
+		}
 
 		// order matters: first check if default JWT role allows for instance switch if header is present
 		switchInstance := ctx.GetHeader(roles.InstanceHeader)
@@ -86,13 +130,8 @@ func AuthorizeJWT(validationKey *rsa.PublicKey, issuer, audience string) gin.Han
 	}
 }
 
-func CheckAccessToken(tokenStr string, claims *AccessTokenClaims, validationKey *rsa.PublicKey, issuer, audience string) error {
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, isvalid := token.Method.(*jwt.SigningMethodRSA); !isvalid {
-			return nil, fmt.Errorf("invalid token signing method: %s", token.Header["alg"])
-		}
-		return validationKey, nil
-	})
+func CheckAccessToken(ctx context.Context, tokenStr string, claims *AccessTokenClaims, validationKeys KeySet, issuer, audience string) error {
+	token, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc(ctx, validationKeys))
 	if err != nil {
 		return errors.Wrap(err, "invalid token")
 	}
@@ -113,13 +152,8 @@ func CheckAccessToken(tokenStr string, claims *AccessTokenClaims, validationKey
 	return nil
 }
 
-func CheckRefreshToken(tokenStr string, claims *RefreshTokenClaims, validationKey *rsa.PublicKey, issuer, audience string) error {
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, isvalid := token.Method.(*jwt.SigningMethodRSA); !isvalid {
-			return nil, fmt.Errorf("invalid token signing method: %s", token.Header["alg"])
-		}
-		return validationKey, nil
-	})
+func CheckRefreshToken(ctx context.Context, tokenStr string, claims *RefreshTokenClaims, validationKeys KeySet, issuer, audience string) error {
+	token, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc(ctx, validationKeys))
 	if err != nil {
 		return errors.Wrap(err, "invalid token")
 	}
@@ -139,3 +173,36 @@ func CheckRefreshToken(tokenStr string, claims *RefreshTokenClaims, validationKe
 	}
 	return nil
 }
+
+func CheckChallengeToken(ctx context.Context, tokenStr string, claims *ChallengeTokenClaims, validationKeys KeySet, issuer, audience string) error {
+	token, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc(ctx, validationKeys))
+	if err != nil {
+		return errors.Wrap(err, "invalid token")
+	}
+	if !token.Valid {
+		return errors.Wrap(err, "invalid token claims")
+	}
+	if claims.Purpose != ChallengePurpose {
+		return errors.Errorf("invalid token purpose %s", claims.Purpose)
+	}
+	ok := claims.VerifyIssuer(issuer, true)
+	if !ok {
+		return errors.New("invalid token issuer")
+	}
+	ok = claims.VerifyAudience(audience, true)
+	if !ok {
+		return errors.New("invalid token audience")
+	}
+	return nil
+}
+
+// keyFunc selects the validation key by the kid header of a token.
+func keyFunc(ctx context.Context, validationKeys KeySet) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		if _, isvalid := token.Method.(*jwt.SigningMethodRSA); !isvalid {
+			return nil, fmt.Errorf("invalid token signing method: %s", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return validationKeys.Key(ctx, kid)
+	}
+}
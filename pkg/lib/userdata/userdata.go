@@ -0,0 +1,81 @@
+package userdata
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/smartnuance/saas-kit/pkg/lib/tokens"
+)
+
+const (
+	// Path is the internal endpoint of a service holding data about users, followed by the ID of a user:
+	// GET exports the data held about the user, DELETE erases it.
+	Path = "/userdata"
+	// Scope authorizes the auth service's access tokens to export and erase user data in other services
+	Scope = "userdata"
+)
+
+// Client exports and erases the data another service holds about users.
+type Client struct {
+	// Name of the service, used as name of its part of an export
+	Name string
+	// URL is the service's Path endpoint
+	URL    string
+	Client *http.Client
+}
+
+func NewClient(name, url string) *Client {
+	return &Client{
+		Name:   name,
+		URL:    url,
+		Client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Export fetches the data the service holds about a user as JSON.
+// The access token has to authorize a super admin with Scope.
+func (c *Client) Export(ctx context.Context, accessToken, userID string) (json.RawMessage, error) {
+	resp, err := c.do(ctx, http.MethodGet, accessToken, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "exporting user data of %s failed", c.Name)
+	}
+	if !json.Valid(data) {
+		return nil, errors.Errorf("exporting user data of %s returned invalid JSON", c.Name)
+	}
+	return data, nil
+}
+
+// Erase anonymizes a user in the service. Erasing a user twice is no error.
+func (c *Client) Erase(ctx context.Context, accessToken, userID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, accessToken, userID)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (c *Client) do(ctx context.Context, method, accessToken, userID string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+"/"+userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", tokens.BearerSchema+accessToken)
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "requesting user data of %s failed", c.Name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("requesting user data of %s failed with status %d", c.Name, resp.StatusCode)
+	}
+	return resp, nil
+}